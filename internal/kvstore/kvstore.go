@@ -2,6 +2,7 @@ package kvstore
 
 import (
 	"github.com/jpfourny/papaya/v2/pkg/cmp"
+	"github.com/jpfourny/papaya/v2/pkg/hash"
 	"github.com/jpfourny/papaya/v2/pkg/opt"
 	"slices"
 )
@@ -29,6 +30,14 @@ func NewSorted[K any, V any](compare cmp.Comparer[K]) Store[K, V] {
 	}
 }
 
+// NewHashed creates a new Store backed by an open-addressing hash table, with keys hashed and compared by the given hash.Hasher.
+// Keys are iterated in insertion order.
+func NewHashed[K any, V any](hasher hash.Hasher[K]) Store[K, V] {
+	return &hashedStore[K, V]{
+		hasher: hasher,
+	}
+}
+
 // Maker is a factory function for creating a Store.
 type Maker[K, V any] func() Store[K, V]
 
@@ -47,6 +56,13 @@ func SortedMaker[K any, V any](compare cmp.Comparer[K]) Maker[K, V] {
 	}
 }
 
+// HashedMaker returns a Maker that calls NewHashed with the given hash.Hasher.
+func HashedMaker[K any, V any](hasher hash.Hasher[K]) Maker[K, V] {
+	return func() Store[K, V] {
+		return NewHashed[K, V](hasher)
+	}
+}
+
 // mappedStore provides an implementation of Store using the builtin map.
 // The key type K must be comparable.
 type mappedStore[K comparable, V any] map[K]V
@@ -134,3 +150,91 @@ func (s *sortedStore[K, V]) ForEachKey(yield func(K) bool) {
 		}
 	}
 }
+
+// hashedStore provides an implementation of Store using an open-addressing hash table with linear probing.
+// The keys are hashed and compared using the given hash.Hasher.
+// Entries are stored densely, in insertion order; the table holds indexes into the entries.
+type hashedStore[K any, V any] struct {
+	hasher hash.Hasher[K]
+	slots  []int // Index of entry + 1 for each slot; 0 if the slot is empty.
+	hashes []uint64
+	keys   []K
+	values []V
+}
+
+func (s *hashedStore[K, V]) Size() int {
+	return len(s.keys)
+}
+
+func (s *hashedStore[K, V]) Get(key K) opt.Optional[V] {
+	if len(s.slots) == 0 {
+		return opt.Empty[V]()
+	}
+	if _, i := s.find(key, s.hasher.Hash(key)); i >= 0 {
+		return opt.Of(s.values[i])
+	}
+	return opt.Empty[V]()
+}
+
+func (s *hashedStore[K, V]) Put(key K, value V) {
+	h := s.hasher.Hash(key)
+	if len(s.slots) > 0 {
+		if _, i := s.find(key, h); i >= 0 {
+			s.values[i] = value
+			return
+		}
+	}
+	if (len(s.keys)+1)*4 > len(s.slots)*3 { // Keep the load factor at most 3/4.
+		s.grow()
+	}
+	slot, _ := s.find(key, h)
+	s.hashes = append(s.hashes, h)
+	s.keys = append(s.keys, key)
+	s.values = append(s.values, value)
+	s.slots[slot] = len(s.keys)
+}
+
+// find returns the slot of the given key with the given hash, and the index of its entry.
+// If the key is absent, the index is -1 and the slot is the empty one where the key would be inserted.
+// The table must have at least one empty slot.
+func (s *hashedStore[K, V]) find(key K, h uint64) (slot, index int) {
+	mask := len(s.slots) - 1
+	for slot = int(h & uint64(mask)); ; slot = (slot + 1) & mask {
+		e := s.slots[slot]
+		if e == 0 {
+			return slot, -1
+		}
+		if s.hashes[e-1] == h && s.hasher.Equal(s.keys[e-1], key) {
+			return slot, e - 1
+		}
+	}
+}
+
+// grow doubles the size of the table (to a minimum of 8 slots) and reinserts the entries.
+func (s *hashedStore[K, V]) grow() {
+	s.slots = make([]int, max(8, len(s.slots)*2))
+	mask := len(s.slots) - 1
+	for i, h := range s.hashes {
+		slot := int(h & uint64(mask))
+		for s.slots[slot] != 0 {
+			slot = (slot + 1) & mask
+		}
+		s.slots[slot] = i + 1
+	}
+}
+
+func (s *hashedStore[K, V]) ForEach(yield func(K, V) bool) {
+	for i, k := range s.keys {
+		if !yield(k, s.values[i]) {
+			break
+		}
+	}
+}
+
+func (s *hashedStore[K, V]) ForEachKey(yield func(K) bool) {
+	for _, k := range s.keys {
+		if !yield(k) {
+			break
+		}
+	}
+}
@@ -3,6 +3,7 @@ package kvstore
 import (
 	"github.com/jpfourny/papaya/v2/internal/assert"
 	"github.com/jpfourny/papaya/v2/pkg/cmp"
+	"github.com/jpfourny/papaya/v2/pkg/hash"
 	"github.com/jpfourny/papaya/v2/pkg/opt"
 	"testing"
 )
@@ -201,3 +202,133 @@ func TestMappedStore_ForEach(t *testing.T) {
 		}
 	})
 }
+
+func TestHashedMaker(t *testing.T) {
+	m := HashedMaker[int, string](hash.Integer[int]())
+	if m == nil {
+		t.Fatalf("got %#v, want non-nil", m)
+	}
+	ks := m()
+	if ks == nil {
+		t.Fatalf("got %#v, want non-nil", ks)
+	}
+}
+
+func TestHashedStore_Get(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		ks := NewHashed[int, string](hash.Integer[int]())
+		got := ks.Get(0)
+		want := opt.Empty[string]()
+		if got != want {
+			t.Fatalf("got %#v, want %#v", got, want)
+		}
+	})
+
+	t.Run("non-empty", func(t *testing.T) {
+		ks := NewHashed[int, string](hash.Integer[int]())
+		ks.Put(1, "one")
+		ks.Put(2, "two")
+		ks.Put(1, "uno")
+		ks.Put(3, "three")
+		ks.Put(2, "dos")
+		got := ks.Get(1)
+		want := opt.Of("uno")
+		if got != want {
+			t.Fatalf("got %#v, want %#v", got, want)
+		}
+		got = ks.Get(2)
+		want = opt.Of("dos")
+		if got != want {
+			t.Fatalf("got %#v, want %#v", got, want)
+		}
+		got = ks.Get(3)
+		want = opt.Of("three")
+		if got != want {
+			t.Fatalf("got %#v, want %#v", got, want)
+		}
+		got = ks.Get(4)
+		want = opt.Empty[string]()
+		if got != want {
+			t.Fatalf("got %#v, want %#v", got, want)
+		}
+		if ks.Size() != 3 {
+			t.Fatalf("got size %d, want 3", ks.Size())
+		}
+	})
+
+	t.Run("collisions", func(t *testing.T) {
+		// A constant hash forces every key into the same probe sequence.
+		ks := NewHashed[[]int, int](hash.New(
+			func([]int) uint64 { return 42 },
+			hash.Slice(hash.Integer[int]()).Equal,
+		))
+		for i := 0; i < 100; i++ {
+			ks.Put([]int{i, i}, i)
+		}
+		for i := 0; i < 100; i++ {
+			if got := ks.Get([]int{i, i}); got != opt.Of(i) {
+				t.Fatalf("got %#v, want %#v", got, opt.Of(i))
+			}
+		}
+		if got := ks.Get([]int{100, 100}); got.Present() {
+			t.Fatalf("got %#v, want empty", got)
+		}
+	})
+
+	t.Run("growth", func(t *testing.T) {
+		ks := NewHashed[string, int](hash.String[string]())
+		for i := 0; i < 10000; i++ {
+			ks.Put(string(rune('a'+i%26))+string(rune(i)), i)
+		}
+		if ks.Size() != 10000 {
+			t.Fatalf("got size %d, want 10000", ks.Size())
+		}
+		for i := 0; i < 10000; i++ {
+			if got := ks.Get(string(rune('a'+i%26)) + string(rune(i))); got != opt.Of(i) {
+				t.Fatalf("got %#v, want %#v", got, opt.Of(i))
+			}
+		}
+	})
+}
+
+func TestHashedStore_ForEach(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		ks := NewHashed[int, string](hash.Integer[int]())
+		var got []int
+		ks.ForEach(func(key int, value string) bool {
+			got = append(got, key)
+			return true
+		})
+		if len(got) != 0 {
+			t.Fatalf("got %#v, want %#v", got, []int{})
+		}
+	})
+
+	t.Run("non-empty", func(t *testing.T) {
+		ks := NewHashed[int, string](hash.Integer[int]())
+		ks.Put(3, "three")
+		ks.Put(1, "one")
+		ks.Put(2, "two")
+		ks.Put(1, "uno")
+		var got []int
+		ks.ForEach(func(key int, value string) bool {
+			got = append(got, key)
+			return true
+		})
+		want := []int{3, 1, 2} // Insertion order.
+		assert.ElementsMatch(t, got, want)
+	})
+
+	t.Run("stop", func(t *testing.T) {
+		ks := NewHashed[int, string](hash.Integer[int]())
+		ks.Put(1, "one")
+		ks.Put(2, "two")
+		var got []int
+		ks.ForEachKey(func(key int) bool {
+			got = append(got, key)
+			return false
+		})
+		want := []int{1}
+		assert.ElementsMatch(t, got, want)
+	})
+}
@@ -0,0 +1,192 @@
+package bitset
+
+import (
+	"math/bits"
+
+	"github.com/jpfourny/papaya/v2/pkg/constraint"
+	"github.com/jpfourny/papaya/v2/pkg/stream"
+	"github.com/jpfourny/papaya/v2/pkg/stream/mapper"
+)
+
+const wordSize = 64
+
+// BitSet represents a set of non-negative integers as a dense array of bits.
+// The memory used is proportional to the largest member of the set, so it is best suited to small, dense domains.
+// The zero value is an empty BitSet ready to use.
+// A BitSet is not safe for concurrent use; concurrent reads are safe only if there are no concurrent writes.
+type BitSet struct {
+	words []uint64
+}
+
+// New returns a new, empty BitSet.
+func New() *BitSet {
+	return &BitSet{}
+}
+
+// Of returns a new BitSet containing the given members.
+//
+// Example usage:
+//
+//	b := bitset.Of(1, 3, 5)
+//	out := b.String() // "{1, 3, 5}"
+func Of(members ...uint) *BitSet {
+	b := New()
+	for _, i := range members {
+		b.Add(i)
+	}
+	return b
+}
+
+// Collect returns a new BitSet containing all elements from the given stream of unsigned integers.
+// The stream is fully consumed.
+// It is provided by this package, rather than by package stream as CollectBitSet, because this package depends on package stream for BitSet.Stream; the reverse dependency would be an import cycle.
+//
+// Example usage:
+//
+//	b := bitset.Collect(stream.Of[uint8](5, 3, 1, 3))
+//	out := b.String() // "{1, 3, 5}"
+func Collect[E constraint.UnsignedInteger](s stream.Stream[E]) *BitSet {
+	b := New()
+	stream.ForEach(s, func(e E) {
+		b.Add(uint(e))
+	})
+	return b
+}
+
+// Add adds the given member to the BitSet.
+func (b *BitSet) Add(i uint) {
+	w := i / wordSize
+	if w >= uint(len(b.words)) {
+		b.words = append(b.words, make([]uint64, w+1-uint(len(b.words)))...)
+	}
+	b.words[w] |= 1 << (i % wordSize)
+}
+
+// Remove removes the given member from the BitSet.
+func (b *BitSet) Remove(i uint) {
+	w := i / wordSize
+	if w < uint(len(b.words)) {
+		b.words[w] &^= 1 << (i % wordSize)
+		b.trim()
+	}
+}
+
+// Contains returns true if the given value is a member of the BitSet; false otherwise.
+func (b *BitSet) Contains(i uint) bool {
+	w := i / wordSize
+	return w < uint(len(b.words)) && b.words[w]&(1<<(i%wordSize)) != 0
+}
+
+// Cardinality returns the number of members in the BitSet.
+func (b *BitSet) Cardinality() int {
+	n := 0
+	for _, w := range b.words {
+		n += bits.OnesCount64(w)
+	}
+	return n
+}
+
+// IsEmpty returns true if the BitSet has no members; false otherwise.
+func (b *BitSet) IsEmpty() bool {
+	return len(b.words) == 0
+}
+
+// Clone returns a copy of the BitSet.
+func (b *BitSet) Clone() *BitSet {
+	return &BitSet{words: append([]uint64(nil), b.words...)}
+}
+
+// Equal returns true if the two BitSets have the same members; false otherwise.
+func (b *BitSet) Equal(other *BitSet) bool {
+	if len(b.words) != len(other.words) {
+		return false
+	}
+	for i, w := range b.words {
+		if w != other.words[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// And returns a new BitSet containing the members that are in both BitSets (intersection).
+func (b *BitSet) And(other *BitSet) *BitSet {
+	n := min(len(b.words), len(other.words))
+	r := &BitSet{words: make([]uint64, n)}
+	for i := 0; i < n; i++ {
+		r.words[i] = b.words[i] & other.words[i]
+	}
+	r.trim()
+	return r
+}
+
+// Or returns a new BitSet containing the members that are in either BitSet (union).
+func (b *BitSet) Or(other *BitSet) *BitSet {
+	long, short := b.words, other.words
+	if len(long) < len(short) {
+		long, short = short, long
+	}
+	r := &BitSet{words: append([]uint64(nil), long...)}
+	for i, w := range short {
+		r.words[i] |= w
+	}
+	return r
+}
+
+// Xor returns a new BitSet containing the members that are in exactly one of the BitSets (symmetric difference).
+func (b *BitSet) Xor(other *BitSet) *BitSet {
+	long, short := b.words, other.words
+	if len(long) < len(short) {
+		long, short = short, long
+	}
+	r := &BitSet{words: append([]uint64(nil), long...)}
+	for i, w := range short {
+		r.words[i] ^= w
+	}
+	r.trim()
+	return r
+}
+
+// AndNot returns a new BitSet containing the members of this BitSet that are not in the other BitSet (difference).
+func (b *BitSet) AndNot(other *BitSet) *BitSet {
+	r := b.Clone()
+	for i := 0; i < min(len(r.words), len(other.words)); i++ {
+		r.words[i] &^= other.words[i]
+	}
+	r.trim()
+	return r
+}
+
+// Stream returns a stream of the members of the BitSet, in ascending order.
+//
+// Example usage:
+//
+//	s := bitset.Of(5, 1, 3).Stream()
+//	out := stream.DebugString(s) // "<1, 3, 5>"
+func (b *BitSet) Stream() stream.Stream[uint] {
+	return func(yield stream.Consumer[uint]) {
+		for i, w := range b.words {
+			for w != 0 {
+				t := bits.TrailingZeros64(w)
+				if !yield(uint(i*wordSize + t)) {
+					return // Consumer saw enough.
+				}
+				w &= w - 1 // Clear lowest set bit.
+			}
+		}
+	}
+}
+
+// String returns a string representation of the BitSet, formatted like "{1, 3, 5}".
+func (b *BitSet) String() string {
+	return "{" + stream.StringJoin(stream.Map(b.Stream(), mapper.Sprint[uint]()), ", ") + "}"
+}
+
+// trim removes trailing zero words, so that the length of the words reflects the largest member.
+func (b *BitSet) trim() {
+	n := len(b.words)
+	for n > 0 && b.words[n-1] == 0 {
+		n--
+	}
+	b.words = b.words[:n]
+}
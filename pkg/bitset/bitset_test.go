@@ -0,0 +1,118 @@
+package bitset
+
+import (
+	"testing"
+
+	"github.com/jpfourny/papaya/v2/internal/assert"
+	"github.com/jpfourny/papaya/v2/pkg/stream"
+)
+
+func TestBitSet_AddRemoveContains(t *testing.T) {
+	var b BitSet // Zero value is ready to use.
+	b.Add(1)
+	b.Add(64)
+	b.Add(200)
+	b.Add(64)
+
+	for _, i := range []uint{1, 64, 200} {
+		if !b.Contains(i) {
+			t.Errorf("expected Contains(%d) to be true", i)
+		}
+	}
+	for _, i := range []uint{0, 2, 63, 65, 199, 1000} {
+		if b.Contains(i) {
+			t.Errorf("expected Contains(%d) to be false", i)
+		}
+	}
+	if b.Cardinality() != 3 {
+		t.Errorf("got Cardinality() %d, want %d", b.Cardinality(), 3)
+	}
+
+	b.Remove(200)
+	b.Remove(1000) // No-op.
+	if b.Contains(200) {
+		t.Errorf("expected Contains(200) to be false")
+	}
+	if len(b.words) != 2 {
+		t.Errorf("expected trailing words to be trimmed; got %d words", len(b.words))
+	}
+
+	b.Remove(1)
+	b.Remove(64)
+	if !b.IsEmpty() {
+		t.Errorf("expected IsEmpty() to be true")
+	}
+}
+
+func TestBitSet_Algebra(t *testing.T) {
+	a := Of(1, 2, 3, 100)
+	b := Of(2, 3, 4, 300)
+
+	tests := []struct {
+		name string
+		got  *BitSet
+		want []uint
+	}{
+		{"And", a.And(b), []uint{2, 3}},
+		{"Or", a.Or(b), []uint{1, 2, 3, 4, 100, 300}},
+		{"Xor", a.Xor(b), []uint{1, 4, 100, 300}},
+		{"AndNot", a.AndNot(b), []uint{1, 100}},
+		{"AndNot-reversed", b.AndNot(a), []uint{4, 300}},
+		{"And-disjoint", Of(1).And(Of(300)), nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ElementsMatch(t, stream.CollectSlice(tt.got.Stream()), tt.want)
+			if !tt.got.Equal(Of(tt.want...)) {
+				t.Errorf("expected %v to equal %v", tt.got, Of(tt.want...))
+			}
+		})
+	}
+
+	// Operands are unchanged.
+	assert.ElementsMatch(t, stream.CollectSlice(a.Stream()), []uint{1, 2, 3, 100})
+	assert.ElementsMatch(t, stream.CollectSlice(b.Stream()), []uint{2, 3, 4, 300})
+}
+
+func TestBitSet_Equal(t *testing.T) {
+	if !Of(1, 2).Equal(Of(2, 1)) {
+		t.Errorf("expected {1, 2} to equal {2, 1}")
+	}
+	if Of(1, 2).Equal(Of(1, 2, 3)) {
+		t.Errorf("expected {1, 2} not to equal {1, 2, 3}")
+	}
+	if !Of(1, 500).AndNot(Of(500)).Equal(Of(1)) {
+		t.Errorf("expected equality to ignore capacity")
+	}
+}
+
+func TestBitSet_Clone(t *testing.T) {
+	a := Of(1, 2)
+	c := a.Clone()
+	c.Add(3)
+	if a.Contains(3) {
+		t.Errorf("expected clone to be independent")
+	}
+}
+
+func TestBitSet_Stream(t *testing.T) {
+	s := Of(130, 5, 64, 0).Stream()
+	assert.ElementsMatch(t, stream.CollectSlice(s), []uint{0, 5, 64, 130})
+	assert.ElementsMatch(t, stream.CollectSlice(stream.Limit(s, 2)), []uint{0, 5})
+}
+
+func TestBitSet_String(t *testing.T) {
+	if got := Of(5, 1, 3).String(); got != "{1, 3, 5}" {
+		t.Errorf("got %q, want %q", got, "{1, 3, 5}")
+	}
+	if got := New().String(); got != "{}" {
+		t.Errorf("got %q, want %q", got, "{}")
+	}
+}
+
+func TestCollect(t *testing.T) {
+	b := Collect(stream.Of[uint8](5, 3, 1, 3))
+	if !b.Equal(Of(1, 3, 5)) {
+		t.Errorf("got %v, want %v", b, Of(1, 3, 5))
+	}
+}
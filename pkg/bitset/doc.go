@@ -0,0 +1,5 @@
+// Package bitset provides compact sets of small unsigned integers, supporting fast set-algebra and streaming of members.
+//
+// BitSet is a dense, uncompressed bit array suited to small domains where most values are in use.
+// Roaring is a compressed bitmap of 32-bit values, in the style of Roaring Bitmaps, suited to large or sparse domains.
+package bitset
@@ -0,0 +1,420 @@
+package bitset
+
+import (
+	"math"
+	"math/bits"
+	"slices"
+
+	"github.com/jpfourny/papaya/v2/pkg/constraint"
+	"github.com/jpfourny/papaya/v2/pkg/stream"
+	"github.com/jpfourny/papaya/v2/pkg/stream/mapper"
+)
+
+const (
+	arrayMaxSize = 4096       // Maximum cardinality of a container in array form.
+	arrayMinSize = 2048       // Cardinality at or below which a container in bitmap form shrinking by removal converts back to array form.
+	bitmapWords  = 65536 / 64 // Number of words in a container in bitmap form.
+)
+
+// Roaring represents a compressed set of 32-bit unsigned integers, in the style of Roaring Bitmaps.
+// The domain is partitioned into chunks of 65536 values sharing the same upper 16 bits.
+// Each non-empty chunk is stored in a container, either as a sorted array of the lower 16 bits (when sparse), or as a bitmap (when dense).
+// The memory used is proportional to the number of members, rather than the largest member, so it is suited to large or sparse domains.
+// The zero value is an empty Roaring ready to use.
+// A Roaring is not safe for concurrent use; concurrent reads are safe only if there are no concurrent writes.
+type Roaring struct {
+	keys       []uint16 // Sorted upper 16 bits of each chunk.
+	containers []*container
+}
+
+// container holds the lower 16 bits of the members of a chunk.
+// Exactly one of array or bitmap is in use, depending on the cardinality.
+// A container converts to bitmap form when it grows beyond arrayMaxSize, but converts back only once it shrinks to arrayMinSize, so that adding and removing members around the threshold does not convert it back and forth.
+// Containers created by set-algebra are always in the form best suited to their cardinality (see normalize).
+type container struct {
+	array  []uint16 // Sorted members, if in array form.
+	bitmap []uint64 // Bitmap of members, if in bitmap form; nil otherwise.
+	card   int
+}
+
+// NewRoaring returns a new, empty Roaring.
+func NewRoaring() *Roaring {
+	return &Roaring{}
+}
+
+// RoaringOf returns a new Roaring containing the given members.
+//
+// Example usage:
+//
+//	r := bitset.RoaringOf(1, 70000, 3)
+//	out := r.String() // "{1, 3, 70000}"
+func RoaringOf(members ...uint32) *Roaring {
+	r := NewRoaring()
+	for _, i := range members {
+		r.Add(i)
+	}
+	return r
+}
+
+// CollectRoaring returns a new Roaring containing all elements from the given stream of unsigned integers.
+// The stream is fully consumed.
+// Panics if an element does not fit in 32 bits.
+//
+// Example usage:
+//
+//	r := bitset.CollectRoaring(stream.Of[uint](70000, 3, 1, 3))
+//	out := r.String() // "{1, 3, 70000}"
+func CollectRoaring[E constraint.UnsignedInteger](s stream.Stream[E]) *Roaring {
+	r := NewRoaring()
+	stream.ForEach(s, func(e E) {
+		if uint64(e) > math.MaxUint32 {
+			panic("element does not fit in 32 bits")
+		}
+		r.Add(uint32(e))
+	})
+	return r
+}
+
+// Add adds the given member to the Roaring.
+func (r *Roaring) Add(i uint32) {
+	hi, lo := split(i)
+	idx, found := r.find(hi)
+	if !found {
+		r.keys = slices.Insert(r.keys, idx, hi)
+		r.containers = slices.Insert(r.containers, idx, &container{})
+	}
+	r.containers[idx].add(lo)
+}
+
+// Remove removes the given member from the Roaring.
+func (r *Roaring) Remove(i uint32) {
+	hi, lo := split(i)
+	if idx, found := r.find(hi); found {
+		c := r.containers[idx]
+		c.remove(lo)
+		if c.card == 0 {
+			r.keys = slices.Delete(r.keys, idx, idx+1)
+			r.containers = slices.Delete(r.containers, idx, idx+1)
+		}
+	}
+}
+
+// Contains returns true if the given value is a member of the Roaring; false otherwise.
+func (r *Roaring) Contains(i uint32) bool {
+	hi, lo := split(i)
+	idx, found := r.find(hi)
+	return found && r.containers[idx].contains(lo)
+}
+
+// Cardinality returns the number of members in the Roaring.
+func (r *Roaring) Cardinality() int {
+	n := 0
+	for _, c := range r.containers {
+		n += c.card
+	}
+	return n
+}
+
+// IsEmpty returns true if the Roaring has no members; false otherwise.
+func (r *Roaring) IsEmpty() bool {
+	return len(r.keys) == 0
+}
+
+// Clone returns a copy of the Roaring.
+func (r *Roaring) Clone() *Roaring {
+	c := &Roaring{
+		keys:       slices.Clone(r.keys),
+		containers: make([]*container, len(r.containers)),
+	}
+	for i, rc := range r.containers {
+		c.containers[i] = rc.clone()
+	}
+	return c
+}
+
+// Equal returns true if the two Roarings have the same members; false otherwise.
+func (r *Roaring) Equal(other *Roaring) bool {
+	if !slices.Equal(r.keys, other.keys) {
+		return false
+	}
+	for i, c := range r.containers {
+		if !c.equal(other.containers[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// And returns a new Roaring containing the members that are in both Roarings (intersection).
+func (r *Roaring) And(other *Roaring) *Roaring {
+	return r.combine(other, opAnd)
+}
+
+// Or returns a new Roaring containing the members that are in either Roaring (union).
+func (r *Roaring) Or(other *Roaring) *Roaring {
+	return r.combine(other, opOr)
+}
+
+// Xor returns a new Roaring containing the members that are in exactly one of the Roarings (symmetric difference).
+func (r *Roaring) Xor(other *Roaring) *Roaring {
+	return r.combine(other, opXor)
+}
+
+// AndNot returns a new Roaring containing the members of this Roaring that are not in the other Roaring (difference).
+func (r *Roaring) AndNot(other *Roaring) *Roaring {
+	return r.combine(other, opAndNot)
+}
+
+// Stream returns a stream of the members of the Roaring, in ascending order.
+//
+// Example usage:
+//
+//	s := bitset.RoaringOf(70000, 1, 3).Stream()
+//	out := stream.DebugString(s) // "<1, 3, 70000>"
+func (r *Roaring) Stream() stream.Stream[uint32] {
+	return func(yield stream.Consumer[uint32]) {
+		for i, c := range r.containers {
+			hi := uint32(r.keys[i]) << 16
+			if !c.each(func(lo uint16) bool { return yield(hi | uint32(lo)) }) {
+				return // Consumer saw enough.
+			}
+		}
+	}
+}
+
+// String returns a string representation of the Roaring, formatted like "{1, 3, 5}".
+func (r *Roaring) String() string {
+	return "{" + stream.StringJoin(stream.Map(r.Stream(), mapper.Sprint[uint32]()), ", ") + "}"
+}
+
+func (r *Roaring) find(hi uint16) (int, bool) {
+	return slices.BinarySearch(r.keys, hi)
+}
+
+// op describes a set-algebra operation, both as a predicate on membership and as a bitwise operation on words.
+type op struct {
+	keep func(inA, inB bool) bool
+	word func(a, b uint64) uint64
+}
+
+var (
+	opAnd = op{
+		keep: func(inA, inB bool) bool { return inA && inB },
+		word: func(a, b uint64) uint64 { return a & b },
+	}
+	opOr = op{
+		keep: func(inA, inB bool) bool { return inA || inB },
+		word: func(a, b uint64) uint64 { return a | b },
+	}
+	opXor = op{
+		keep: func(inA, inB bool) bool { return inA != inB },
+		word: func(a, b uint64) uint64 { return a ^ b },
+	}
+	opAndNot = op{
+		keep: func(inA, inB bool) bool { return inA && !inB },
+		word: func(a, b uint64) uint64 { return a &^ b },
+	}
+)
+
+// combine merges the chunks of the two Roarings using the given operation.
+func (r *Roaring) combine(other *Roaring, o op) *Roaring {
+	res := NewRoaring()
+	put := func(key uint16, c *container) {
+		if c.card > 0 {
+			res.keys = append(res.keys, key)
+			res.containers = append(res.containers, c)
+		}
+	}
+	i, j := 0, 0
+	for i < len(r.keys) || j < len(other.keys) {
+		switch {
+		case j == len(other.keys) || (i < len(r.keys) && r.keys[i] < other.keys[j]): // Chunk only in r.
+			if o.keep(true, false) {
+				put(r.keys[i], r.containers[i].clone().normalize())
+			}
+			i++
+		case i == len(r.keys) || other.keys[j] < r.keys[i]: // Chunk only in other.
+			if o.keep(false, true) {
+				put(other.keys[j], other.containers[j].clone().normalize())
+			}
+			j++
+		default: // Chunk in both.
+			put(r.keys[i], r.containers[i].combine(other.containers[j], o))
+			i++
+			j++
+		}
+	}
+	return res
+}
+
+func split(i uint32) (hi, lo uint16) {
+	return uint16(i >> 16), uint16(i)
+}
+
+func (c *container) add(v uint16) {
+	if c.bitmap != nil {
+		w, b := v/64, uint64(1)<<(v%64)
+		if c.bitmap[w]&b == 0 {
+			c.bitmap[w] |= b
+			c.card++
+		}
+		return
+	}
+	i, found := slices.BinarySearch(c.array, v)
+	if found {
+		return
+	}
+	c.array = slices.Insert(c.array, i, v)
+	c.card++
+	if c.card > arrayMaxSize {
+		c.toBitmap()
+	}
+}
+
+func (c *container) remove(v uint16) {
+	if c.bitmap != nil {
+		w, b := v/64, uint64(1)<<(v%64)
+		if c.bitmap[w]&b != 0 {
+			c.bitmap[w] &^= b
+			c.card--
+			if c.card <= arrayMinSize {
+				c.toArray()
+			}
+		}
+		return
+	}
+	if i, found := slices.BinarySearch(c.array, v); found {
+		c.array = slices.Delete(c.array, i, i+1)
+		c.card--
+	}
+}
+
+func (c *container) contains(v uint16) bool {
+	if c.bitmap != nil {
+		return c.bitmap[v/64]&(1<<(v%64)) != 0
+	}
+	_, found := slices.BinarySearch(c.array, v)
+	return found
+}
+
+func (c *container) each(yield func(uint16) bool) bool {
+	if c.bitmap != nil {
+		for i, w := range c.bitmap {
+			for w != 0 {
+				if !yield(uint16(i*64 + bits.TrailingZeros64(w))) {
+					return false
+				}
+				w &= w - 1 // Clear lowest set bit.
+			}
+		}
+		return true
+	}
+	for _, v := range c.array {
+		if !yield(v) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *container) clone() *container {
+	return &container{
+		array:  slices.Clone(c.array),
+		bitmap: slices.Clone(c.bitmap),
+		card:   c.card,
+	}
+}
+
+func (c *container) equal(other *container) bool {
+	if c.card != other.card {
+		return false
+	}
+	switch {
+	case c.bitmap != nil && other.bitmap != nil:
+		return slices.Equal(c.bitmap, other.bitmap)
+	case c.bitmap == nil && other.bitmap == nil:
+		return slices.Equal(c.array, other.array)
+	}
+	return slices.Equal(c.words(), other.words()) // Forms may differ between arrayMinSize and arrayMaxSize.
+}
+
+// combine returns a new container from applying the given operation to the two containers.
+func (c *container) combine(other *container, o op) *container {
+	if c.bitmap == nil && other.bitmap == nil {
+		res := &container{array: mergeArrays(c.array, other.array, o.keep)}
+		return res.normalize()
+	}
+	a, b := c.words(), other.words()
+	res := &container{bitmap: make([]uint64, bitmapWords)}
+	for i := range res.bitmap {
+		res.bitmap[i] = o.word(a[i], b[i])
+		res.card += bits.OnesCount64(res.bitmap[i])
+	}
+	return res.normalize()
+}
+
+// words returns the members of the container as a bitmap, converting from array form if necessary.
+func (c *container) words() []uint64 {
+	if c.bitmap != nil {
+		return c.bitmap
+	}
+	w := make([]uint64, bitmapWords)
+	for _, v := range c.array {
+		w[v/64] |= 1 << (v % 64)
+	}
+	return w
+}
+
+// normalize converts the container to the form appropriate for its cardinality.
+func (c *container) normalize() *container {
+	if c.bitmap == nil {
+		c.card = len(c.array)
+		if c.card > arrayMaxSize {
+			c.toBitmap()
+		}
+	} else if c.card <= arrayMaxSize {
+		c.toArray()
+	}
+	return c
+}
+
+func (c *container) toBitmap() {
+	c.bitmap = c.words()
+	c.array = nil
+}
+
+func (c *container) toArray() {
+	c.array = make([]uint16, 0, c.card)
+	c.each(func(v uint16) bool {
+		c.array = append(c.array, v)
+		return true
+	})
+	c.bitmap = nil
+}
+
+// mergeArrays merges two sorted arrays, keeping the values for which the given predicate on membership returns true.
+func mergeArrays(a, b []uint16, keep func(inA, inB bool) bool) []uint16 {
+	var res []uint16
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case j == len(b) || (i < len(a) && a[i] < b[j]):
+			if keep(true, false) {
+				res = append(res, a[i])
+			}
+			i++
+		case i == len(a) || b[j] < a[i]:
+			if keep(false, true) {
+				res = append(res, b[j])
+			}
+			j++
+		default:
+			if keep(true, true) {
+				res = append(res, a[i])
+			}
+			i++
+			j++
+		}
+	}
+	return res
+}
@@ -0,0 +1,183 @@
+package bitset
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/jpfourny/papaya/v2/internal/assert"
+	"github.com/jpfourny/papaya/v2/pkg/stream"
+)
+
+func TestRoaring_AddRemoveContains(t *testing.T) {
+	var r Roaring // Zero value is ready to use.
+	r.Add(1)
+	r.Add(70000)
+	r.Add(1)
+
+	if !r.Contains(1) || !r.Contains(70000) {
+		t.Errorf("expected Contains to be true for members")
+	}
+	if r.Contains(2) || r.Contains(70001) {
+		t.Errorf("expected Contains to be false for non-members")
+	}
+	if r.Cardinality() != 2 {
+		t.Errorf("got Cardinality() %d, want %d", r.Cardinality(), 2)
+	}
+
+	r.Remove(70000)
+	r.Remove(12345) // No-op.
+	if len(r.keys) != 1 {
+		t.Errorf("expected empty container to be removed; got %d containers", len(r.keys))
+	}
+	r.Remove(1)
+	if !r.IsEmpty() {
+		t.Errorf("expected IsEmpty() to be true")
+	}
+}
+
+func TestRoaring_ContainerConversion(t *testing.T) {
+	r := NewRoaring()
+	for i := uint32(0); i < 2*arrayMaxSize; i += 2 {
+		r.Add(i)
+	}
+	if r.containers[0].bitmap != nil {
+		t.Fatalf("expected array container at cardinality %d", r.Cardinality())
+	}
+
+	r.Add(1) // Exceeds array capacity.
+	if r.containers[0].bitmap == nil {
+		t.Fatalf("expected bitmap container at cardinality %d", r.Cardinality())
+	}
+	if !r.Contains(1) || !r.Contains(2*arrayMaxSize-2) || r.Contains(3) {
+		t.Errorf("membership changed by conversion")
+	}
+
+	r.Remove(1) // Back within array capacity, but above arrayMinSize.
+	if r.containers[0].bitmap == nil {
+		t.Fatalf("expected bitmap container at cardinality %d", r.Cardinality())
+	}
+	if r.Cardinality() != arrayMaxSize {
+		t.Errorf("got Cardinality() %d, want %d", r.Cardinality(), arrayMaxSize)
+	}
+	if o := r.Or(NewRoaring()); o.containers[0].bitmap != nil || !r.Equal(o) || !o.Equal(r) {
+		t.Errorf("expected normalized copy in array form, equal to the original")
+	}
+
+	for i := uint32(0); r.Cardinality() > arrayMinSize; i += 2 {
+		r.Remove(i)
+	}
+	if r.containers[0].bitmap != nil {
+		t.Fatalf("expected array container at cardinality %d", r.Cardinality())
+	}
+	if !r.Contains(2*arrayMaxSize-2) || r.Contains(0) {
+		t.Errorf("membership changed by conversion")
+	}
+}
+
+func TestRoaring_Algebra(t *testing.T) {
+	a := RoaringOf(1, 2, 3, 70000)
+	b := RoaringOf(2, 3, 4, 140000)
+
+	tests := []struct {
+		name string
+		got  *Roaring
+		want []uint32
+	}{
+		{"And", a.And(b), []uint32{2, 3}},
+		{"Or", a.Or(b), []uint32{1, 2, 3, 4, 70000, 140000}},
+		{"Xor", a.Xor(b), []uint32{1, 4, 70000, 140000}},
+		{"AndNot", a.AndNot(b), []uint32{1, 70000}},
+		{"And-disjoint", RoaringOf(1).And(RoaringOf(70000)), nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ElementsMatch(t, stream.CollectSlice(tt.got.Stream()), tt.want)
+			if !tt.got.Equal(RoaringOf(tt.want...)) {
+				t.Errorf("expected %v to equal %v", tt.got, RoaringOf(tt.want...))
+			}
+		})
+	}
+}
+
+func TestRoaring_AlgebraRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(0))
+	random := func(n int, limit int32) (*Roaring, *BitSet) {
+		r, b := NewRoaring(), New()
+		for i := 0; i < n; i++ {
+			v := uint32(rnd.Int31n(limit))
+			r.Add(v)
+			b.Add(uint(v))
+		}
+		return r, b
+	}
+
+	// Mix of dense (bitmap) and sparse (array) containers.
+	r1, b1 := random(20000, 200000)
+	r2, b2 := random(3000, 200000)
+	r3, b3 := random(30000, 100000)
+
+	check := func(name string, got *Roaring, want *BitSet) {
+		t.Helper()
+		g := stream.CollectSlice(stream.Map(got.Stream(), func(v uint32) uint { return uint(v) }))
+		w := stream.CollectSlice(want.Stream())
+		if !slices.Equal(g, w) {
+			t.Errorf("%s: got %d members, want %d", name, len(g), len(w))
+		}
+		if got.Cardinality() != want.Cardinality() {
+			t.Errorf("%s: got Cardinality() %d, want %d", name, got.Cardinality(), want.Cardinality())
+		}
+	}
+
+	for _, p := range []struct {
+		r1, r2 *Roaring
+		b1, b2 *BitSet
+	}{
+		{r1, r2, b1, b2},
+		{r1, r3, b1, b3},
+		{r2, r3, b2, b3},
+	} {
+		check("And", p.r1.And(p.r2), p.b1.And(p.b2))
+		check("Or", p.r1.Or(p.r2), p.b1.Or(p.b2))
+		check("Xor", p.r1.Xor(p.r2), p.b1.Xor(p.b2))
+		check("AndNot", p.r1.AndNot(p.r2), p.b1.AndNot(p.b2))
+	}
+}
+
+func TestRoaring_Clone(t *testing.T) {
+	a := RoaringOf(1, 2)
+	c := a.Clone()
+	c.Add(3)
+	if a.Contains(3) {
+		t.Errorf("expected clone to be independent")
+	}
+	if !c.Equal(RoaringOf(1, 2, 3)) {
+		t.Errorf("got %v, want %v", c, RoaringOf(1, 2, 3))
+	}
+}
+
+func TestRoaring_Stream(t *testing.T) {
+	s := RoaringOf(140000, 5, 70000, 0).Stream()
+	assert.ElementsMatch(t, stream.CollectSlice(s), []uint32{0, 5, 70000, 140000})
+	assert.ElementsMatch(t, stream.CollectSlice(stream.Limit(s, 3)), []uint32{0, 5, 70000})
+}
+
+func TestRoaring_String(t *testing.T) {
+	if got := RoaringOf(70000, 1, 3).String(); got != "{1, 3, 70000}" {
+		t.Errorf("got %q, want %q", got, "{1, 3, 70000}")
+	}
+}
+
+func TestCollectRoaring(t *testing.T) {
+	r := CollectRoaring(stream.Of[uint](70000, 3, 1, 3))
+	if !r.Equal(RoaringOf(1, 3, 70000)) {
+		t.Errorf("got %v, want %v", r, RoaringOf(1, 3, 70000))
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected CollectRoaring to panic for element exceeding 32 bits")
+		}
+	}()
+	CollectRoaring(stream.Of[uint64](1 << 32))
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/jpfourny/papaya/v2/pkg/constraint"
 	"github.com/jpfourny/papaya/v2/pkg/pair"
+	"github.com/jpfourny/papaya/v2/pkg/tuple"
 )
 
 // Comparer is a function that compares two values of the same type E and returns an integer.
@@ -222,6 +223,62 @@ func Pair[A, B any](compareA Comparer[A], compareB Comparer[B]) Comparer[pair.Pa
 	}
 }
 
+// Tuple3 returns a Comparer that compares two values of type tuple.T3[A, B, C] by comparing their elements in order using the provided Comparer functions.
+// The tuples are ordered by the first elements that are not equal; if all elements are equal, the tuples are considered equal.
+//
+// Example:
+//
+//	s := []tuple.T3[int, string, bool]{tuple.Of3(2, "a", true), tuple.Of3(1, "b", false), tuple.Of3(1, "a", true)}
+//	slices.SortFunc(s, cmp.Tuple3(cmp.Natural[int](), cmp.Natural[string](), cmp.Bool())) // [(1, "a", true), (1, "b", false), (2, "a", true)]
+func Tuple3[A, B, C any](compareA Comparer[A], compareB Comparer[B], compareC Comparer[C]) Comparer[tuple.T3[A, B, C]] {
+	return func(a, b tuple.T3[A, B, C]) int {
+		if c := compareA(a.First(), b.First()); c != 0 {
+			return c
+		}
+		if c := compareB(a.Second(), b.Second()); c != 0 {
+			return c
+		}
+		return compareC(a.Third(), b.Third())
+	}
+}
+
+// Tuple4 returns a Comparer that compares two values of type tuple.T4[A, B, C, D] by comparing their elements in order using the provided Comparer functions.
+// See Tuple3 for details.
+func Tuple4[A, B, C, D any](compareA Comparer[A], compareB Comparer[B], compareC Comparer[C], compareD Comparer[D]) Comparer[tuple.T4[A, B, C, D]] {
+	return func(a, b tuple.T4[A, B, C, D]) int {
+		if c := compareA(a.First(), b.First()); c != 0 {
+			return c
+		}
+		if c := compareB(a.Second(), b.Second()); c != 0 {
+			return c
+		}
+		if c := compareC(a.Third(), b.Third()); c != 0 {
+			return c
+		}
+		return compareD(a.Fourth(), b.Fourth())
+	}
+}
+
+// Tuple5 returns a Comparer that compares two values of type tuple.T5[A, B, C, D, E] by comparing their elements in order using the provided Comparer functions.
+// See Tuple3 for details.
+func Tuple5[A, B, C, D, E any](compareA Comparer[A], compareB Comparer[B], compareC Comparer[C], compareD Comparer[D], compareE Comparer[E]) Comparer[tuple.T5[A, B, C, D, E]] {
+	return func(a, b tuple.T5[A, B, C, D, E]) int {
+		if c := compareA(a.First(), b.First()); c != 0 {
+			return c
+		}
+		if c := compareB(a.Second(), b.Second()); c != 0 {
+			return c
+		}
+		if c := compareC(a.Third(), b.Third()); c != 0 {
+			return c
+		}
+		if c := compareD(a.Fourth(), b.Fourth()); c != 0 {
+			return c
+		}
+		return compareE(a.Fifth(), b.Fifth())
+	}
+}
+
 // Slice returns a Comparer that compares two slices of type []E by comparing the elements of the slices using the provided Comparer.
 // The first N elements of the slices are compared, where N is the length of the shorter slice.
 // If all N elements are equal, the length of the slices are compared.
@@ -7,6 +7,7 @@ import (
 
 	"github.com/jpfourny/papaya/v2/pkg/pair"
 	"github.com/jpfourny/papaya/v2/pkg/ptr"
+	"github.com/jpfourny/papaya/v2/pkg/tuple"
 )
 
 type Person struct {
@@ -167,6 +168,58 @@ func TestPair(t *testing.T) {
 	}
 }
 
+func TestTuple3(t *testing.T) {
+	c := Tuple3(Natural[int](), Natural[string](), Bool())
+	tests := []struct {
+		a, b tuple.T3[int, string, bool]
+		want int
+	}{
+		{tuple.Of3(1, "a", true), tuple.Of3(1, "a", true), 0},
+		{tuple.Of3(1, "a", true), tuple.Of3(2, "a", true), -1},
+		{tuple.Of3(1, "b", true), tuple.Of3(1, "a", true), 1},
+		{tuple.Of3(1, "a", false), tuple.Of3(1, "a", true), -1},
+	}
+	for _, tt := range tests {
+		if got := c(tt.a, tt.b); got != tt.want {
+			t.Errorf("Tuple3(...)(%v, %v): expected %d, got %d", tt.a, tt.b, tt.want, got)
+		}
+	}
+}
+
+func TestTuple4(t *testing.T) {
+	c := Tuple4(Natural[int](), Natural[int](), Natural[int](), Natural[int]())
+	tests := []struct {
+		a, b tuple.T4[int, int, int, int]
+		want int
+	}{
+		{tuple.Of4(1, 2, 3, 4), tuple.Of4(1, 2, 3, 4), 0},
+		{tuple.Of4(1, 2, 3, 4), tuple.Of4(1, 2, 4, 0), -1},
+		{tuple.Of4(1, 2, 3, 5), tuple.Of4(1, 2, 3, 4), 1},
+	}
+	for _, tt := range tests {
+		if got := c(tt.a, tt.b); got != tt.want {
+			t.Errorf("Tuple4(...)(%v, %v): expected %d, got %d", tt.a, tt.b, tt.want, got)
+		}
+	}
+}
+
+func TestTuple5(t *testing.T) {
+	c := Tuple5(Natural[int](), Natural[int](), Natural[int](), Natural[int](), Natural[int]())
+	tests := []struct {
+		a, b tuple.T5[int, int, int, int, int]
+		want int
+	}{
+		{tuple.Of5(1, 2, 3, 4, 5), tuple.Of5(1, 2, 3, 4, 5), 0},
+		{tuple.Of5(1, 2, 3, 4, 5), tuple.Of5(1, 2, 3, 4, 6), -1},
+		{tuple.Of5(1, 2, 3, 5, 0), tuple.Of5(1, 2, 3, 4, 5), 1},
+	}
+	for _, tt := range tests {
+		if got := c(tt.a, tt.b); got != tt.want {
+			t.Errorf("Tuple5(...)(%v, %v): expected %d, got %d", tt.a, tt.b, tt.want, got)
+		}
+	}
+}
+
 func TestSlice(t *testing.T) {
 	c := Slice(Natural[int]())
 	got := c([]int{3, 1, 2}, []int{1, 2, 3})
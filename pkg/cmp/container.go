@@ -0,0 +1,239 @@
+package cmp
+
+import (
+	stdcmp "cmp"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/jpfourny/papaya/v2/pkg/constraint"
+	"github.com/jpfourny/papaya/v2/pkg/opt"
+	"github.com/jpfourny/papaya/v2/pkg/res"
+)
+
+// Float returns a Comparer that compares two floating-point values of type F, with NaN values placed first or last according to nanFirst.
+// All NaN values are considered equal to each other, and negative zero is considered equal to positive zero.
+// Unlike Natural, this is a total order even in the presence of NaN values, so it is safe for sorting and for sorted stores.
+//
+// Example:
+//
+//	s := []float64{2, math.NaN(), 1}
+//	slices.SortFunc(s, cmp.Float[float64](false)) // [1, 2, NaN]
+func Float[F constraint.Float](nanFirst bool) Comparer[F] {
+	nanOrder := 1
+	if nanFirst {
+		nanOrder = -1
+	}
+	return func(a, b F) int {
+		aNaN, bNaN := math.IsNaN(float64(a)), math.IsNaN(float64(b))
+		switch {
+		case aNaN && bNaN:
+			return 0
+		case aNaN:
+			return nanOrder
+		case bNaN:
+			return -nanOrder
+		case a < b:
+			return -1
+		case a > b:
+			return 1
+		default:
+			return 0
+		}
+	}
+}
+
+// Optional returns a Comparer that compares two values of type opt.Optional[E] by comparing the contained values using the provided Comparer.
+// Empty Optionals are considered equal to each other, and are placed before non-empty ones if noneFirst is true, or after them otherwise.
+// A nil Optional is considered empty.
+//
+// Example:
+//
+//	s := []opt.Optional[int]{opt.Of(2), opt.Empty[int](), opt.Of(1)}
+//	slices.SortFunc(s, cmp.Optional(cmp.Natural[int](), true)) // [None, Some(1), Some(2)]
+func Optional[E any](compare Comparer[E], noneFirst bool) Comparer[opt.Optional[E]] {
+	noneOrder := 1
+	if noneFirst {
+		noneOrder = -1
+	}
+	return func(a, b opt.Optional[E]) int {
+		va, oka := getOptional(a)
+		vb, okb := getOptional(b)
+		switch {
+		case !oka && !okb:
+			return 0
+		case !oka:
+			return noneOrder
+		case !okb:
+			return -noneOrder
+		default:
+			return compare(va, vb)
+		}
+	}
+}
+
+func getOptional[E any](o opt.Optional[E]) (E, bool) {
+	if o == nil {
+		var zero E
+		return zero, false
+	}
+	return o.Get()
+}
+
+// Error returns a Comparer that compares two values of type error by their messages, with nil errors placed first.
+//
+// Example:
+//
+//	c := cmp.Error()
+//	n := c(errors.New("a"), errors.New("b")) // -1
+//	n = c(nil, errors.New("a")) // -1
+func Error() Comparer[error] {
+	return NilFirst(func(a, b error) int {
+		return strings.Compare(a.Error(), b.Error())
+	})
+}
+
+// Result returns a Comparer that compares two values of type res.Result[E].
+// Results are ordered by outcome first: failed results before partially successful results, before successful results.
+// Results with the same outcome are then compared by value, using the provided Comparer, and by error, as with Error.
+//
+// Example:
+//
+//	s := []res.Result[int]{res.OK(2), res.Fail[int](errors.New("boom")), res.OK(1)}
+//	slices.SortFunc(s, cmp.Result(cmp.Natural[int]())) // [Failure(boom), Success(1), Success(2)]
+func Result[E any](compare Comparer[E]) Comparer[res.Result[E]] {
+	compareErr := Error()
+	return func(a, b res.Result[E]) int {
+		if c := stdcmp.Compare(resultOrder(a), resultOrder(b)); c != 0 {
+			return c
+		}
+		if va, ok := a.Value().Get(); ok {
+			if c := compare(va, b.Value().GetOrZero()); c != 0 {
+				return c
+			}
+		}
+		return compareErr(a.Error().GetOrZero(), b.Error().GetOrZero())
+	}
+}
+
+func resultOrder[E any](r res.Result[E]) int {
+	switch {
+	case r.Failed():
+		return -1
+	case r.PartiallySucceeded():
+		return 0
+	default:
+		return 1
+	}
+}
+
+// Map returns a Comparer that compares two maps of type map[K]V by comparing their entries, in key order, using the provided Comparers.
+// The entries are compared lexicographically, first by key and then by value, as with Slice; if all the entries of the smaller map are equal to the first entries of the larger one, the smaller map is considered less.
+// The keys of both maps are sorted on each comparison, so the cost is O(n log n) in the size of the maps.
+//
+// Example:
+//
+//	c := cmp.Map(cmp.Natural[string](), cmp.Natural[int]())
+//	n := c(map[string]int{"a": 1, "b": 2}, map[string]int{"a": 1, "b": 3}) // -1
+//	n = c(map[string]int{"a": 1, "b": 2}, map[string]int{"a": 1, "c": 0}) // -1
+func Map[K comparable, V any](keyCompare Comparer[K], valueCompare Comparer[V]) Comparer[map[K]V] {
+	return func(a, b map[K]V) int {
+		ka, kb := sortedKeys(a, keyCompare), sortedKeys(b, keyCompare)
+		n := min(len(ka), len(kb))
+		for i := 0; i < n; i++ {
+			if c := keyCompare(ka[i], kb[i]); c != 0 {
+				return c
+			}
+			if c := valueCompare(a[ka[i]], b[kb[i]]); c != 0 {
+				return c
+			}
+		}
+		return stdcmp.Compare(len(ka), len(kb))
+	}
+}
+
+func sortedKeys[K comparable, V any](m map[K]V, compare Comparer[K]) []K {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.SortFunc(keys, compare)
+	return keys
+}
+
+// Lexicographic returns a Comparer that compares two arrays (or slices) of type A, with elements of type E, lexicographically, as with Slice.
+// It is intended for array types, which Slice does not accept, such as [16]byte or named array types; the element type is inferred from the provided Comparer.
+// Lexicographic panics if A is not an array or slice type with elements of type E.
+//
+// Example:
+//
+//	s := [][3]int{{1, 2, 4}, {1, 2, 3}, {0, 9, 9}}
+//	slices.SortFunc(s, cmp.Lexicographic[[3]int](cmp.Natural[int]())) // [[0, 9, 9], [1, 2, 3], [1, 2, 4]]
+func Lexicographic[A any, E any](compare Comparer[E]) Comparer[A] {
+	t, et := reflect.TypeFor[A](), reflect.TypeFor[E]()
+	if (t.Kind() != reflect.Array && t.Kind() != reflect.Slice) || t.Elem() != et {
+		panic(fmt.Sprintf("cmp: Lexicographic requires an array or slice type with elements of type %s; got %s", et, t))
+	}
+	compareSlices := Slice(compare)
+	st := reflect.SliceOf(et)
+	return func(a, b A) int {
+		return compareSlices(asSlice[E](&a, st), asSlice[E](&b, st))
+	}
+}
+
+// asSlice returns a slice of type []E referring to the elements of the array (or slice) pointed to by p.
+func asSlice[E any](p any, st reflect.Type) []E {
+	v := reflect.ValueOf(p).Elem()
+	if v.Kind() == reflect.Array {
+		v = v.Slice(0, v.Len())
+	}
+	return v.Convert(st).Interface().([]E)
+}
+
+// NilFirst returns a Comparer that compares two values of type E using the provided Comparer, with nil values placed first.
+// A value is nil if it is a nil interface, or a nil pointer, map, slice, channel or function; nil values are considered equal to each other.
+// The provided Comparer is called only if both values are non-nil, so it need not handle nil values itself.
+// It is intended for interface types, such as error or fmt.Stringer; see DerefNilFirst for pointers to values that should be compared by dereferencing.
+//
+// Example:
+//
+//	c := cmp.NilFirst(func(a, b fmt.Stringer) int { return strings.Compare(a.String(), b.String()) })
+//	n := c(nil, time.Second) // -1
+func NilFirst[E any](compare Comparer[E]) Comparer[E] {
+	return nilOrdered(compare, -1)
+}
+
+// NilLast returns a Comparer that compares two values of type E using the provided Comparer, with nil values placed last.
+// See NilFirst for details.
+func NilLast[E any](compare Comparer[E]) Comparer[E] {
+	return nilOrdered(compare, 1)
+}
+
+func nilOrdered[E any](compare Comparer[E], nilOrder int) Comparer[E] {
+	return func(a, b E) int {
+		aNil, bNil := isNil(a), isNil(b)
+		switch {
+		case aNil && bNil:
+			return 0
+		case aNil:
+			return nilOrder
+		case bNil:
+			return -nilOrder
+		default:
+			return compare(a, b)
+		}
+	}
+}
+
+func isNil(v any) bool {
+	if v == nil {
+		return true
+	}
+	switch rv := reflect.ValueOf(v); rv.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Chan, reflect.Func, reflect.Interface, reflect.UnsafePointer:
+		return rv.IsNil()
+	}
+	return false
+}
@@ -0,0 +1,187 @@
+package cmp
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jpfourny/papaya/v2/pkg/opt"
+	"github.com/jpfourny/papaya/v2/pkg/res"
+)
+
+func TestFloat(t *testing.T) {
+	nan := math.NaN()
+
+	s := []float64{2, nan, math.Inf(-1), 1, nan, math.Inf(1)}
+	slices.SortFunc(s, Float[float64](false))
+	if fmt.Sprint(s) != "[-Inf 1 2 +Inf NaN NaN]" {
+		t.Errorf("Float(false): got %v", s)
+	}
+	slices.SortFunc(s, Float[float64](true))
+	if fmt.Sprint(s) != "[NaN NaN -Inf 1 2 +Inf]" {
+		t.Errorf("Float(true): got %v", s)
+	}
+
+	c := Float[float32](false)
+	if got := c(float32(math.Copysign(0, -1)), 0); got != 0 {
+		t.Errorf("Float()(-0, 0): expected 0, got %d", got)
+	}
+	if got := c(float32(nan), float32(nan)); got != 0 {
+		t.Errorf("Float()(NaN, NaN): expected 0, got %d", got)
+	}
+}
+
+func TestOptional(t *testing.T) {
+	s := []opt.Optional[int]{opt.Of(2), opt.Empty[int](), opt.Of(1), nil}
+
+	slices.SortFunc(s, Optional(Natural[int](), true))
+	if got := s[2:]; !slices.Equal(opt.ToSlice(got[0]), []int{1}) || !slices.Equal(opt.ToSlice(got[1]), []int{2}) {
+		t.Errorf("Optional(true): got %v", s)
+	}
+	if s[0] != nil && s[0].Present() || s[1] != nil && s[1].Present() {
+		t.Errorf("Optional(true): got %v", s)
+	}
+
+	c := Optional(Natural[int](), false)
+	tests := []struct {
+		a, b opt.Optional[int]
+		want int
+	}{
+		{opt.Of(1), opt.Empty[int](), -1},
+		{opt.Empty[int](), opt.Of(1), 1},
+		{opt.Empty[int](), nil, 0},
+		{opt.Of(1), opt.Of(1), 0},
+		{opt.Of(1), opt.Of(2), -1},
+	}
+	for _, tt := range tests {
+		if got := c(tt.a, tt.b); got != tt.want {
+			t.Errorf("Optional(false)(%v, %v): expected %d, got %d", tt.a, tt.b, tt.want, got)
+		}
+	}
+}
+
+func TestError(t *testing.T) {
+	c := Error()
+	a, b := errors.New("a"), errors.New("b")
+	tests := []struct {
+		a, b error
+		want int
+	}{
+		{nil, nil, 0},
+		{nil, a, -1},
+		{a, nil, 1},
+		{a, b, -1},
+		{a, errors.New("a"), 0},
+	}
+	for _, tt := range tests {
+		if got := c(tt.a, tt.b); got != tt.want {
+			t.Errorf("Error()(%v, %v): expected %d, got %d", tt.a, tt.b, tt.want, got)
+		}
+	}
+}
+
+func TestResult(t *testing.T) {
+	c := Result(Natural[int]())
+	boom, oops := errors.New("boom"), errors.New("oops")
+	tests := []struct {
+		a, b res.Result[int]
+		want int
+	}{
+		{res.Fail[int](boom), res.Partial(1, boom), -1},
+		{res.Partial(1, boom), res.OK(0), -1},
+		{res.OK(0), res.Fail[int](boom), 1},
+		{res.OK(1), res.OK(2), -1},
+		{res.OK(2), res.OK(2), 0},
+		{res.Partial(1, oops), res.Partial(2, boom), -1},
+		{res.Partial(1, oops), res.Partial(1, boom), 1},
+		{res.Fail[int](boom), res.Fail[int](oops), -1},
+		{res.Fail[int](boom), res.Fail[int](errors.New("boom")), 0},
+	}
+	for _, tt := range tests {
+		if got := c(tt.a, tt.b); got != tt.want {
+			t.Errorf("Result()(%v, %v): expected %d, got %d", tt.a, tt.b, tt.want, got)
+		}
+	}
+}
+
+func TestMap(t *testing.T) {
+	c := Map(Natural[string](), Natural[int]())
+	tests := []struct {
+		a, b map[string]int
+		want int
+	}{
+		{nil, map[string]int{}, 0},
+		{map[string]int{"a": 1, "b": 2}, map[string]int{"b": 2, "a": 1}, 0},
+		{map[string]int{"a": 1, "b": 2}, map[string]int{"a": 1, "b": 3}, -1},
+		{map[string]int{"a": 1, "b": 2}, map[string]int{"a": 1, "c": 0}, -1},
+		{map[string]int{"a": 1}, map[string]int{"a": 1, "b": 0}, -1},
+		{map[string]int{"b": 0}, map[string]int{"a": 9, "b": 0}, 1},
+	}
+	for _, tt := range tests {
+		if got := c(tt.a, tt.b); got != tt.want {
+			t.Errorf("Map()(%v, %v): expected %d, got %d", tt.a, tt.b, tt.want, got)
+		}
+	}
+}
+
+type ipv4 [4]byte
+
+func TestLexicographic(t *testing.T) {
+	s := [][3]int{{1, 2, 4}, {1, 2, 3}, {0, 9, 9}}
+	slices.SortFunc(s, Lexicographic[[3]int](Natural[int]()))
+	if want := [][3]int{{0, 9, 9}, {1, 2, 3}, {1, 2, 4}}; !slices.Equal(s, want) {
+		t.Errorf("got %v, want %v", s, want)
+	}
+
+	c := Lexicographic[ipv4](Natural[byte]())
+	if got := c(ipv4{10, 0, 0, 2}, ipv4{10, 0, 0, 10}); got != -1 {
+		t.Errorf("Lexicographic[ipv4]: expected -1, got %d", got)
+	}
+
+	type ints []int
+	if got := Lexicographic[ints](Natural[int]())(ints{1, 2}, ints{1}); got != 1 {
+		t.Errorf("Lexicographic[ints]: expected 1, got %d", got)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected panic for non-array type")
+		}
+	}()
+	Lexicographic[[3]string](Natural[int]())
+}
+
+func TestNilFirst(t *testing.T) {
+	c := NilFirst(func(a, b fmt.Stringer) int { return strings.Compare(a.String(), b.String()) })
+	var nilTime *time.Location
+	tests := []struct {
+		a, b fmt.Stringer
+		want int
+	}{
+		{nil, nil, 0},
+		{nil, time.Second, -1},
+		{time.Second, nil, 1},
+		{nilTime, time.Second, -1},
+		{nilTime, nil, 0},
+		{time.Second, time.Minute, 1}, // By string: "1s" > "1m0s".
+	}
+	for _, tt := range tests {
+		if got := c(tt.a, tt.b); got != tt.want {
+			t.Errorf("NilFirst()(%v, %v): expected %d, got %d", tt.a, tt.b, tt.want, got)
+		}
+	}
+}
+
+func TestNilLast(t *testing.T) {
+	c := NilLast(DerefNilFirst(Natural[int]()))
+	one, two := 1, 2
+	s := []*int{&two, nil, &one}
+	slices.SortFunc(s, c)
+	if s[0] != &one || s[1] != &two || s[2] != nil {
+		t.Errorf("NilLast: got %v", s)
+	}
+}
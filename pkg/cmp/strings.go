@@ -0,0 +1,270 @@
+package cmp
+
+import (
+	stdcmp "cmp"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// NaturalString returns a Comparer that compares two strings in natural order, where runs of ASCII digits are compared by their numeric value rather than bytewise.
+// Other characters are compared bytewise, as with Natural.
+// Numbers that differ only in leading zeros (eg: "01" and "1") are compared bytewise as a last resort, so that distinct strings are never considered equal.
+//
+// Example:
+//
+//	s := []string{"file10", "file2", "file1"}
+//	slices.SortFunc(s, cmp.NaturalString()) // [file1, file2, file10]
+func NaturalString() Comparer[string] {
+	return compareNatural
+}
+
+func compareNatural(a, b string) int {
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		if isDigit(a[i]) && isDigit(b[j]) {
+			na, nb := digitRun(a[i:]), digitRun(b[j:])
+			i, j = i+len(na), j+len(nb)
+			if c := compareNumbers(na, nb); c != 0 {
+				return c
+			}
+			continue
+		}
+		if a[i] != b[j] {
+			return stdcmp.Compare(a[i], b[j])
+		}
+		i, j = i+1, j+1
+	}
+	if c := stdcmp.Compare(len(a)-i, len(b)-j); c != 0 {
+		return c
+	}
+	return strings.Compare(a, b)
+}
+
+// compareNumbers compares two runs of ASCII digits by their numeric value.
+func compareNumbers(a, b string) int {
+	a, b = strings.TrimLeft(a, "0"), strings.TrimLeft(b, "0")
+	if c := stdcmp.Compare(len(a), len(b)); c != 0 {
+		return c
+	}
+	return strings.Compare(a, b)
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// digitRun returns the run of ASCII digits at the start of the given string.
+func digitRun(s string) string {
+	n := 0
+	for n < len(s) && isDigit(s[n]) {
+		n++
+	}
+	return s[:n]
+}
+
+// CaseInsensitive returns a Comparer that compares two strings rune by rune, ignoring differences in case (by simple Unicode case folding).
+// Strings that differ only in case are considered equal.
+//
+// Example:
+//
+//	s := []string{"banana", "Apple", "cherry"}
+//	slices.SortFunc(s, cmp.CaseInsensitive()) // [Apple, banana, cherry]
+func CaseInsensitive() Comparer[string] {
+	return compareCaseInsensitive
+}
+
+func compareCaseInsensitive(a, b string) int {
+	for a != "" && b != "" {
+		ra, na := utf8.DecodeRuneInString(a)
+		rb, nb := utf8.DecodeRuneInString(b)
+		if c := stdcmp.Compare(foldRune(ra), foldRune(rb)); c != 0 {
+			return c
+		}
+		a, b = a[na:], b[nb:]
+	}
+	return stdcmp.Compare(len(a), len(b))
+}
+
+// foldRune returns the canonical case of the given rune, such that runes differing only in case have the same canonical case.
+func foldRune(r rune) rune {
+	return unicode.ToLower(unicode.ToUpper(r))
+}
+
+// CollationOptions configures a Comparer returned by Collation.
+// The zero value compares strings at full strength.
+type CollationOptions struct {
+	// IgnoreAccents considers strings that differ only in diacritics (eg: "resume" and "résumé") equal.
+	IgnoreAccents bool
+
+	// IgnoreCase considers strings that differ only in case equal.
+	IgnoreCase bool
+
+	// Numeric compares runs of ASCII digits by their numeric value, as with NaturalString.
+	Numeric bool
+}
+
+// Collation returns a Comparer that compares two strings in a linguistically sensible order, following a subset of the Unicode Collation Algorithm (UCA).
+//
+// As in the UCA, strings are compared at three levels, each of which only breaks ties left by the previous one:
+//   - Base characters: letters are compared alphabetically, ignoring case and diacritics; whitespace, punctuation and symbols sort before digits, which sort before letters.
+//   - Diacritics: unaccented letters sort before accented ones (eg: "role" < "rôle" < "roles").
+//   - Case: lowercase letters sort before uppercase ones (eg: "apple" < "Apple").
+//
+// Diacritics are recognised for the precomposed letters of the Latin, Greek and Cyrillic scripts, and for combining marks; ligatures such as 'æ' and 'ß' are compared as their letter sequences ("ae", "ss").
+// Letters of different scripts are ordered by script, and the letters of other scripts by code point.
+// Tailorings for particular languages (eg: Swedish 'ä' after 'z') are not supported.
+//
+// Strings that are equal at all levels compared are ordered bytewise, unless IgnoreAccents or IgnoreCase is set, in which case they are considered equal.
+//
+// Example:
+//
+//	s := []string{"Zoë", "zoe", "Émile", "eve", "Eve"}
+//	slices.SortFunc(s, cmp.Collation(cmp.CollationOptions{})) // [Émile, eve, Eve, zoe, Zoë]
+func Collation(opts CollationOptions) Comparer[string] {
+	return func(a, b string) int {
+		ka, kb := collationKey(a, opts.Numeric), collationKey(b, opts.Numeric)
+		if c := compareWeights(ka, kb, collationElement.primaryWeight); c != 0 {
+			return c
+		}
+		if !opts.IgnoreAccents {
+			if c := compareWeights(ka, kb, collationElement.secondaryWeight); c != 0 {
+				return c
+			}
+		}
+		if !opts.IgnoreCase {
+			if c := compareWeights(ka, kb, collationElement.tertiaryWeight); c != 0 {
+				return c
+			}
+		}
+		if opts.IgnoreAccents || opts.IgnoreCase {
+			return 0
+		}
+		return strings.Compare(a, b)
+	}
+}
+
+// collationElement holds the weights of a character at each level of comparison; a zero weight is ignored at that level.
+type collationElement struct {
+	primary, secondary, tertiary uint32
+}
+
+func (e collationElement) primaryWeight() uint32   { return e.primary }
+func (e collationElement) secondaryWeight() uint32 { return e.secondary }
+func (e collationElement) tertiaryWeight() uint32  { return e.tertiary }
+
+// Character classes, in primary order.
+// A primary weight holds the class in its high bits, above primaryClassBits, and a value within the class in its low bits.
+const (
+	classVariable = iota + 1 // Whitespace, punctuation and symbols.
+	classDigit
+	classLetter
+	classOther
+
+	primaryClassBits = 24
+)
+
+// Secondary and tertiary weights.
+const (
+	secondaryBase   = 1 // Base characters; combining marks weigh their code point.
+	tertiaryLower   = 2
+	tertiaryVariant = 1 // Added to the case weight of characters from an expansion.
+	tertiaryUpper   = 8
+)
+
+// collationKey returns the collation elements of the given string.
+func collationKey(s string, numeric bool) []collationElement {
+	key := make([]collationElement, 0, len(s))
+	for i := 0; i < len(s); {
+		if numeric && isDigit(s[i]) {
+			run := digitRun(s[i:])
+			i += len(run)
+			key = appendNumber(key, run)
+			continue
+		}
+
+		r, n := utf8.DecodeRuneInString(s[i:])
+		i += n
+		if exp, ok := expansions[r]; ok {
+			for _, er := range exp {
+				e := baseElement(er)
+				e.tertiary += tertiaryVariant
+				key = append(key, e)
+			}
+		} else if d, ok := decompositions[r]; ok {
+			for j, dr := range d {
+				if j == 0 {
+					key = append(key, baseElement(dr))
+				} else {
+					key = append(key, collationElement{secondary: uint32(dr)})
+				}
+			}
+		} else if unicode.Is(unicode.Mn, r) {
+			key = append(key, collationElement{secondary: uint32(r)})
+		} else {
+			key = append(key, baseElement(r))
+		}
+	}
+	return key
+}
+
+// appendNumber appends the collation elements of a run of ASCII digits, compared by numeric value: first by the number of significant digits, then digit by digit.
+func appendNumber(key []collationElement, run string) []collationElement {
+	run = strings.TrimLeft(run, "0")
+	key = append(key, collationElement{
+		primary:   classDigit<<primaryClassBits | uint32(min(len(run), 1<<primaryClassBits-1)),
+		secondary: secondaryBase,
+		tertiary:  tertiaryLower,
+	})
+	for i := 0; i < len(run); i++ {
+		key = append(key, collationElement{
+			primary:   classDigit<<primaryClassBits | uint32(run[i]-'0'),
+			secondary: secondaryBase,
+			tertiary:  tertiaryLower,
+		})
+	}
+	return key
+}
+
+// baseElement returns the collation element of a character with no diacritics.
+func baseElement(r rune) collationElement {
+	var class uint32
+	value := uint32(r)
+	switch {
+	case unicode.IsSpace(r) || unicode.IsPunct(r) || unicode.IsSymbol(r):
+		class = classVariable
+	case r >= '0' && r <= '9':
+		class, value = classDigit, uint32(r-'0')
+	case unicode.IsDigit(r):
+		class = classDigit
+	case unicode.IsLetter(r):
+		class, value = classLetter, uint32(foldRune(r))
+	default:
+		class = classOther
+	}
+	tertiary := uint32(tertiaryLower)
+	if unicode.IsUpper(r) {
+		tertiary = tertiaryUpper
+	}
+	return collationElement{
+		primary:   class<<primaryClassBits | value,
+		secondary: secondaryBase,
+		tertiary:  tertiary,
+	}
+}
+
+// compareWeights compares the non-zero weights of the given keys at one level, lexicographically.
+func compareWeights(a, b []collationElement, weight func(collationElement) uint32) int {
+	return slices.Compare(nonZeroWeights(a, weight), nonZeroWeights(b, weight))
+}
+
+func nonZeroWeights(key []collationElement, weight func(collationElement) uint32) []uint32 {
+	ws := make([]uint32, 0, len(key))
+	for _, e := range key {
+		if w := weight(e); w != 0 {
+			ws = append(ws, w)
+		}
+	}
+	return ws
+}
@@ -0,0 +1,106 @@
+package cmp
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestNaturalString(t *testing.T) {
+	s := []string{"file10", "file2", "file1", "file02", "file", "File3", "a10b2", "a10b10", "a9", "10", "9"}
+	slices.SortFunc(s, NaturalString())
+	want := []string{"9", "10", "File3", "a9", "a10b2", "a10b10", "file", "file1", "file02", "file2", "file10"}
+	if !slices.Equal(s, want) {
+		t.Errorf("got %q, want %q", s, want)
+	}
+
+	c := NaturalString()
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"x1", "x1", 0},
+		{"x01", "x1", -1},
+		{"x1", "x01", 1},
+		{"x99999999999999999999", "x100000000000000000000", -1},
+		{"x1y", "x1", 1},
+	}
+	for _, tt := range tests {
+		if got := c(tt.a, tt.b); got != tt.want {
+			t.Errorf("NaturalString()(%q, %q): expected %d, got %d", tt.a, tt.b, tt.want, got)
+		}
+	}
+}
+
+func TestCaseInsensitive(t *testing.T) {
+	c := CaseInsensitive()
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"apple", "APPLE", 0},
+		{"Apple", "banana", -1},
+		{"apple", "Apples", -1},
+		{"ÉCOLE", "école", 0},
+		{"Σίσυφος", "ΣΊΣΥΦΟΣ", 0},
+		{"b", "A", 1},
+	}
+	for _, tt := range tests {
+		if got := c(tt.a, tt.b); got != tt.want {
+			t.Errorf("CaseInsensitive()(%q, %q): expected %d, got %d", tt.a, tt.b, tt.want, got)
+		}
+	}
+}
+
+func TestCollation(t *testing.T) {
+	t.Run("default", func(t *testing.T) {
+		s := []string{"Zoë", "zoe", "Émile", "eve", "Eve", "roles", "rôle", "role", "Straße", "strasse", "Strasse", "ångström", "angstrom", "Øre", "ore", "éclair", "eclair", "10 apples", "9 apples", "-dash", "Zebra", "ärger"}
+		slices.SortFunc(s, Collation(CollationOptions{}))
+		want := []string{"-dash", "10 apples", "9 apples", "angstrom", "ångström", "ärger", "eclair", "éclair", "Émile", "eve", "Eve", "ore", "Øre", "role", "rôle", "roles", "strasse", "Strasse", "Straße", "Zebra", "zoe", "Zoë"}
+		if !slices.Equal(s, want) {
+			t.Errorf("got %q, want %q", s, want)
+		}
+	})
+
+	t.Run("numeric", func(t *testing.T) {
+		s := []string{"file10", "File2", "file1", "file01"}
+		slices.SortFunc(s, Collation(CollationOptions{Numeric: true}))
+		want := []string{"file01", "file1", "File2", "file10"}
+		if !slices.Equal(s, want) {
+			t.Errorf("got %q, want %q", s, want)
+		}
+	})
+
+	t.Run("combining marks", func(t *testing.T) {
+		c := Collation(CollationOptions{IgnoreCase: true})
+		if got := c("é", "é"); got != 0 {
+			t.Errorf("expected decomposed and precomposed forms to be equal, got %d", got)
+		}
+	})
+
+	tests := []struct {
+		opts CollationOptions
+		a, b string
+		want int
+	}{
+		{CollationOptions{}, "resume", "résumé", -1},
+		{CollationOptions{}, "a", "A", -1},
+		{CollationOptions{}, "a", "a", 0},
+		{CollationOptions{IgnoreAccents: true}, "resume", "résumé", 0},
+		{CollationOptions{IgnoreAccents: true}, "resume", "Résumé", -1},
+		{CollationOptions{IgnoreCase: true}, "ÉCOLE", "école", 0},
+		{CollationOptions{IgnoreCase: true}, "ecole", "école", -1},
+		{CollationOptions{IgnoreCase: true, IgnoreAccents: true}, "ECOLE", "école", 0},
+		{CollationOptions{IgnoreCase: true, IgnoreAccents: true}, "ecole", "ecoles", -1},
+		{CollationOptions{}, "straße", "strasse", 1},
+		{CollationOptions{IgnoreCase: true}, "straße", "strasse", 0},
+		{CollationOptions{}, "αβγ", "abc", 1},
+		{CollationOptions{}, "ёж", "еж", 1},
+		{CollationOptions{IgnoreAccents: true}, "ёж", "еж", 0},
+	}
+	for _, tt := range tests {
+		if got := Collation(tt.opts)(tt.a, tt.b); got != tt.want {
+			t.Errorf("Collation(%+v)(%q, %q): expected %d, got %d", tt.opts, tt.a, tt.b, tt.want, got)
+		}
+	}
+}
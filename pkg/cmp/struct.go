@@ -0,0 +1,333 @@
+package cmp
+
+import (
+	stdcmp "cmp"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ByFields returns a Comparer that compares two values of the struct type T (or pointer to struct) by the named fields, in order.
+// Each field spec is a field name, optionally followed by "asc" (the default) or "desc" to reverse the order (eg: "Age desc").
+// A name may be a dotted path to a field of a nested struct (eg: "Address.City"), and may name a field promoted from an embedded struct.
+//
+// The fields are resolved by reflection once, when the Comparer is built.
+// An error is returned if T is not a struct (or pointer to struct), if a spec is malformed, or if a field is unknown, unexported or of a type that cannot be ordered.
+//
+// Fields of the following types can be ordered:
+//   - Booleans (false before true), integers, floats and strings, including named types based on them.
+//   - Types with a method Compare(T) int, such as time.Time.
+//   - Pointers to orderable types, with nil ordered first.
+//   - Arrays and slices of orderable types, ordered lexicographically, as with Slice.
+//   - Structs whose exported fields are all orderable, ordered as with Struct.
+//
+// A nil pointer to a struct along a dotted path is ordered before any non-nil pointer.
+//
+// Example:
+//
+//	type Person struct {
+//		FirstName string
+//		LastName  string
+//		Age       int
+//	}
+//
+//	c, err := cmp.ByFields[Person]("LastName", "FirstName", "Age desc")
+//	if err != nil {
+//		return err
+//	}
+//	slices.SortFunc(people, c)
+func ByFields[T any](fields ...string) (Comparer[T], error) {
+	t := reflect.TypeFor[T]()
+	st := t
+	if st.Kind() == reflect.Pointer {
+		st = st.Elem()
+	}
+	if st.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("cmp: ByFields requires a struct or pointer to struct type; got %s", t)
+	}
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("cmp: ByFields[%s] requires at least one field", t)
+	}
+
+	keys := make([]valueComparer, 0, len(fields))
+	for _, spec := range fields {
+		k, err := fieldComparer(st, spec)
+		if err != nil {
+			return nil, fmt.Errorf("cmp: ByFields[%s]: %w", t, err)
+		}
+		keys = append(keys, k)
+	}
+	return reflectComparer[T](derefIfPointer(t, chainComparers(keys))), nil
+}
+
+// MustByFields behaves like ByFields, but panics if an error occurs.
+// It is intended for initializing package-level variables.
+func MustByFields[T any](fields ...string) Comparer[T] {
+	c, err := ByFields[T](fields...)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// Struct returns a Comparer that compares two values of the struct type T (or pointer to struct) by all their exported fields, in declaration order.
+// Unexported fields are ignored.
+// An error is returned if T is not a struct (or pointer to struct), or if an exported field is of a type that cannot be ordered; see ByFields.
+//
+// Example:
+//
+//	type Version struct {
+//		Major, Minor, Patch int
+//	}
+//
+//	c, _ := cmp.Struct[Version]()
+//	n := c(Version{1, 2, 3}, Version{1, 10, 0}) // -1
+func Struct[T any]() (Comparer[T], error) {
+	t := reflect.TypeFor[T]()
+	st := t
+	if st.Kind() == reflect.Pointer {
+		st = st.Elem()
+	}
+	if st.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("cmp: Struct requires a struct or pointer to struct type; got %s", t)
+	}
+	c, err := typeComparer(st, "")
+	if err != nil {
+		return nil, fmt.Errorf("cmp: Struct[%s]: %w", t, err)
+	}
+	return reflectComparer[T](derefIfPointer(t, c)), nil
+}
+
+// MustStruct behaves like Struct, but panics if an error occurs.
+// It is intended for initializing package-level variables.
+func MustStruct[T any]() Comparer[T] {
+	c, err := Struct[T]()
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// valueComparer compares two reflected values of the same type.
+type valueComparer func(a, b reflect.Value) int
+
+func reflectComparer[T any](c valueComparer) Comparer[T] {
+	return func(a, b T) int {
+		return c(reflect.ValueOf(&a).Elem(), reflect.ValueOf(&b).Elem())
+	}
+}
+
+func chainComparers(cs []valueComparer) valueComparer {
+	return func(a, b reflect.Value) int {
+		for _, c := range cs {
+			if r := c(a, b); r != 0 {
+				return r
+			}
+		}
+		return 0
+	}
+}
+
+// derefIfPointer wraps the given comparer of struct values to compare pointers to them, if t is a pointer type, with nil first.
+func derefIfPointer(t reflect.Type, c valueComparer) valueComparer {
+	if t.Kind() != reflect.Pointer {
+		return c
+	}
+	return nilFirst(c)
+}
+
+func nilFirst(c valueComparer) valueComparer {
+	return func(a, b reflect.Value) int {
+		if a.IsNil() {
+			if b.IsNil() {
+				return 0
+			}
+			return -1
+		}
+		if b.IsNil() {
+			return 1
+		}
+		return c(a.Elem(), b.Elem())
+	}
+}
+
+// fieldComparer returns a comparer of values of the struct type t by the field described by the given spec.
+func fieldComparer(t reflect.Type, spec string) (valueComparer, error) {
+	parts := strings.Fields(spec)
+	if len(parts) == 0 || len(parts) > 2 {
+		return nil, fmt.Errorf("invalid field spec %q", spec)
+	}
+	desc := false
+	if len(parts) == 2 {
+		switch strings.ToLower(parts[1]) {
+		case "asc":
+		case "desc":
+			desc = true
+		default:
+			return nil, fmt.Errorf("invalid order %q in field spec %q; want asc or desc", parts[1], spec)
+		}
+	}
+
+	path := parts[0]
+	get, ft, err := fieldGetter(t, path)
+	if err != nil {
+		return nil, err
+	}
+	c, err := typeComparer(ft, path)
+	if err != nil {
+		return nil, err
+	}
+	return func(a, b reflect.Value) int {
+		fa, oka := get(a)
+		fb, okb := get(b)
+		var r int
+		switch {
+		case !oka || !okb:
+			r = stdcmp.Compare(btoi(oka), btoi(okb))
+		default:
+			r = c(fa, fb)
+		}
+		if desc {
+			return -r
+		}
+		return r
+	}, nil
+}
+
+// fieldGetter returns a function that gets the field at the given dotted path from a value of the struct type t, along with the type of the field.
+// The function returns false if the field is unreachable because of a nil pointer.
+func fieldGetter(t reflect.Type, path string) (func(reflect.Value) (reflect.Value, bool), reflect.Type, error) {
+	var indexes [][]int
+	for i, name := range strings.Split(path, ".") {
+		if i > 0 {
+			if t.Kind() == reflect.Pointer {
+				t = t.Elem()
+			}
+			if t.Kind() != reflect.Struct {
+				return nil, nil, fmt.Errorf("field %q: %s is not a struct", path, t)
+			}
+		}
+		sf, ok := t.FieldByName(name)
+		if !ok {
+			return nil, nil, fmt.Errorf("unknown field %q", path)
+		}
+		if !sf.IsExported() {
+			return nil, nil, fmt.Errorf("field %q is unexported", path)
+		}
+		indexes = append(indexes, sf.Index)
+		t = sf.Type
+	}
+
+	return func(v reflect.Value) (reflect.Value, bool) {
+		for i, index := range indexes {
+			if i > 0 && v.Kind() == reflect.Pointer {
+				if v.IsNil() {
+					return v, false
+				}
+				v = v.Elem()
+			}
+			var err error
+			if v, err = v.FieldByIndexErr(index); err != nil {
+				return v, false // Nil embedded pointer.
+			}
+		}
+		return v, true
+	}, t, nil
+}
+
+// typeComparer returns a comparer of values of the given type, or an error if the type cannot be ordered.
+// The path names the value in error messages.
+func typeComparer(t reflect.Type, path string) (valueComparer, error) {
+	return comparerBuilder{}.build(t, path)
+}
+
+// comparerBuilder builds comparers of types, reusing those of struct types already being built, so that recursive types are supported.
+type comparerBuilder map[reflect.Type]*valueComparer
+
+func (cb comparerBuilder) build(t reflect.Type, path string) (valueComparer, error) {
+	if m, ok := t.MethodByName("Compare"); ok && m.Type.NumIn() == 2 && m.Type.In(1) == t && m.Type.NumOut() == 1 && m.Type.Out(0).Kind() == reflect.Int {
+		return func(a, b reflect.Value) int {
+			return int(m.Func.Call([]reflect.Value{a, b})[0].Int())
+		}, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return func(a, b reflect.Value) int {
+			return stdcmp.Compare(btoi(a.Bool()), btoi(b.Bool()))
+		}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return func(a, b reflect.Value) int {
+			return stdcmp.Compare(a.Int(), b.Int())
+		}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return func(a, b reflect.Value) int {
+			return stdcmp.Compare(a.Uint(), b.Uint())
+		}, nil
+	case reflect.Float32, reflect.Float64:
+		return func(a, b reflect.Value) int {
+			return stdcmp.Compare(a.Float(), b.Float())
+		}, nil
+	case reflect.String:
+		return func(a, b reflect.Value) int {
+			return strings.Compare(a.String(), b.String())
+		}, nil
+	case reflect.Pointer:
+		c, err := cb.build(t.Elem(), path)
+		if err != nil {
+			return nil, err
+		}
+		return nilFirst(c), nil
+	case reflect.Array, reflect.Slice:
+		c, err := cb.build(t.Elem(), path+"[]")
+		if err != nil {
+			return nil, err
+		}
+		return func(a, b reflect.Value) int {
+			n := min(a.Len(), b.Len())
+			for i := 0; i < n; i++ {
+				if r := c(a.Index(i), b.Index(i)); r != 0 {
+					return r
+				}
+			}
+			return stdcmp.Compare(a.Len(), b.Len())
+		}, nil
+	case reflect.Struct:
+		if pc, ok := cb[t]; ok {
+			// Recursive type; defer to the comparer being built.
+			return func(a, b reflect.Value) int {
+				return (*pc)(a, b)
+			}, nil
+		}
+		pc := new(valueComparer)
+		cb[t] = pc
+		var cs []valueComparer
+		for i := 0; i < t.NumField(); i++ {
+			sf := t.Field(i)
+			if !sf.IsExported() {
+				continue
+			}
+			name := sf.Name
+			if path != "" {
+				name = path + "." + name
+			}
+			c, err := cb.build(sf.Type, name)
+			if err != nil {
+				return nil, err
+			}
+			cs = append(cs, func(a, b reflect.Value) int {
+				return c(a.Field(i), b.Field(i))
+			})
+		}
+		*pc = chainComparers(cs)
+		return *pc, nil
+	}
+	return nil, fmt.Errorf("field %q of type %s cannot be ordered", path, t)
+}
+
+func btoi(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
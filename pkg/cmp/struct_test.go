@@ -0,0 +1,190 @@
+package cmp
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+type address struct {
+	City string
+	Zip  *int
+}
+
+type embedded struct {
+	Nickname string
+}
+
+type person struct {
+	embedded
+	FirstName string
+	LastName  string
+	Age       int
+	Born      time.Time
+	Home      *address
+	Tags      []string
+	secret    func()
+}
+
+func TestByFields(t *testing.T) {
+	people := []person{
+		{FirstName: "John", LastName: "Smith", Age: 30},
+		{FirstName: "Jane", LastName: "Doe", Age: 25},
+		{FirstName: "John", LastName: "Doe", Age: 40},
+		{FirstName: "John", LastName: "Doe", Age: 20},
+	}
+	c, err := ByFields[person]("LastName", "FirstName asc", "Age DESC")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	slices.SortFunc(people, c)
+	var got []string
+	for _, p := range people {
+		got = append(got, p.FirstName+" "+p.LastName+" "+time.Duration(p.Age).String())
+	}
+	want := []string{"Jane Doe 25ns", "John Doe 40ns", "John Doe 20ns", "John Smith 30ns"}
+	if !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestByFields_types(t *testing.T) {
+	zip1, zip2 := 1000, 2000
+	t0 := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		field string
+		a, b  person
+		want  int
+	}{
+		{"Born", person{Born: t0}, person{Born: t0.Add(time.Hour)}, -1},
+		{"Nickname", person{embedded: embedded{"b"}}, person{embedded: embedded{"a"}}, 1},
+		{"Home.City", person{Home: &address{City: "A"}}, person{Home: &address{City: "B"}}, -1},
+		{"Home.City", person{}, person{Home: &address{City: "A"}}, -1},
+		{"Home.City", person{}, person{}, 0},
+		{"Home.Zip", person{Home: &address{Zip: &zip2}}, person{Home: &address{Zip: &zip1}}, 1},
+		{"Home.Zip", person{Home: &address{}}, person{Home: &address{Zip: &zip1}}, -1},
+		{"Home", person{Home: &address{City: "A"}}, person{Home: &address{City: "A", Zip: &zip1}}, -1},
+		{"Tags", person{Tags: []string{"a", "b"}}, person{Tags: []string{"a"}}, 1},
+		{"Home.City desc", person{Home: &address{City: "A"}}, person{Home: &address{City: "B"}}, 1},
+	}
+	for _, tt := range tests {
+		c, err := ByFields[person](tt.field)
+		if err != nil {
+			t.Errorf("ByFields(%q): unexpected error: %v", tt.field, err)
+			continue
+		}
+		if got := c(tt.a, tt.b); got != tt.want {
+			t.Errorf("ByFields(%q)(%v, %v): expected %d, got %d", tt.field, tt.a, tt.b, tt.want, got)
+		}
+	}
+}
+
+func TestByFields_pointer(t *testing.T) {
+	c, err := ByFields[*person]("Age")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := c(nil, &person{}); got != -1 {
+		t.Errorf("expected -1, got %d", got)
+	}
+	if got := c(&person{Age: 2}, &person{Age: 1}); got != 1 {
+		t.Errorf("expected 1, got %d", got)
+	}
+}
+
+func TestByFields_errors(t *testing.T) {
+	tests := []struct {
+		fields []string
+		want   string
+	}{
+		{nil, "requires at least one field"},
+		{[]string{"Unknown"}, `unknown field "Unknown"`},
+		{[]string{"secret"}, `field "secret" is unexported`},
+		{[]string{"Age sideways"}, `invalid order "sideways"`},
+		{[]string{"Age asc desc"}, `invalid field spec "Age asc desc"`},
+		{[]string{" "}, `invalid field spec " "`},
+		{[]string{"Age.Years"}, `field "Age.Years": int is not a struct`},
+		{[]string{"Home.Street"}, `unknown field "Home.Street"`},
+	}
+	for _, tt := range tests {
+		_, err := ByFields[person](tt.fields...)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("ByFields(%q): got error %v, want error containing %q", tt.fields, err, tt.want)
+		}
+	}
+
+	type unsortable struct {
+		M map[string]int
+	}
+	if _, err := ByFields[unsortable]("M"); err == nil || !strings.Contains(err.Error(), `field "M" of type map[string]int cannot be ordered`) {
+		t.Errorf("got error %v, want unsortable field error", err)
+	}
+	if _, err := ByFields[int]("X"); err == nil || !strings.Contains(err.Error(), "requires a struct") {
+		t.Errorf("got error %v, want struct type error", err)
+	}
+}
+
+func TestMustByFields(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected panic")
+		}
+	}()
+	MustByFields[person]("Unknown")
+}
+
+type version struct {
+	Major, Minor, Patch int
+	label               string
+}
+
+type node struct {
+	Value int
+	Next  *node
+}
+
+func TestStruct(t *testing.T) {
+	c, err := Struct[version]()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	tests := []struct {
+		a, b version
+		want int
+	}{
+		{version{1, 2, 3, "a"}, version{1, 2, 3, "b"}, 0},
+		{version{1, 2, 3, ""}, version{1, 10, 0, ""}, -1},
+		{version{2, 0, 0, ""}, version{1, 10, 0, ""}, 1},
+	}
+	for _, tt := range tests {
+		if got := c(tt.a, tt.b); got != tt.want {
+			t.Errorf("Struct()(%v, %v): expected %d, got %d", tt.a, tt.b, tt.want, got)
+		}
+	}
+
+	t.Run("recursive", func(t *testing.T) {
+		c := MustStruct[node]()
+		a := node{Value: 1, Next: &node{Value: 2}}
+		b := node{Value: 1, Next: &node{Value: 3}}
+		if got := c(a, b); got != -1 {
+			t.Errorf("expected -1, got %d", got)
+		}
+		if got := c(a, a); got != 0 {
+			t.Errorf("expected 0, got %d", got)
+		}
+	})
+
+	t.Run("unsortable", func(t *testing.T) {
+		type nested struct {
+			Inner struct{ F func() }
+		}
+		_, err := Struct[nested]()
+		if err == nil || !strings.Contains(err.Error(), `field "Inner.F" of type func() cannot be ordered`) {
+			t.Errorf("got error %v, want unsortable field error", err)
+		}
+		if _, err := Struct[string](); err == nil {
+			t.Errorf("expected error")
+		}
+	})
+}
@@ -0,0 +1,273 @@
+package env
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/jpfourny/papaya/v2/pkg/opt"
+	"github.com/jpfourny/papaya/v2/pkg/stream/mapper"
+)
+
+// BindOptions configures the behaviour of Bind.
+// The zero value is ready to use.
+type BindOptions struct {
+	// Prefix is prepended to the key of every field (eg: "APP_").
+	Prefix string
+	// Separator separates the elements of slice and map fields; defaults to ",".
+	Separator string
+	// KeyValueSeparator separates the key and value of each map entry; defaults to "=".
+	KeyValueSeparator string
+}
+
+// BindError aggregates the VarError of every variable that could not be bound.
+type BindError struct {
+	Errors []*VarError
+}
+
+func (e *BindError) Error() string {
+	return joinVarErrors(fmt.Sprintf("env: cannot bind %d variable(s)", len(e.Errors)), e.Errors)
+}
+
+func (e *BindError) Unwrap() []error {
+	return unwrapVarErrors(e.Errors)
+}
+
+// Bind populates the fields of the struct pointed to by target from environment variables.
+// The target must be a non-nil pointer to a struct; otherwise, an error is returned.
+//
+// Fields are bound according to the following struct tags:
+//   - `env:"KEY"` names the environment variable; fields without the tag (or with `env:"-"`) are skipped.
+//   - `default:"VALUE"` provides a value to use if the variable is unset.
+//   - `required:"true"` reports ErrMissing if the variable is unset and there is no default.
+//
+// A nested struct field is bound recursively.
+// If it has an env tag, the tag followed by an underscore is prepended to the keys of its fields (eg: `env:"DB"` binds "DB_PORT").
+//
+// Supported field types are string, bool, integers, floats, time.Duration, types implementing encoding.TextUnmarshaler, and pointers to any of these.
+// Slice fields are parsed from a list of elements, split by BindOptions.Separator.
+// Map fields are parsed from a list of entries, split by BindOptions.Separator, each having a key and value split by BindOptions.KeyValueSeparator.
+// A Secret field is parsed as for its wrapped type, may be read from a file (see GetSecret), and its value is redacted from errors.
+// Fields whose variable is unset and have no default are left unchanged.
+//
+// All fields are processed, even if some fail.
+// If any variables are missing or malformed, a *BindError listing all of them is returned.
+//
+// Example usage:
+//
+//	type Config struct {
+//		Port    int           `env:"PORT" default:"8080"`
+//		Timeout time.Duration `env:"TIMEOUT" required:"true"`
+//		Hosts   []string      `env:"HOSTS"`
+//		DB      struct {
+//			URL string `env:"URL" required:"true"`
+//		} `env:"DB"`
+//	}
+//
+//	var cfg Config
+//	err := env.Bind(&cfg, env.BindOptions{Prefix: "APP_"}) // Reads APP_PORT, APP_TIMEOUT, APP_HOSTS and APP_DB_URL.
+func Bind(target any, opts BindOptions) error {
+	return bind(OS(), target, opts)
+}
+
+func bind(src Source, target any, opts BindOptions) error {
+	rv := reflect.ValueOf(target)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("env: Bind requires a non-nil pointer to a struct; got %T", target)
+	}
+	b := binder{src: src, opts: opts.withDefaults()}
+	walkFields(rv.Elem(), opts.Prefix, "", b.bindField)
+	if len(b.errs) > 0 {
+		return &BindError{Errors: b.errs}
+	}
+	return nil
+}
+
+// withDefaults returns a copy of the options with defaults applied to unset fields.
+func (o BindOptions) withDefaults() BindOptions {
+	if o.Separator == "" {
+		o.Separator = ","
+	}
+	if o.KeyValueSeparator == "" {
+		o.KeyValueSeparator = "="
+	}
+	return o
+}
+
+type binder struct {
+	src  Source
+	opts BindOptions
+	errs []*VarError
+}
+
+var (
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+	durationType        = reflect.TypeFor[time.Duration]()
+)
+
+// walkFields calls visit for each bindable field of the given struct value, recursing into nested structs.
+// The key passed to visit includes the given prefix and those of any enclosing nested structs.
+func walkFields(v reflect.Value, prefix, path string, visit func(fv reflect.Value, sf reflect.StructField, key, path string)) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		key, tagged := sf.Tag.Lookup("env")
+		if key == "-" {
+			continue
+		}
+		fv := v.Field(i)
+		fpath := path + sf.Name
+
+		if sf.Type.Kind() == reflect.Struct && !isParsable(sf.Type) {
+			// Nested struct; the env tag (if any) extends the prefix.
+			nestedPrefix := prefix
+			if tagged && key != "" {
+				nestedPrefix += key + "_"
+			}
+			walkFields(fv, nestedPrefix, fpath+".", visit)
+			continue
+		}
+		if !tagged || key == "" {
+			continue
+		}
+		visit(fv, sf, prefix+key, fpath)
+	}
+}
+
+func (b *binder) bindField(fv reflect.Value, sf reflect.StructField, key, path string) {
+	secret := isSecret(sf.Type)
+	var s string
+	var ok bool
+	if secret {
+		var err error
+		if s, ok, err = lookupSecret(b.src, key); err != nil {
+			b.errs = append(b.errs, &VarError{Key: key + FileSuffix, Field: path, Err: err})
+			return
+		}
+	} else {
+		s, ok = b.src.Lookup(key)
+	}
+	if !ok {
+		s, ok = sf.Tag.Lookup("default")
+	}
+	if !ok {
+		if sf.Tag.Get("required") == "true" {
+			b.errs = append(b.errs, &VarError{Key: key, Field: path, Err: ErrMissing})
+		}
+		return
+	}
+
+	pv, err := b.parse(s, sf.Type)
+	if err != nil {
+		if secret {
+			s = Redacted
+		}
+		b.errs = append(b.errs, &VarError{Key: key, Value: s, Field: path, Err: err})
+		return
+	}
+	fv.Set(pv)
+}
+
+// parse returns the given string parsed as a value of the given type.
+func (b *binder) parse(s string, t reflect.Type) (reflect.Value, error) {
+	if reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		pv := reflect.New(t)
+		if err := pv.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s)); err != nil {
+			if isSecret(t) {
+				return reflect.Value{}, err // Must not include the value.
+			}
+			return reflect.Value{}, fmt.Errorf("cannot parse as %s: %w", t, err)
+		}
+		return pv.Elem(), nil
+	}
+	if t == durationType {
+		return fromOptional(mapper.TryParseDuration[string]()(s), t)
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return reflect.ValueOf(s).Convert(t), nil
+	case reflect.Bool:
+		return fromOptional(mapper.TryParseBool[string]()(s), t)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return fromOptional(mapper.TryParseInt[string, int64](10, t.Bits())(s), t)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return fromOptional(mapper.TryParseUint[string, uint64](10, t.Bits())(s), t)
+	case reflect.Float32, reflect.Float64:
+		return fromOptional(mapper.TryParseFloat[string, float64](t.Bits())(s), t)
+	case reflect.Pointer:
+		ev, err := b.parse(s, t.Elem())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		pv := reflect.New(t.Elem())
+		pv.Elem().Set(ev)
+		return pv, nil
+	case reflect.Slice:
+		return b.parseSlice(s, t)
+	case reflect.Map:
+		return b.parseMap(s, t)
+	}
+	return reflect.Value{}, fmt.Errorf("unsupported field type %s", t)
+}
+
+func (b *binder) parseSlice(s string, t reflect.Type) (reflect.Value, error) {
+	parts := splitList(s, b.opts.Separator)
+	sv := reflect.MakeSlice(t, 0, len(parts))
+	for i, part := range parts {
+		ev, err := b.parse(part, t.Elem())
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("element %d: %w", i, err)
+		}
+		sv = reflect.Append(sv, ev)
+	}
+	return sv, nil
+}
+
+func (b *binder) parseMap(s string, t reflect.Type) (reflect.Value, error) {
+	parts := splitList(s, b.opts.Separator)
+	mv := reflect.MakeMapWithSize(t, len(parts))
+	for _, part := range parts {
+		k, v, found := strings.Cut(part, b.opts.KeyValueSeparator)
+		if !found {
+			return reflect.Value{}, fmt.Errorf("invalid map entry %q: missing %q", part, b.opts.KeyValueSeparator)
+		}
+		kv, err := b.parse(strings.TrimSpace(k), t.Key())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		vv, err := b.parse(strings.TrimSpace(v), t.Elem())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		mv.SetMapIndex(kv, vv)
+	}
+	return mv, nil
+}
+
+// isSecret returns true if the given type is a Secret, or a pointer to one.
+func isSecret(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.Implements(secretInterface)
+}
+
+// isParsable returns true if values of the given struct type are parsed from a single variable, rather than bound field by field.
+func isParsable(t reflect.Type) bool {
+	return reflect.PointerTo(t).Implements(textUnmarshalerType)
+}
+
+// fromOptional converts the result of a mapper.TryParse* function to a reflect.Value of the given type.
+// The error does not repeat the string, which is reported by the VarError.
+func fromOptional[V any](o opt.Optional[V], t reflect.Type) (reflect.Value, error) {
+	if v, ok := o.Get(); ok {
+		return reflect.ValueOf(v).Convert(t), nil
+	}
+	return reflect.Value{}, fmt.Errorf("cannot parse as %s", t)
+}
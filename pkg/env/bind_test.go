@@ -0,0 +1,158 @@
+package env
+
+import (
+	"errors"
+	"net/netip"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBind(t *testing.T) {
+	type DB struct {
+		URL  string `env:"URL" required:"true"`
+		Pool int    `env:"POOL" default:"4"`
+	}
+	type Config struct {
+		Port    int            `env:"PORT" default:"8080"`
+		Debug   bool           `env:"DEBUG"`
+		Ratio   float32        `env:"RATIO"`
+		Max     uint8          `env:"MAX"`
+		Timeout time.Duration  `env:"TIMEOUT" required:"true"`
+		Hosts   []string       `env:"HOSTS"`
+		Ports   []int          `env:"PORTS"`
+		Labels  map[string]int `env:"LABELS"`
+		Addr    netip.Addr     `env:"ADDR"`
+		Name    *string        `env:"NAME"`
+		Skipped string         `env:"-"`
+		Untag   string         // No env tag; left as-is.
+		DB      DB             `env:"DB"`
+		Inline  struct {
+			X int `env:"X"`
+		}
+		Started time.Time         `env:"STARTED"`
+		Extra   map[string]string `env:"EXTRA"`
+	}
+
+	revert := SetAllMap(map[string]string{
+		"APP_DEBUG":   "true",
+		"APP_RATIO":   "0.5",
+		"APP_MAX":     "255",
+		"APP_TIMEOUT": "3s",
+		"APP_HOSTS":   "a, b ,c",
+		"APP_PORTS":   "1,2,3",
+		"APP_LABELS":  "x=1, y=2",
+		"APP_ADDR":    "10.0.0.1",
+		"APP_NAME":    "papaya",
+		"APP_SKIPPED": "nope",
+		"APP_DB_URL":  "postgres://localhost",
+		"APP_X":       "7",
+		"APP_STARTED": "2024-01-02T03:04:05Z",
+		"APP_EXTRA":   "",
+	})
+	defer revert()
+
+	cfg := Config{Untag: "kept"}
+	if err := Bind(&cfg, BindOptions{Prefix: "APP_"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	name := "papaya"
+	want := Config{
+		Port:    8080,
+		Debug:   true,
+		Ratio:   0.5,
+		Max:     255,
+		Timeout: 3 * time.Second,
+		Hosts:   []string{"a", "b", "c"},
+		Ports:   []int{1, 2, 3},
+		Labels:  map[string]int{"x": 1, "y": 2},
+		Addr:    netip.MustParseAddr("10.0.0.1"),
+		Name:    &name,
+		Untag:   "kept",
+		DB:      DB{URL: "postgres://localhost", Pool: 4},
+		Started: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Extra:   map[string]string{},
+	}
+	want.Inline.X = 7
+	if !reflect.DeepEqual(cfg, want) {
+		t.Errorf("got %+v, want %+v", cfg, want)
+	}
+}
+
+func TestBind_Separators(t *testing.T) {
+	type Config struct {
+		Tags map[string]string `env:"TAGS"`
+	}
+	revert := SetAllMap(map[string]string{"TAGS": "a:1;b:2"})
+	defer revert()
+
+	var cfg Config
+	if err := Bind(&cfg, BindOptions{Separator: ";", KeyValueSeparator: ":"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string]string{"a": "1", "b": "2"}
+	if !reflect.DeepEqual(cfg.Tags, want) {
+		t.Errorf("got %v, want %v", cfg.Tags, want)
+	}
+}
+
+func TestBind_Errors(t *testing.T) {
+	type Config struct {
+		Port    int            `env:"PORT"`
+		Small   int8           `env:"SMALL"`
+		Timeout time.Duration  `env:"TIMEOUT" required:"true"`
+		Labels  map[string]int `env:"LABELS"`
+		Addr    netip.Addr     `env:"ADDR"`
+		Ch      chan int       `env:"CH"`
+		Ok      string         `env:"OK" required:"true"`
+	}
+	revert := SetAllMap(map[string]string{
+		"PORT":   "abc",
+		"SMALL":  "300",
+		"LABELS": "x",
+		"ADDR":   "not-an-ip",
+		"CH":     "1",
+		"OK":     "yes",
+	})
+	defer revert()
+	Unset("TIMEOUT")
+
+	var cfg Config
+	err := Bind(&cfg, BindOptions{})
+
+	var be *BindError
+	if !errors.As(err, &be) {
+		t.Fatalf("got %v, want *BindError", err)
+	}
+	var keys []string
+	for _, ve := range be.Errors {
+		keys = append(keys, ve.Key)
+	}
+	wantKeys := []string{"PORT", "SMALL", "TIMEOUT", "LABELS", "ADDR", "CH"}
+	if !reflect.DeepEqual(keys, wantKeys) {
+		t.Errorf("got %v, want %v", keys, wantKeys)
+	}
+	if !errors.Is(err, ErrMissing) {
+		t.Errorf("expected error to wrap ErrMissing")
+	}
+	if !strings.Contains(err.Error(), "TIMEOUT (field Timeout): required variable is not set") {
+		t.Errorf("got %q", err.Error())
+	}
+	if got, want := be.Errors[0].Error(), `PORT="abc" (field Port): cannot parse as int`; got != want {
+		t.Errorf("got %q, want %q", got, want) // The value is reported once.
+	}
+	if cfg.Ok != "yes" {
+		t.Errorf("got %q, want %q", cfg.Ok, "yes")
+	}
+}
+
+func TestBind_InvalidTarget(t *testing.T) {
+	type Config struct{}
+	for _, target := range []any{nil, Config{}, (*Config)(nil), new(int)} {
+		if err := Bind(target, BindOptions{}); err == nil {
+			t.Errorf("expected error for target %#v", target)
+		}
+	}
+}
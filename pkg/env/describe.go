@@ -0,0 +1,122 @@
+package env
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+)
+
+// VarDescription describes an environment variable declared by a field of a config struct.
+type VarDescription struct {
+	Key         string // The environment variable key, including any prefixes.
+	Field       string // The path of the struct field (eg: "DB.Port").
+	Type        string // The Go type of the field (eg: "time.Duration").
+	Default     string // The value of the default tag; empty, if there is none.
+	Required    bool   // Whether the required tag is "true".
+	Description string // The value of the desc tag.
+	Constraints string // The validation constraints (eg: "min=1, max=65535"); see Validate.
+}
+
+// Description describes all the environment variables declared by a config struct.
+type Description []VarDescription
+
+// Describe returns a Description of the environment variables declared by the given config struct (or pointer to struct), as read by Bind with the given options.
+// In addition to the tags read by Bind, a `desc:"..."` tag provides a human-readable description of each variable.
+// The Description can be rendered with Markdown or Text, so that the variables honoured by a service can be documented from the same declarations that Bind reads.
+//
+// Example usage:
+//
+//	type Config struct {
+//		Port int `env:"PORT" default:"8080" desc:"Port to listen on" min:"1" max:"65535"`
+//	}
+//
+//	d, _ := env.Describe(Config{}, env.BindOptions{Prefix: "APP_"})
+//	out := d[0].Key // "APP_PORT"
+func Describe(cfg any, opts BindOptions) (Description, error) {
+	v, err := structValue("Describe", cfg)
+	if err != nil {
+		return nil, err
+	}
+	var d Description
+	walkFields(v, opts.Prefix, "", func(_ reflect.Value, sf reflect.StructField, key, path string) {
+		d = append(d, VarDescription{
+			Key:         key,
+			Field:       path,
+			Type:        sf.Type.String(),
+			Default:     sf.Tag.Get("default"),
+			Required:    sf.Tag.Get("required") == "true",
+			Description: sf.Tag.Get("desc"),
+			Constraints: describeConstraints(sf.Tag),
+		})
+	})
+	return d, nil
+}
+
+// Markdown returns the Description as a Markdown table.
+//
+// Example usage:
+//
+//	d, _ := env.Describe(Config{}, env.BindOptions{})
+//	fmt.Println(d.Markdown())
+//	// | Name | Type | Default | Required | Constraints | Description |
+//	// |------|------|---------|----------|-------------|-------------|
+//	// | `PORT` | int | `8080` | no | min=1, max=65535 | Port to listen on |
+func (d Description) Markdown() string {
+	var sb strings.Builder
+	sb.WriteString("| Name | Type | Default | Required | Constraints | Description |\n")
+	sb.WriteString("|------|------|---------|----------|-------------|-------------|\n")
+	for _, vd := range d {
+		def := ""
+		if vd.Default != "" {
+			def = "`" + vd.Default + "`"
+		}
+		cells := []string{"`" + vd.Key + "`", vd.Type, def, yesNo(vd.Required), vd.Constraints, vd.Description}
+		for i := range cells {
+			cells[i] = strings.ReplaceAll(cells[i], "|", `\|`)
+		}
+		sb.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+	}
+	return sb.String()
+}
+
+// Text returns the Description as a plain-text table with aligned columns, suitable for `--help` output.
+func (d Description) Text() string {
+	var sb strings.Builder
+	w := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "NAME\tTYPE\tDEFAULT\tREQUIRED\tCONSTRAINTS\tDESCRIPTION")
+	for _, vd := range d {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", vd.Key, vd.Type, vd.Default, yesNo(vd.Required), vd.Constraints, vd.Description)
+	}
+	_ = w.Flush()
+	return sb.String()
+}
+
+// structValue returns the struct value of the given struct or pointer to struct.
+func structValue(fn string, cfg any) (reflect.Value, error) {
+	v := reflect.ValueOf(cfg)
+	if v.Kind() == reflect.Pointer && !v.IsNil() {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return reflect.Value{}, fmt.Errorf("env: %s requires a struct or non-nil pointer to a struct; got %T", fn, cfg)
+	}
+	return v, nil
+}
+
+func describeConstraints(tag reflect.StructTag) string {
+	var cs []string
+	for _, name := range constraintTags {
+		if c, ok := tag.Lookup(name); ok {
+			cs = append(cs, name+"="+c)
+		}
+	}
+	return strings.Join(cs, ", ")
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
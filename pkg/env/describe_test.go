@@ -0,0 +1,62 @@
+package env
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+type describeConfig struct {
+	Port    int           `env:"PORT" default:"8080" desc:"Port to listen on" min:"1" max:"65535"`
+	Timeout time.Duration `env:"TIMEOUT" required:"true" desc:"Request timeout"`
+	Level   string        `env:"LEVEL" default:"info" oneof:"debug info"`
+	DB      struct {
+		URL string `env:"URL" required:"true" desc:"Connection string | DSN"`
+	} `env:"DB"`
+	Ignored string
+}
+
+func TestDescribe(t *testing.T) {
+	got, err := Describe(&describeConfig{}, BindOptions{Prefix: "APP_"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := Description{
+		{Key: "APP_PORT", Field: "Port", Type: "int", Default: "8080", Description: "Port to listen on", Constraints: "min=1, max=65535"},
+		{Key: "APP_TIMEOUT", Field: "Timeout", Type: "time.Duration", Required: true, Description: "Request timeout"},
+		{Key: "APP_LEVEL", Field: "Level", Type: "string", Default: "info", Constraints: "oneof=debug info"},
+		{Key: "APP_DB_URL", Field: "DB.URL", Type: "string", Required: true, Description: "Connection string | DSN"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	if _, err = Describe(42, BindOptions{}); err == nil {
+		t.Errorf("expected error for non-struct")
+	}
+}
+
+func TestDescription_Markdown(t *testing.T) {
+	d, _ := Describe(describeConfig{}, BindOptions{})
+	want := "| Name | Type | Default | Required | Constraints | Description |\n" +
+		"|------|------|---------|----------|-------------|-------------|\n" +
+		"| `PORT` | int | `8080` | no | min=1, max=65535 | Port to listen on |\n" +
+		"| `TIMEOUT` | time.Duration |  | yes |  | Request timeout |\n" +
+		"| `LEVEL` | string | `info` | no | oneof=debug info |  |\n" +
+		"| `DB_URL` | string |  | yes |  | Connection string \\| DSN |\n"
+	if got := d.Markdown(); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestDescription_Text(t *testing.T) {
+	d, _ := Describe(describeConfig{}, BindOptions{})
+	want := "NAME     TYPE           DEFAULT  REQUIRED  CONSTRAINTS       DESCRIPTION\n" +
+		"PORT     int            8080     no        min=1, max=65535  Port to listen on\n" +
+		"TIMEOUT  time.Duration           yes                         Request timeout\n" +
+		"LEVEL    string         info     no        oneof=debug info  \n" +
+		"DB_URL   string                  yes                         Connection string | DSN\n"
+	if got := d.Text(); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
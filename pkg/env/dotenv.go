@@ -0,0 +1,402 @@
+package env
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"strings"
+)
+
+// ParseError describes a syntax error in dotenv input.
+type ParseError struct {
+	Path string // The path of the file; empty, if parsed from a reader.
+	Line int    // The line number, starting at 1.
+	Msg  string // A description of the error.
+}
+
+func (e *ParseError) Error() string {
+	if e.Path == "" {
+		return fmt.Sprintf("env: line %d: %s", e.Line, e.Msg)
+	}
+	return fmt.Sprintf("env: %s:%d: %s", e.Path, e.Line, e.Msg)
+}
+
+// Parse returns the variables defined by the given dotenv-formatted input.
+// The input is not applied to the environment; see LoadFile for that.
+//
+// The format consists of lines of KEY=VALUE, with the following rules:
+//   - Blank lines and lines starting with # are ignored.
+//   - An optional `export` prefix before the key is ignored.
+//   - Unquoted values are trimmed of surrounding whitespace, and end at a # preceded by whitespace (an inline comment).
+//   - Single-quoted values are taken literally, and may span multiple lines.
+//   - Double-quoted values may span multiple lines, and support the escapes \n, \r, \t, \", \\ and \$.
+//   - Unquoted and double-quoted values expand references of the form $VAR, ${VAR} and ${VAR:-default}.
+//     The default is used if VAR is unset or empty.
+//
+// References are resolved against the variables defined earlier in the input, and then against the environment.
+// Undefined references expand to the empty string.
+//
+// Example usage:
+//
+//	vars, err := env.Parse(strings.NewReader("HOST=localhost\nURL=http://${HOST}:${PORT:-8080}"))
+//	out := vars["URL"] // "http://localhost:8080" (if PORT is unset)
+func Parse(r io.Reader) (map[string]string, error) {
+	return parseDotenv(r, "", os.LookupEnv)
+}
+
+// LoadFile parses the dotenv file at the given path (see Parse for the format), and sets the variables it defines in the environment.
+// Existing variables with the same keys are overwritten.
+// Returns a function that can be called to revert the changes, as with SetAllMap.
+// If the file cannot be read or parsed, no variables are set, and the error is returned.
+//
+// Example usage:
+//
+//	revert, err := env.LoadFile(".env")
+//	if err != nil {
+//		return err
+//	}
+//	defer revert()
+func LoadFile(path string) (revert func(), err error) {
+	vars, err := parseFile(path, os.LookupEnv)
+	if err != nil {
+		return nil, err
+	}
+	return SetAllMap(vars), nil
+}
+
+// LoadOverlay parses the dotenv files at the given paths (see Parse for the format), and sets the variables they define in the environment.
+// Files are applied in order, such that a variable defined in a later file takes precedence over the same variable in an earlier file.
+// References in a file may refer to variables defined in earlier files.
+// Files that do not exist are skipped, so optional overrides (eg: ".env.local") may be listed.
+// Existing variables with the same keys are overwritten.
+// Returns a function that can be called to revert the changes, as with SetAllMap.
+// If a file cannot be read or parsed, no variables are set, and the error is returned.
+//
+// Example usage:
+//
+//	revert, err := env.LoadOverlay(".env", ".env.test", ".env.local")
+//	if err != nil {
+//		return err
+//	}
+//	defer revert()
+func LoadOverlay(paths ...string) (revert func(), err error) {
+	merged := make(map[string]string)
+	lookup := func(key string) (string, bool) {
+		if v, ok := merged[key]; ok {
+			return v, true
+		}
+		return os.LookupEnv(key)
+	}
+	for _, path := range paths {
+		vars, err := parseFile(path, lookup)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range vars {
+			merged[k] = v
+		}
+	}
+	return SetAllMap(merged), nil
+}
+
+func parseFile(path string, lookup func(string) (string, bool)) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	return parseDotenv(f, path, lookup)
+}
+
+func parseDotenv(r io.Reader, path string, lookup func(string) (string, bool)) (map[string]string, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	p := &dotenvParser{src: string(b), path: path, line: 1, vars: make(map[string]string)}
+	p.lookup = func(key string) (string, bool) {
+		if v, ok := p.vars[key]; ok {
+			return v, true
+		}
+		return lookup(key)
+	}
+	if err = p.parse(); err != nil {
+		return nil, err
+	}
+	return p.vars, nil
+}
+
+type dotenvParser struct {
+	src    string
+	pos    int
+	path   string
+	line   int
+	vars   map[string]string
+	lookup func(string) (string, bool)
+}
+
+func (p *dotenvParser) parse() error {
+	for {
+		p.skipBlank()
+		if p.eof() {
+			return nil
+		}
+		if p.peek() == '#' {
+			p.skipLine()
+			continue
+		}
+		if err := p.parseAssignment(); err != nil {
+			return err
+		}
+	}
+}
+
+func (p *dotenvParser) parseAssignment() error {
+	key := p.readKey()
+	if key == "export" && (p.peek() == ' ' || p.peek() == '\t') {
+		p.skipSpaces()
+		key = p.readKey()
+	}
+	if key == "" {
+		return p.errorf("expected variable name")
+	}
+	p.skipSpaces()
+	if p.eof() || p.peek() != '=' {
+		return p.errorf("expected '=' after %s", key)
+	}
+	p.pos++
+	p.skipSpaces()
+
+	var value string
+	var err error
+	switch p.peek() {
+	case '\'':
+		value, err = p.readSingleQuoted()
+	case '"':
+		value, err = p.readDoubleQuoted()
+	default:
+		value = p.readUnquoted()
+	}
+	if err != nil {
+		return err
+	}
+
+	// Only a comment may follow on the same line.
+	p.skipSpaces()
+	if !p.eof() && p.peek() != '\n' && p.peek() != '\r' && p.peek() != '#' {
+		return p.errorf("unexpected character %q after value of %s", p.peek(), key)
+	}
+	p.skipLine()
+	p.vars[key] = value
+	return nil
+}
+
+func (p *dotenvParser) readKey() string {
+	start := p.pos
+	for !p.eof() {
+		c := p.peek()
+		if c == '_' || c == '.' || isAlpha(c) || (p.pos > start && isDigit(c)) {
+			p.pos++
+			continue
+		}
+		break
+	}
+	return p.src[start:p.pos]
+}
+
+func (p *dotenvParser) readSingleQuoted() (string, error) {
+	p.pos++ // Opening quote.
+	end := strings.IndexByte(p.src[p.pos:], '\'')
+	if end < 0 {
+		return "", p.errorf("unterminated single-quoted value")
+	}
+	value := p.src[p.pos : p.pos+end]
+	p.line += strings.Count(value, "\n")
+	p.pos += end + 1
+	return value, nil
+}
+
+func (p *dotenvParser) readDoubleQuoted() (string, error) {
+	start := p.line
+	p.pos++ // Opening quote.
+	var sb strings.Builder
+	for !p.eof() {
+		c := p.next()
+		switch c {
+		case '"':
+			return sb.String(), nil
+		case '\\':
+			if p.eof() {
+				break
+			}
+			switch e := p.next(); e {
+			case 'n':
+				sb.WriteByte('\n')
+			case 'r':
+				sb.WriteByte('\r')
+			case 't':
+				sb.WriteByte('\t')
+			case '"', '\\', '$':
+				sb.WriteByte(e)
+			default:
+				sb.WriteByte('\\')
+				sb.WriteByte(e)
+			}
+		case '$':
+			if err := p.expand(&sb); err != nil {
+				return "", err
+			}
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	p.line = start
+	return "", p.errorf("unterminated double-quoted value")
+}
+
+func (p *dotenvParser) readUnquoted() string {
+	var sb strings.Builder
+	for !p.eof() {
+		c := p.peek()
+		if c == '\n' || c == '\r' {
+			break
+		}
+		if c == '#' && (sb.Len() == 0 || isSpace(p.src[p.pos-1])) {
+			break // Inline comment.
+		}
+		p.pos++
+		if c == '$' {
+			// Unquoted references cannot be malformed enough to fail; an unterminated ${ is kept as-is.
+			if err := p.expand(&sb); err != nil {
+				sb.WriteByte('$')
+			}
+			continue
+		}
+		sb.WriteByte(c)
+	}
+	return strings.TrimRight(sb.String(), " \t")
+}
+
+// expand writes the value of the reference following a '$' to the given builder.
+// If no reference follows, a literal '$' is written.
+func (p *dotenvParser) expand(sb *strings.Builder) error {
+	if !p.eof() && p.peek() == '{' {
+		end := matchingBrace(p.src[p.pos:])
+		if end < 0 {
+			return p.errorf("unterminated variable reference")
+		}
+		ref := p.src[p.pos+1 : p.pos+end]
+		p.pos += end + 1
+		name, def, hasDefault := strings.Cut(ref, ":-")
+		if v, _ := p.lookup(name); v != "" || !hasDefault {
+			sb.WriteString(v)
+			return nil
+		}
+		// Expand references within the default.
+		sub := &dotenvParser{src: def, path: p.path, line: p.line, lookup: p.lookup}
+		for !sub.eof() {
+			if c := sub.next(); c != '$' {
+				sb.WriteByte(c)
+			} else if err := sub.expand(sb); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	start := p.pos
+	for !p.eof() && (p.peek() == '_' || isAlpha(p.peek()) || (p.pos > start && isDigit(p.peek()))) {
+		p.pos++
+	}
+	if p.pos == start {
+		sb.WriteByte('$')
+		return nil
+	}
+	v, _ := p.lookup(p.src[start:p.pos])
+	sb.WriteString(v)
+	return nil
+}
+
+// matchingBrace returns the index of the '}' closing the '{' at the start of the given string, accounting for nested references; -1, if there is none on the same line.
+func matchingBrace(s string) int {
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '{':
+			depth++
+		case '}':
+			if depth--; depth == 0 {
+				return i
+			}
+		case '\n', '\r':
+			return -1
+		}
+	}
+	return -1
+}
+
+func (p *dotenvParser) eof() bool {
+	return p.pos >= len(p.src)
+}
+
+func (p *dotenvParser) peek() byte {
+	if p.eof() {
+		return 0
+	}
+	return p.src[p.pos]
+}
+
+func (p *dotenvParser) next() byte {
+	c := p.src[p.pos]
+	p.pos++
+	if c == '\n' {
+		p.line++
+	}
+	return c
+}
+
+// skipBlank skips whitespace, including line breaks.
+func (p *dotenvParser) skipBlank() {
+	for !p.eof() && (isSpace(p.peek()) || p.peek() == '\n' || p.peek() == '\r') {
+		p.next()
+	}
+}
+
+// skipSpaces skips whitespace on the current line.
+func (p *dotenvParser) skipSpaces() {
+	for !p.eof() && isSpace(p.peek()) {
+		p.pos++
+	}
+}
+
+// skipLine skips to the start of the next line.
+func (p *dotenvParser) skipLine() {
+	for !p.eof() {
+		if p.next() == '\n' {
+			return
+		}
+	}
+}
+
+func (p *dotenvParser) errorf(format string, args ...any) error {
+	return &ParseError{Path: p.path, Line: p.line, Msg: fmt.Sprintf(format, args...)}
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t'
+}
+
+func isAlpha(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
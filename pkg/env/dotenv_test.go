@@ -0,0 +1,168 @@
+package env
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	revert := SetAllMap(map[string]string{"DOTENV_OS": "os"})
+	defer revert()
+	Unset("DOTENV_UNSET")
+
+	input := `# A comment.
+PLAIN=hello world   
+export EXPORTED=1
+  SPACED = value  # inline comment
+HASH=a#b
+EMPTY=
+SINGLE='literal $PLAIN \n # not a comment'
+DOUBLE="line1\nline2\t\"quoted\" \$PLAIN"
+MULTI="first
+second"
+REF=${PLAIN}!
+BARE=$PLAIN-$DOTENV_OS
+DEFAULT=${DOTENV_UNSET:-fallback}
+DEFAULT_EMPTY=${EMPTY:-${DOTENV_OS}}
+DEFAULT_SET=${PLAIN:-fallback}
+UNDEFINED=[${DOTENV_UNSET}]
+DOLLAR=costs $5
+CRLF=windows` + "\r\n" + `LAST=end`
+
+	got, err := Parse(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string]string{
+		"PLAIN":         "hello world",
+		"EXPORTED":      "1",
+		"SPACED":        "value",
+		"HASH":          "a#b",
+		"EMPTY":         "",
+		"SINGLE":        `literal $PLAIN \n # not a comment`,
+		"DOUBLE":        "line1\nline2\t\"quoted\" $PLAIN",
+		"MULTI":         "first\nsecond",
+		"REF":           "hello world!",
+		"BARE":          "hello world-os",
+		"DEFAULT":       "fallback",
+		"DEFAULT_EMPTY": "os",
+		"DEFAULT_SET":   "hello world",
+		"UNDEFINED":     "[]",
+		"DOLLAR":        "costs $5",
+		"CRLF":          "windows",
+		"LAST":          "end",
+	}
+	if !reflect.DeepEqual(got, want) {
+		for k, v := range want {
+			if got[k] != v {
+				t.Errorf("%s: got %q, want %q", k, got[k], v)
+			}
+		}
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		line  int
+	}{
+		{name: "missing equals", input: "A=1\nB", line: 2},
+		{name: "missing name", input: "=1", line: 1},
+		{name: "unterminated single", input: "A='abc\n", line: 1},
+		{name: "unterminated double", input: "\nA=\"abc", line: 2},
+		{name: "unterminated reference", input: `A="${B"`, line: 1},
+		{name: "trailing garbage", input: "A='x' y", line: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(strings.NewReader(tt.input))
+			var pe *ParseError
+			if !errors.As(err, &pe) {
+				t.Fatalf("got %v, want *ParseError", err)
+			}
+			if pe.Line != tt.line {
+				t.Errorf("got line %d, want %d", pe.Line, tt.line)
+			}
+		})
+	}
+}
+
+func TestLoadFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, ".env")
+	writeFile(t, path, "DOTENV_A=1\nDOTENV_B=${DOTENV_A}2\n")
+
+	_ = os.Setenv("DOTENV_A", "orig")
+	defer Unset("DOTENV_A")
+
+	revert, err := LoadFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := os.Getenv("DOTENV_A"); got != "1" {
+		t.Errorf("got %q, want %q", got, "1")
+	}
+	if got := os.Getenv("DOTENV_B"); got != "12" {
+		t.Errorf("got %q, want %q", got, "12")
+	}
+
+	revert()
+	if got := os.Getenv("DOTENV_A"); got != "orig" {
+		t.Errorf("got %q, want %q", got, "orig")
+	}
+	if _, ok := os.LookupEnv("DOTENV_B"); ok {
+		t.Errorf("expected DOTENV_B to be unset")
+	}
+
+	if _, err = LoadFile(filepath.Join(dir, "missing")); err == nil {
+		t.Errorf("expected error for missing file")
+	}
+
+	bad := filepath.Join(dir, "bad.env")
+	writeFile(t, bad, "DOTENV_C=1\nnope\n")
+	_, err = LoadFile(bad)
+	if err == nil || !strings.Contains(err.Error(), "bad.env:2:") {
+		t.Errorf("got %v, want error at bad.env:2", err)
+	}
+	if _, ok := os.LookupEnv("DOTENV_C"); ok {
+		t.Errorf("expected DOTENV_C to be unset after parse error")
+	}
+}
+
+func TestLoadOverlay(t *testing.T) {
+	dir := t.TempDir()
+	base := filepath.Join(dir, ".env")
+	local := filepath.Join(dir, ".env.local")
+	writeFile(t, base, "DOTENV_HOST=localhost\nDOTENV_PORT=80\n")
+	writeFile(t, local, "DOTENV_PORT=8080\nDOTENV_URL=http://${DOTENV_HOST}:${DOTENV_PORT}\n")
+
+	revert, err := LoadOverlay(base, filepath.Join(dir, ".env.missing"), local)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer revert()
+
+	want := map[string]string{
+		"DOTENV_HOST": "localhost",
+		"DOTENV_PORT": "8080",
+		"DOTENV_URL":  "http://localhost:8080",
+	}
+	for k, v := range want {
+		if got := os.Getenv(k); got != v {
+			t.Errorf("%s: got %q, want %q", k, got, v)
+		}
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
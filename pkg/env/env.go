@@ -5,9 +5,7 @@ import (
 	"github.com/jpfourny/papaya/v2/pkg/opt"
 	"github.com/jpfourny/papaya/v2/pkg/pair"
 	"github.com/jpfourny/papaya/v2/pkg/stream"
-	"github.com/jpfourny/papaya/v2/pkg/stream/mapper"
 	"os"
-	"strings"
 	"time"
 )
 
@@ -58,84 +56,57 @@ func setAll(vars stream.Stream[pair.Pair[string, string]]) (revert func()) {
 
 // Get returns the value of the environment variable with the given key, if it exists.
 func Get(key string) opt.Optional[string] {
-	return opt.Maybe(os.LookupEnv(key))
+	return OS().Get(key)
 }
 
 // GetBool returns the value of the environment variable with the given key, if it exists and can be parsed as a boolean.
 // An empty Optional is returned if the variable is unset or if value cannot be parsed as a boolean.
 func GetBool(key string) opt.Optional[bool] {
-	return opt.OptionalMap(
-		Get(key),
-		mapper.TryParseBool[string](),
-	)
+	return getBool(OS(), key)
 }
 
 // GetInt returns the value of the environment variable with the given key, if it exists and can be parsed as an integer of the desired type I.
 // An empty Optional is returned if the variable is unset or if value cannot be parsed as an integer.
 func GetInt[I constraint.SignedInteger](key string) opt.Optional[I] {
-	return opt.OptionalMap(
-		Get(key),
-		mapper.TryParseInt[string, I](10, 64),
-	)
+	return getInt[I](OS(), key)
 }
 
 // GetUInt returns the value of the environment variable with the given key, if it exists and can be parsed as an unsigned integer of the desired type I.
 // An empty Optional is returned if the variable is unset or if value cannot be parsed as an unsigned integer.
 func GetUInt[I constraint.UnsignedInteger](key string) opt.Optional[I] {
-	return opt.OptionalMap(
-		Get(key),
-		mapper.TryParseUint[string, I](10, 64),
-	)
+	return getUInt[I](OS(), key)
 }
 
 // GetFloat returns the value of the environment variable with the given key, if it exists and can be parsed as a float of the desired type F.
 // An empty Optional is returned if the variable is unset or if value cannot be parsed as a float.
 func GetFloat[F constraint.Float](key string) opt.Optional[F] {
-	return opt.OptionalMap(
-		Get(key),
-		mapper.TryParseFloat[string, F](64),
-	)
+	return getFloat[F](OS(), key)
 }
 
 // GetDuration returns the value of the environment variable with the given key, if it exists and can be parsed as a duration.
 // An empty Optional is returned if the variable is unset or if value cannot be parsed as a duration.
 // See time.ParseDuration for details on the expected format.
 func GetDuration(key string) opt.Optional[time.Duration] {
-	return opt.OptionalMap(
-		Get(key),
-		mapper.TryParseDuration[string](),
-	)
+	return getDuration(OS(), key)
 }
 
 // GetTime returns the value of the environment variable with the given key, if it exists and can be parsed as a time.Time with the given layout.
 // An empty Optional is returned if the variable is unset or if value cannot be parsed as a time.Time.
 // See time.Parse for details on the expected format.
 func GetTime(key string, layout string) opt.Optional[time.Time] {
-	return opt.OptionalMap(
-		Get(key),
-		mapper.TryParseTime[string](layout),
-	)
+	return getTime(OS(), key, layout)
 }
 
 // GetTimeInLocation returns the value of the environment variable with the given key, if it exists and can be parsed as a time.Time with the given layout and location.
 // An empty Optional is returned if the variable is unset or if value cannot be parsed as a time.Time.
 // See time.ParseInLocation for details on the expected format.
 func GetTimeInLocation(key string, layout string, loc *time.Location) opt.Optional[time.Time] {
-	return opt.OptionalMap(
-		Get(key),
-		mapper.TryParseTimeInLocation[string](layout, loc),
-	)
+	return getTimeInLocation(OS(), key, layout, loc)
 }
 
 // ToStream returns a stream of pairs representing the environment variables.
 func ToStream() stream.Stream[pair.Pair[string, string]] {
-	return stream.Map(
-		stream.FromSlice(os.Environ()),
-		func(s string) pair.Pair[string, string] {
-			parts := strings.SplitN(s, "=", 2)
-			return pair.Of(parts[0], parts[1])
-		},
-	)
+	return OS().Stream()
 }
 
 // ToMap returns a map representing the environment variables.
@@ -0,0 +1,53 @@
+package env
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrMissing is reported for a required variable that is not set and has no default.
+var ErrMissing = errors.New("required variable is not set")
+
+// VarError describes a problem with reading a single environment variable.
+type VarError struct {
+	Key   string // The environment variable key.
+	Value string // The raw value of the variable; empty, if it is not set.
+	Field string // The path of the struct field (eg: "DB.Port"), if bound with Bind; empty otherwise.
+	Err   error  // The underlying error; ErrMissing, if the variable was required but not set.
+}
+
+func (e *VarError) Error() string {
+	s := e.Key
+	if !errors.Is(e.Err, ErrMissing) {
+		s += fmt.Sprintf("=%q", e.Value)
+	}
+	if e.Field != "" {
+		s += fmt.Sprintf(" (field %s)", e.Field)
+	}
+	return fmt.Sprintf("%s: %v", s, e.Err)
+}
+
+func (e *VarError) Unwrap() error {
+	return e.Err
+}
+
+// joinVarErrors returns the given heading followed by the given errors, one per line.
+func joinVarErrors(heading string, errs []*VarError) string {
+	var sb strings.Builder
+	sb.WriteString(heading)
+	sb.WriteString(":")
+	for _, ve := range errs {
+		sb.WriteString("\n\t")
+		sb.WriteString(ve.Error())
+	}
+	return sb.String()
+}
+
+func unwrapVarErrors(errs []*VarError) []error {
+	out := make([]error, len(errs))
+	for i, ve := range errs {
+		out[i] = ve
+	}
+	return out
+}
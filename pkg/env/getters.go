@@ -0,0 +1,279 @@
+package env
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+	"net/netip"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/jpfourny/papaya/v2/pkg/opt"
+)
+
+// GetSlice returns the value of the environment variable with the given key, if it exists, split by the given separator, with each element parsed by the given parser.
+// Surrounding whitespace is removed from each element before parsing.
+// An empty value yields an empty slice.
+// An empty Optional is returned if the variable is unset or if any element cannot be parsed.
+// The parser may be any of the mapper.TryParse* functions.
+//
+// Example usage:
+//
+//	env.Set("PORTS", "80, 443")
+//	out := env.GetSlice("PORTS", ",", mapper.TryParseInt[string, int](10, 64)) // Some([80 443])
+func GetSlice[T any](key string, sep string, parser func(string) opt.Optional[T]) opt.Optional[[]T] {
+	return GetSliceFrom(OS(), key, sep, parser)
+}
+
+// GetSliceFrom behaves like GetSlice, but reads the variable from the given Source.
+//
+// Example usage:
+//
+//	src := env.FromMap(map[string]string{"PORTS": "80, 443"})
+//	out := env.GetSliceFrom(src, "PORTS", ",", mapper.TryParseInt[string, int](10, 64)) // Some([80 443])
+func GetSliceFrom[T any](src Source, key string, sep string, parser func(string) opt.Optional[T]) opt.Optional[[]T] {
+	return getParsed(src, key, sliceParser(sep, fromTry(parser)))
+}
+
+// GetMap returns the value of the environment variable with the given key, if it exists, parsed as a map.
+// The value is split into entries by the given separator, and each entry is split into a key and value by the given key-value separator.
+// Surrounding whitespace is removed from each key and value before parsing with the given parsers.
+// An empty value yields an empty map.
+// An empty Optional is returned if the variable is unset or if any entry cannot be parsed.
+//
+// Example usage:
+//
+//	env.Set("LIMITS", "read=10,write=5")
+//	out := env.GetMap("LIMITS", ",", "=", opt.Of[string], mapper.TryParseInt[string, int](10, 64)) // Some(map[read:10 write:5])
+func GetMap[K comparable, V any](key string, sep, kvSep string, keyParser func(string) opt.Optional[K], valueParser func(string) opt.Optional[V]) opt.Optional[map[K]V] {
+	return GetMapFrom(OS(), key, sep, kvSep, keyParser, valueParser)
+}
+
+// GetMapFrom behaves like GetMap, but reads the variable from the given Source.
+func GetMapFrom[K comparable, V any](src Source, key string, sep, kvSep string, keyParser func(string) opt.Optional[K], valueParser func(string) opt.Optional[V]) opt.Optional[map[K]V] {
+	return getParsed(src, key, mapParser(sep, kvSep, fromTry(keyParser), fromTry(valueParser)))
+}
+
+// GetURL returns the value of the environment variable with the given key, if it exists and can be parsed as an absolute URL (one with a scheme).
+func GetURL(key string) opt.Optional[*url.URL] {
+	return OS().GetURL(key)
+}
+
+// GetIP returns the value of the environment variable with the given key, if it exists and can be parsed as an IPv4 or IPv6 address.
+func GetIP(key string) opt.Optional[netip.Addr] {
+	return OS().GetIP(key)
+}
+
+// GetCIDR returns the value of the environment variable with the given key, if it exists and can be parsed as an IP network in CIDR notation (eg: "10.0.0.0/8").
+func GetCIDR(key string) opt.Optional[netip.Prefix] {
+	return OS().GetCIDR(key)
+}
+
+// GetByteSize returns the value of the environment variable with the given key, if it exists and can be parsed as a number of bytes.
+// The value is a non-negative number followed by an optional unit, which is either decimal (B, KB, MB, GB, TB, PB) or binary (KiB, MiB, GiB, TiB, PiB).
+// Units are case-insensitive, and a fractional result is rounded down.
+//
+// Example usage:
+//
+//	env.Set("MAX_BODY", "10MiB")
+//	out := env.GetByteSize("MAX_BODY") // Some(10485760)
+func GetByteSize(key string) opt.Optional[uint64] {
+	return OS().GetByteSize(key)
+}
+
+// GetEnum returns the value of the environment variable with the given key, if it exists and is one of the allowed values.
+// Values are compared case-sensitively.
+//
+// Example usage:
+//
+//	env.Set("LOG_LEVEL", "debug")
+//	out := env.GetEnum("LOG_LEVEL", "debug", "info", "warn") // Some("debug")
+func GetEnum[S ~string](key string, allowed ...S) opt.Optional[S] {
+	return GetEnumFrom(OS(), key, allowed...)
+}
+
+// GetEnumFrom behaves like GetEnum, but reads the variable from the given Source.
+func GetEnumFrom[S ~string](src Source, key string, allowed ...S) opt.Optional[S] {
+	return getParsed(src, key, enumParser(allowed))
+}
+
+// GetRegexp returns the value of the environment variable with the given key, if it exists and can be compiled as a regular expression.
+func GetRegexp(key string) opt.Optional[*regexp.Regexp] {
+	return OS().GetRegexp(key)
+}
+
+// GetURL returns the value of the variable with the given key, if it exists and can be parsed as an absolute URL (one with a scheme).
+func (e Env) GetURL(key string) opt.Optional[*url.URL] {
+	return getParsed(e, key, parseURL)
+}
+
+// GetIP returns the value of the variable with the given key, if it exists and can be parsed as an IPv4 or IPv6 address.
+func (e Env) GetIP(key string) opt.Optional[netip.Addr] {
+	return getParsed(e, key, netip.ParseAddr)
+}
+
+// GetCIDR returns the value of the variable with the given key, if it exists and can be parsed as an IP network in CIDR notation.
+func (e Env) GetCIDR(key string) opt.Optional[netip.Prefix] {
+	return getParsed(e, key, netip.ParsePrefix)
+}
+
+// GetByteSize returns the value of the variable with the given key, if it exists and can be parsed as a number of bytes.
+// See the package-level GetByteSize for the format.
+func (e Env) GetByteSize(key string) opt.Optional[uint64] {
+	return getParsed(e, key, parseByteSize)
+}
+
+// GetRegexp returns the value of the variable with the given key, if it exists and can be compiled as a regular expression.
+func (e Env) GetRegexp(key string) opt.Optional[*regexp.Regexp] {
+	return getParsed(e, key, regexp.Compile)
+}
+
+// parseFunc parses a raw value, returning an error describing why it is invalid.
+type parseFunc[T any] func(s string) (T, error)
+
+// fromTry adapts a parser returning an Optional (eg: mapper.TryParseInt) to a parseFunc.
+func fromTry[T any](try func(string) opt.Optional[T]) parseFunc[T] {
+	return func(s string) (T, error) {
+		v, ok := try(s).Get()
+		if !ok {
+			return v, fmt.Errorf("cannot parse %q as %T", s, v)
+		}
+		return v, nil
+	}
+}
+
+// getParsed returns the parsed value of the variable with the given key; an empty Optional, if it is unset or invalid.
+func getParsed[T any](src Source, key string, parse parseFunc[T]) opt.Optional[T] {
+	s, ok := src.Lookup(key)
+	if !ok {
+		return opt.Empty[T]()
+	}
+	v, err := parse(s)
+	return opt.Maybe(v, err == nil)
+}
+
+func sliceParser[T any](sep string, elem parseFunc[T]) parseFunc[[]T] {
+	return func(s string) ([]T, error) {
+		parts := splitList(s, sep)
+		out := make([]T, 0, len(parts))
+		for i, part := range parts {
+			v, err := elem(part)
+			if err != nil {
+				return nil, fmt.Errorf("element %d: %w", i, err)
+			}
+			out = append(out, v)
+		}
+		return out, nil
+	}
+}
+
+func mapParser[K comparable, V any](sep, kvSep string, keyParse parseFunc[K], valueParse parseFunc[V]) parseFunc[map[K]V] {
+	return func(s string) (map[K]V, error) {
+		parts := splitList(s, sep)
+		out := make(map[K]V, len(parts))
+		for _, part := range parts {
+			ks, vs, found := strings.Cut(part, kvSep)
+			if !found {
+				return nil, fmt.Errorf("entry %q: missing %q", part, kvSep)
+			}
+			k, err := keyParse(strings.TrimSpace(ks))
+			if err != nil {
+				return nil, fmt.Errorf("entry %q: %w", part, err)
+			}
+			v, err := valueParse(strings.TrimSpace(vs))
+			if err != nil {
+				return nil, fmt.Errorf("entry %q: %w", part, err)
+			}
+			out[k] = v
+		}
+		return out, nil
+	}
+}
+
+func enumParser[S ~string](allowed []S) parseFunc[S] {
+	return func(s string) (S, error) {
+		if !slices.Contains(allowed, S(s)) {
+			return "", fmt.Errorf("must be one of %q", allowed)
+		}
+		return S(s), nil
+	}
+}
+
+// splitList returns the elements of the given list, with surrounding whitespace removed.
+// An empty (or blank) list has no elements.
+func splitList(s, sep string) []string {
+	if strings.TrimSpace(s) == "" {
+		return nil
+	}
+	parts := strings.Split(s, sep)
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+	}
+	return parts
+}
+
+func parseURL(s string) (*url.URL, error) {
+	u, err := url.Parse(s)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme == "" {
+		return nil, errors.New("URL must be absolute")
+	}
+	return u, nil
+}
+
+var byteUnits = map[string]uint64{
+	"":    1,
+	"b":   1,
+	"kb":  1e3,
+	"mb":  1e6,
+	"gb":  1e9,
+	"tb":  1e12,
+	"pb":  1e15,
+	"kib": 1 << 10,
+	"mib": 1 << 20,
+	"gib": 1 << 30,
+	"tib": 1 << 40,
+	"pib": 1 << 50,
+}
+
+func parseByteSize(s string) (uint64, error) {
+	s = strings.TrimSpace(s)
+	i := strings.IndexFunc(s, func(r rune) bool { return (r < '0' || r > '9') && r != '.' })
+	if i < 0 {
+		i = len(s)
+	}
+	num, unit := s[:i], strings.ToLower(strings.TrimSpace(s[i:]))
+	mult, ok := byteUnits[unit]
+	if !ok {
+		return 0, fmt.Errorf("unknown byte size unit %q", s[i:])
+	}
+
+	if !strings.Contains(num, ".") {
+		// Integral; compute exactly.
+		n, err := strconv.ParseUint(num, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid byte size %q", s)
+		}
+		hi, lo := bits.Mul64(n, mult)
+		if hi != 0 {
+			return 0, fmt.Errorf("byte size %q overflows uint64", s)
+		}
+		return lo, nil
+	}
+
+	f, err := strconv.ParseFloat(num, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid byte size %q", s)
+	}
+	f *= float64(mult)
+	if f >= math.MaxUint64 {
+		return 0, fmt.Errorf("byte size %q overflows uint64", s)
+	}
+	return uint64(f), nil
+}
@@ -0,0 +1,150 @@
+package env
+
+import (
+	"net/netip"
+	"reflect"
+	"testing"
+
+	"github.com/jpfourny/papaya/v2/pkg/opt"
+	"github.com/jpfourny/papaya/v2/pkg/stream/mapper"
+)
+
+func TestGetSlice(t *testing.T) {
+	revert := SetAllMap(map[string]string{
+		"SLICE":   "1, 2 ,3",
+		"EMPTY":   "",
+		"INVALID": "1,x",
+	})
+	defer revert()
+
+	parser := mapper.TryParseInt[string, int](10, 64)
+	if got := GetSlice("SLICE", ",", parser).GetOrZero(); !reflect.DeepEqual(got, []int{1, 2, 3}) {
+		t.Errorf("got %v, want %v", got, []int{1, 2, 3})
+	}
+	if got := GetSlice("EMPTY", ",", parser); !got.Present() || len(got.GetOrZero()) != 0 {
+		t.Errorf("got %v, want Some([])", got)
+	}
+	if got := GetSlice("INVALID", ",", parser); got.Present() {
+		t.Errorf("got %v, want None", got)
+	}
+	if got := GetSlice("MISSING", ",", parser); got.Present() {
+		t.Errorf("got %v, want None", got)
+	}
+}
+
+func TestGetMap(t *testing.T) {
+	revert := SetAllMap(map[string]string{
+		"MAP":     "read=10, write = 5",
+		"INVALID": "read",
+	})
+	defer revert()
+
+	vp := mapper.TryParseInt[string, int](10, 64)
+	want := map[string]int{"read": 10, "write": 5}
+	if got := GetMap("MAP", ",", "=", opt.Of[string], vp).GetOrZero(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if got := GetMap("INVALID", ",", "=", opt.Of[string], vp); got.Present() {
+		t.Errorf("got %v, want None", got)
+	}
+}
+
+func TestGetURL(t *testing.T) {
+	revert := SetAllMap(map[string]string{
+		"URL":      "https://example.com:8443/path?q=1",
+		"RELATIVE": "/path",
+		"INVALID":  "http://[::1",
+	})
+	defer revert()
+
+	if got := GetURL("URL"); !got.Present() || got.GetOrZero().Host != "example.com:8443" {
+		t.Errorf("got %v, want host %q", got, "example.com:8443")
+	}
+	for _, key := range []string{"RELATIVE", "INVALID", "MISSING"} {
+		if got := GetURL(key); got.Present() {
+			t.Errorf("%s: got %v, want None", key, got)
+		}
+	}
+}
+
+func TestGetIP(t *testing.T) {
+	revert := SetAllMap(map[string]string{
+		"IP4":     "10.0.0.1",
+		"IP6":     "::1",
+		"CIDR":    "10.0.0.0/8",
+		"INVALID": "10.0.0",
+	})
+	defer revert()
+
+	if got := GetIP("IP4"); got != opt.Of(netip.MustParseAddr("10.0.0.1")) {
+		t.Errorf("got %v, want %v", got, "10.0.0.1")
+	}
+	if got := GetIP("IP6"); got != opt.Of(netip.IPv6Loopback()) {
+		t.Errorf("got %v, want %v", got, "::1")
+	}
+	if got := GetIP("INVALID"); got.Present() {
+		t.Errorf("got %v, want None", got)
+	}
+	if got := GetCIDR("CIDR"); got != opt.Of(netip.MustParsePrefix("10.0.0.0/8")) {
+		t.Errorf("got %v, want %v", got, "10.0.0.0/8")
+	}
+	if got := GetCIDR("IP4"); got.Present() {
+		t.Errorf("got %v, want None", got)
+	}
+}
+
+func TestGetByteSize(t *testing.T) {
+	tests := []struct {
+		value string
+		want  opt.Optional[uint64]
+	}{
+		{"512", opt.Of[uint64](512)},
+		{"512B", opt.Of[uint64](512)},
+		{"10MiB", opt.Of[uint64](10 << 20)},
+		{"10 mib", opt.Of[uint64](10 << 20)},
+		{"10MB", opt.Of[uint64](10_000_000)},
+		{"1.5KiB", opt.Of[uint64](1536)},
+		{"0.5B", opt.Of[uint64](0)},
+		{"16384PiB", opt.Empty[uint64]()}, // Overflows.
+		{"10XB", opt.Empty[uint64]()},
+		{"-1", opt.Empty[uint64]()},
+		{"MiB", opt.Empty[uint64]()},
+		{"1.2.3", opt.Empty[uint64]()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got := FromMap(map[string]string{"SIZE": tt.value}).GetByteSize("SIZE")
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetEnum(t *testing.T) {
+	type level string
+	revert := SetAllMap(map[string]string{"LEVEL": "debug"})
+	defer revert()
+
+	if got := GetEnum[level]("LEVEL", "debug", "info"); got != opt.Of[level]("debug") {
+		t.Errorf("got %v, want %v", got, opt.Of[level]("debug"))
+	}
+	if got := GetEnum("LEVEL", "info", "DEBUG"); got.Present() {
+		t.Errorf("got %v, want None", got)
+	}
+}
+
+func TestGetRegexp(t *testing.T) {
+	revert := SetAllMap(map[string]string{
+		"RE":      "^a+$",
+		"INVALID": "a(",
+	})
+	defer revert()
+
+	if got := GetRegexp("RE"); !got.Present() || !got.GetOrZero().MatchString("aaa") {
+		t.Errorf("got %v, want regexp matching %q", got, "aaa")
+	}
+	if got := GetRegexp("INVALID"); got.Present() {
+		t.Errorf("got %v, want None", got)
+	}
+}
@@ -0,0 +1,224 @@
+package env
+
+import (
+	"net/netip"
+	"net/url"
+	"regexp"
+	"time"
+
+	"github.com/jpfourny/papaya/v2/pkg/constraint"
+	"github.com/jpfourny/papaya/v2/pkg/opt"
+	"github.com/jpfourny/papaya/v2/pkg/res"
+	"github.com/jpfourny/papaya/v2/pkg/stream/mapper"
+)
+
+// Require returns the value of the environment variable with the given key.
+// A failed Result is returned if the variable is unset.
+//
+// Each Require* function is the counterpart of a Get* function, for variables that must be set and valid.
+// Rather than an empty Optional, a failed Result is returned with a *VarError naming the key and raw value.
+// The error wraps ErrMissing if the variable is unset.
+//
+// Example usage:
+//
+//	r := env.RequireInt[int]("PORT")
+//	out := r.String() // "Failure(PORT: required variable is not set)", if PORT is unset.
+func Require(key string) res.Result[string] {
+	return OS().Require(key)
+}
+
+// RequireBool returns the value of the environment variable with the given key, parsed as a boolean.
+// A failed Result is returned if the variable is unset or cannot be parsed.
+func RequireBool(key string) res.Result[bool] {
+	return OS().RequireBool(key)
+}
+
+// RequireInt returns the value of the environment variable with the given key, parsed as an integer of the desired type I.
+// A failed Result is returned if the variable is unset or cannot be parsed.
+func RequireInt[I constraint.SignedInteger](key string) res.Result[I] {
+	return requireParsed(OS(), key, fromTry(mapper.TryParseInt[string, I](10, 64)))
+}
+
+// RequireUInt returns the value of the environment variable with the given key, parsed as an unsigned integer of the desired type I.
+// A failed Result is returned if the variable is unset or cannot be parsed.
+func RequireUInt[I constraint.UnsignedInteger](key string) res.Result[I] {
+	return requireParsed(OS(), key, fromTry(mapper.TryParseUint[string, I](10, 64)))
+}
+
+// RequireFloat returns the value of the environment variable with the given key, parsed as a float of the desired type F.
+// A failed Result is returned if the variable is unset or cannot be parsed.
+func RequireFloat[F constraint.Float](key string) res.Result[F] {
+	return requireParsed(OS(), key, fromTry(mapper.TryParseFloat[string, F](64)))
+}
+
+// RequireDuration returns the value of the environment variable with the given key, parsed as a duration.
+// A failed Result is returned if the variable is unset or cannot be parsed.
+func RequireDuration(key string) res.Result[time.Duration] {
+	return OS().RequireDuration(key)
+}
+
+// RequireTime returns the value of the environment variable with the given key, parsed as a time.Time with the given layout.
+// A failed Result is returned if the variable is unset or cannot be parsed.
+func RequireTime(key string, layout string) res.Result[time.Time] {
+	return OS().RequireTime(key, layout)
+}
+
+// RequireSlice returns the value of the environment variable with the given key, parsed as a slice; see GetSlice.
+// A failed Result is returned if the variable is unset or any element cannot be parsed.
+func RequireSlice[T any](key string, sep string, parser func(string) opt.Optional[T]) res.Result[[]T] {
+	return RequireSliceFrom(OS(), key, sep, parser)
+}
+
+// RequireSliceFrom behaves like RequireSlice, but reads the variable from the given Source.
+func RequireSliceFrom[T any](src Source, key string, sep string, parser func(string) opt.Optional[T]) res.Result[[]T] {
+	return requireParsed(src, key, sliceParser(sep, fromTry(parser)))
+}
+
+// RequireMap returns the value of the environment variable with the given key, parsed as a map; see GetMap.
+// A failed Result is returned if the variable is unset or any entry cannot be parsed.
+func RequireMap[K comparable, V any](key string, sep, kvSep string, keyParser func(string) opt.Optional[K], valueParser func(string) opt.Optional[V]) res.Result[map[K]V] {
+	return RequireMapFrom(OS(), key, sep, kvSep, keyParser, valueParser)
+}
+
+// RequireMapFrom behaves like RequireMap, but reads the variable from the given Source.
+func RequireMapFrom[K comparable, V any](src Source, key string, sep, kvSep string, keyParser func(string) opt.Optional[K], valueParser func(string) opt.Optional[V]) res.Result[map[K]V] {
+	return requireParsed(src, key, mapParser(sep, kvSep, fromTry(keyParser), fromTry(valueParser)))
+}
+
+// RequireURL returns the value of the environment variable with the given key, parsed as an absolute URL.
+// A failed Result is returned if the variable is unset or cannot be parsed.
+func RequireURL(key string) res.Result[*url.URL] {
+	return OS().RequireURL(key)
+}
+
+// RequireIP returns the value of the environment variable with the given key, parsed as an IPv4 or IPv6 address.
+// A failed Result is returned if the variable is unset or cannot be parsed.
+func RequireIP(key string) res.Result[netip.Addr] {
+	return OS().RequireIP(key)
+}
+
+// RequireCIDR returns the value of the environment variable with the given key, parsed as an IP network in CIDR notation.
+// A failed Result is returned if the variable is unset or cannot be parsed.
+func RequireCIDR(key string) res.Result[netip.Prefix] {
+	return OS().RequireCIDR(key)
+}
+
+// RequireByteSize returns the value of the environment variable with the given key, parsed as a number of bytes; see GetByteSize.
+// A failed Result is returned if the variable is unset or cannot be parsed.
+func RequireByteSize(key string) res.Result[uint64] {
+	return OS().RequireByteSize(key)
+}
+
+// RequireEnum returns the value of the environment variable with the given key, if it is one of the allowed values.
+// A failed Result is returned if the variable is unset or is not allowed.
+func RequireEnum[S ~string](key string, allowed ...S) res.Result[S] {
+	return RequireEnumFrom(OS(), key, allowed...)
+}
+
+// RequireEnumFrom behaves like RequireEnum, but reads the variable from the given Source.
+func RequireEnumFrom[S ~string](src Source, key string, allowed ...S) res.Result[S] {
+	return requireParsed(src, key, enumParser(allowed))
+}
+
+// RequireRegexp returns the value of the environment variable with the given key, compiled as a regular expression.
+// A failed Result is returned if the variable is unset or cannot be compiled.
+func RequireRegexp(key string) res.Result[*regexp.Regexp] {
+	return OS().RequireRegexp(key)
+}
+
+// Require returns the value of the variable with the given key.
+// A failed Result is returned if the variable is unset.
+func (e Env) Require(key string) res.Result[string] {
+	return requireParsed(e, key, func(s string) (string, error) { return s, nil })
+}
+
+// RequireBool returns the value of the variable with the given key, parsed as a boolean.
+// A failed Result is returned if the variable is unset or cannot be parsed.
+func (e Env) RequireBool(key string) res.Result[bool] {
+	return requireParsed(e, key, fromTry(mapper.TryParseBool[string]()))
+}
+
+// RequireInt returns the value of the variable with the given key, parsed as an int.
+// A failed Result is returned if the variable is unset or cannot be parsed.
+func (e Env) RequireInt(key string) res.Result[int] {
+	return requireParsed(e, key, fromTry(mapper.TryParseInt[string, int](10, 64)))
+}
+
+// RequireInt64 returns the value of the variable with the given key, parsed as an int64.
+// A failed Result is returned if the variable is unset or cannot be parsed.
+func (e Env) RequireInt64(key string) res.Result[int64] {
+	return requireParsed(e, key, fromTry(mapper.TryParseInt[string, int64](10, 64)))
+}
+
+// RequireUInt returns the value of the variable with the given key, parsed as a uint.
+// A failed Result is returned if the variable is unset or cannot be parsed.
+func (e Env) RequireUInt(key string) res.Result[uint] {
+	return requireParsed(e, key, fromTry(mapper.TryParseUint[string, uint](10, 64)))
+}
+
+// RequireUInt64 returns the value of the variable with the given key, parsed as a uint64.
+// A failed Result is returned if the variable is unset or cannot be parsed.
+func (e Env) RequireUInt64(key string) res.Result[uint64] {
+	return requireParsed(e, key, fromTry(mapper.TryParseUint[string, uint64](10, 64)))
+}
+
+// RequireFloat returns the value of the variable with the given key, parsed as a float64.
+// A failed Result is returned if the variable is unset or cannot be parsed.
+func (e Env) RequireFloat(key string) res.Result[float64] {
+	return requireParsed(e, key, fromTry(mapper.TryParseFloat[string, float64](64)))
+}
+
+// RequireDuration returns the value of the variable with the given key, parsed as a duration.
+// A failed Result is returned if the variable is unset or cannot be parsed.
+func (e Env) RequireDuration(key string) res.Result[time.Duration] {
+	return requireParsed(e, key, time.ParseDuration)
+}
+
+// RequireTime returns the value of the variable with the given key, parsed as a time.Time with the given layout.
+// A failed Result is returned if the variable is unset or cannot be parsed.
+func (e Env) RequireTime(key string, layout string) res.Result[time.Time] {
+	return requireParsed(e, key, func(s string) (time.Time, error) { return time.Parse(layout, s) })
+}
+
+// RequireURL returns the value of the variable with the given key, parsed as an absolute URL.
+// A failed Result is returned if the variable is unset or cannot be parsed.
+func (e Env) RequireURL(key string) res.Result[*url.URL] {
+	return requireParsed(e, key, parseURL)
+}
+
+// RequireIP returns the value of the variable with the given key, parsed as an IPv4 or IPv6 address.
+// A failed Result is returned if the variable is unset or cannot be parsed.
+func (e Env) RequireIP(key string) res.Result[netip.Addr] {
+	return requireParsed(e, key, netip.ParseAddr)
+}
+
+// RequireCIDR returns the value of the variable with the given key, parsed as an IP network in CIDR notation.
+// A failed Result is returned if the variable is unset or cannot be parsed.
+func (e Env) RequireCIDR(key string) res.Result[netip.Prefix] {
+	return requireParsed(e, key, netip.ParsePrefix)
+}
+
+// RequireByteSize returns the value of the variable with the given key, parsed as a number of bytes.
+// A failed Result is returned if the variable is unset or cannot be parsed.
+func (e Env) RequireByteSize(key string) res.Result[uint64] {
+	return requireParsed(e, key, parseByteSize)
+}
+
+// RequireRegexp returns the value of the variable with the given key, compiled as a regular expression.
+// A failed Result is returned if the variable is unset or cannot be compiled.
+func (e Env) RequireRegexp(key string) res.Result[*regexp.Regexp] {
+	return requireParsed(e, key, regexp.Compile)
+}
+
+// requireParsed returns the parsed value of the variable with the given key; a failed Result with a *VarError, if it is unset or invalid.
+func requireParsed[T any](src Source, key string, parse parseFunc[T]) res.Result[T] {
+	s, ok := src.Lookup(key)
+	if !ok {
+		return res.Fail[T](&VarError{Key: key, Err: ErrMissing})
+	}
+	v, err := parse(s)
+	if err != nil {
+		return res.Fail[T](&VarError{Key: key, Value: s, Err: err})
+	}
+	return res.OK(v)
+}
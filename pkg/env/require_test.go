@@ -0,0 +1,101 @@
+package env
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jpfourny/papaya/v2/pkg/stream/mapper"
+)
+
+func TestRequire(t *testing.T) {
+	revert := SetAllMap(map[string]string{
+		"STR":      "foo",
+		"INT":      "42",
+		"DURATION": "1s",
+		"PORTS":    "80,x",
+		"LEVEL":    "trace",
+	})
+	defer revert()
+	Unset("MISSING")
+
+	if got := Require("STR"); got.Value().GetOrZero() != "foo" || !got.Succeeded() {
+		t.Errorf("got %v, want Success(foo)", got)
+	}
+	if got := RequireInt[int]("INT"); got.Value().GetOrZero() != 42 {
+		t.Errorf("got %v, want Success(42)", got)
+	}
+	if got := RequireDuration("DURATION"); got.Value().GetOrZero() != time.Second {
+		t.Errorf("got %v, want Success(1s)", got)
+	}
+
+	tests := []struct {
+		name    string
+		err     error
+		missing bool
+		msg     string
+	}{
+		{"missing", Require("MISSING").Error().GetOrZero(), true, "MISSING: required variable is not set"},
+		{"int", RequireInt[int]("STR").Error().GetOrZero(), false, `STR="foo": cannot parse "foo" as int`},
+		{"slice", RequireSlice("PORTS", ",", mapper.TryParseInt[string, int](10, 64)).Error().GetOrZero(), false, `PORTS="80,x": element 1: cannot parse "x" as int`},
+		{"enum", RequireEnum("LEVEL", "debug", "info").Error().GetOrZero(), false, `LEVEL="trace": must be one of ["debug" "info"]`},
+		{"url", RequireURL("STR").Error().GetOrZero(), false, `STR="foo": URL must be absolute`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ve *VarError
+			if !errors.As(tt.err, &ve) {
+				t.Fatalf("got %v, want *VarError", tt.err)
+			}
+			if errors.Is(tt.err, ErrMissing) != tt.missing {
+				t.Errorf("got errors.Is(ErrMissing) %t, want %t", !tt.missing, tt.missing)
+			}
+			if tt.err.Error() != tt.msg {
+				t.Errorf("got %q, want %q", tt.err.Error(), tt.msg)
+			}
+		})
+	}
+}
+
+func TestEnv_Require(t *testing.T) {
+	t.Parallel()
+
+	e := FromMap(map[string]string{
+		"BOOL":  "true",
+		"SIZE":  "1KiB",
+		"IP":    "::1",
+		"CIDR":  "::/0",
+		"RE":    "a+",
+		"TIME":  "2024-01-02",
+		"FLOAT": "1.5",
+	})
+	if got := e.RequireBool("BOOL"); !got.Succeeded() || !got.Value().GetOrZero() {
+		t.Errorf("got %v, want Success(true)", got)
+	}
+	if got := e.RequireByteSize("SIZE"); got.Value().GetOrZero() != 1024 {
+		t.Errorf("got %v, want Success(1024)", got)
+	}
+	if got := e.RequireIP("IP"); !got.Succeeded() {
+		t.Errorf("got %v, want Success", got)
+	}
+	if got := e.RequireCIDR("CIDR"); !got.Succeeded() {
+		t.Errorf("got %v, want Success", got)
+	}
+	if got := e.RequireRegexp("RE"); !got.Succeeded() {
+		t.Errorf("got %v, want Success", got)
+	}
+	if got := e.RequireTime("TIME", time.DateOnly); !got.Succeeded() {
+		t.Errorf("got %v, want Success", got)
+	}
+	if got := e.RequireFloat("FLOAT"); got.Value().GetOrZero() != 1.5 {
+		t.Errorf("got %v, want Success(1.5)", got)
+	}
+	for _, r := range []interface{ Failed() bool }{
+		e.RequireInt("MISSING"), e.RequireInt64("BOOL"), e.RequireUInt("BOOL"), e.RequireUInt64("BOOL"), e.RequireDuration("BOOL"), e.RequireURL("MISSING"),
+	} {
+		if !r.Failed() {
+			t.Errorf("got %v, want Failure", r)
+		}
+	}
+
+}
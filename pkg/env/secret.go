@@ -0,0 +1,194 @@
+package env
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/jpfourny/papaya/v2/pkg/opt"
+	"github.com/jpfourny/papaya/v2/pkg/pair"
+	"github.com/jpfourny/papaya/v2/pkg/res"
+	"github.com/jpfourny/papaya/v2/pkg/stream"
+)
+
+// Redacted is the placeholder shown in place of secret values.
+const Redacted = "[REDACTED]"
+
+// FileSuffix is appended to the key of a secret to name a variable holding the path of a file containing the secret, as with Docker secrets.
+const FileSuffix = "_FILE"
+
+// Secret wraps a value of type T that must not be revealed in logs or serialized output.
+// Formatting with the fmt package (with any verb), and marshaling to JSON or text, yield Redacted rather than the value.
+// Use Reveal to access the value.
+//
+// A Secret field may be populated by Bind, in which case the value is parsed as for a field of type T, and may be read from a file named by the variable with FileSuffix appended to the key.
+type Secret[T any] struct {
+	value T
+}
+
+// NewSecret returns a Secret wrapping the given value.
+func NewSecret[T any](value T) Secret[T] {
+	return Secret[T]{value: value}
+}
+
+// Reveal returns the secret value.
+func (s Secret[T]) Reveal() T {
+	return s.value
+}
+
+// String returns Redacted.
+func (s Secret[T]) String() string {
+	return Redacted
+}
+
+// GoString returns Redacted.
+func (s Secret[T]) GoString() string {
+	return Redacted
+}
+
+// Format writes Redacted, regardless of the verb and flags.
+func (s Secret[T]) Format(f fmt.State, _ rune) {
+	_, _ = f.Write([]byte(Redacted))
+}
+
+// MarshalJSON returns Redacted as a JSON string.
+func (s Secret[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(Redacted)
+}
+
+// MarshalText returns Redacted.
+func (s Secret[T]) MarshalText() ([]byte, error) {
+	return []byte(Redacted), nil
+}
+
+// UnmarshalText parses the given text as a value of type T, as Bind does for a field of type T.
+// The error, if any, does not include the text.
+func (s *Secret[T]) UnmarshalText(text []byte) error {
+	t := reflect.TypeFor[T]()
+	b := binder{opts: BindOptions{}.withDefaults()}
+	v, err := b.parse(string(text), t)
+	if err != nil {
+		return fmt.Errorf("cannot parse secret as %s", t)
+	}
+	s.value = v.Interface().(T)
+	return nil
+}
+
+// secret is implemented by all Secret types, so they can be recognised by reflection.
+func (s Secret[T]) secret() {}
+
+type secretType interface {
+	secret()
+}
+
+var secretInterface = reflect.TypeFor[secretType]()
+
+// GetSecret returns the value of the environment variable with the given key as a Secret, if it exists.
+// If the variable is unset, but the variable named by the key with FileSuffix appended is set, the secret is read from the file at that path, with any trailing newline removed.
+// An empty Optional is returned if neither variable is set, or if the file cannot be read.
+//
+// Example usage:
+//
+//	env.Set("DB_PASSWORD_FILE", "/run/secrets/db_password")
+//	s := env.GetSecret("DB_PASSWORD")
+//	out := fmt.Sprint(s) // "Some([REDACTED])"
+//	pw := s.GetOrZero().Reveal() // The contents of /run/secrets/db_password.
+func GetSecret(key string) opt.Optional[Secret[string]] {
+	return OS().GetSecret(key)
+}
+
+// RequireSecret returns the value of the environment variable with the given key as a Secret; see GetSecret.
+// A failed Result is returned if neither the variable nor its file variable is set, or if the file cannot be read.
+// The error does not include the secret.
+func RequireSecret(key string) res.Result[Secret[string]] {
+	return OS().RequireSecret(key)
+}
+
+// GetSecret returns the value of the variable with the given key as a Secret, if it exists; see the package-level GetSecret.
+func (e Env) GetSecret(key string) opt.Optional[Secret[string]] {
+	return e.RequireSecret(key).Value()
+}
+
+// RequireSecret returns the value of the variable with the given key as a Secret; see the package-level RequireSecret.
+func (e Env) RequireSecret(key string) res.Result[Secret[string]] {
+	s, ok, err := lookupSecret(e, key)
+	if err != nil {
+		return res.Fail[Secret[string]](&VarError{Key: key + FileSuffix, Err: err})
+	}
+	if !ok {
+		return res.Fail[Secret[string]](&VarError{Key: key, Err: ErrMissing})
+	}
+	return res.OK(NewSecret(s))
+}
+
+// lookupSecret returns the value of the variable with the given key, falling back to the contents of the file named by the variable with FileSuffix appended.
+func lookupSecret(src Source, key string) (string, bool, error) {
+	if s, ok := src.Lookup(key); ok {
+		return s, true, nil
+	}
+	path, ok := src.Lookup(key + FileSuffix)
+	if !ok {
+		return "", false, nil
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return "", false, err
+	}
+	return strings.TrimRight(string(b), "\r\n"), true, nil
+}
+
+// IsSensitive returns true if the given key looks like it names a secret; false otherwise.
+// A key is considered sensitive if it contains (ignoring case) any of PASSWORD, PASSWD, SECRET, TOKEN, CREDENTIAL, PRIVATE, API_KEY or ACCESS_KEY.
+func IsSensitive(key string) bool {
+	upper := strings.ToUpper(key)
+	for _, word := range sensitiveWords {
+		if strings.Contains(upper, word) {
+			return true
+		}
+	}
+	return false
+}
+
+var sensitiveWords = []string{"PASSWORD", "PASSWD", "SECRET", "TOKEN", "CREDENTIAL", "PRIVATE", "API_KEY", "ACCESS_KEY"}
+
+// ToStreamRedacted returns a stream of pairs representing the environment variables, with the values of sensitive variables replaced by Redacted.
+// A variable is sensitive if the given function returns true for its key; if the function is nil, IsSensitive is used.
+//
+// Example usage:
+//
+//	s := env.ToStreamRedacted(nil)
+//	stream.ForEach(s, func(p pair.Pair[string, string]) { log.Println(p) }) // eg: ("DB_PASSWORD", "[REDACTED]")
+func ToStreamRedacted(sensitive func(key string) bool) stream.Stream[pair.Pair[string, string]] {
+	return OS().StreamRedacted(sensitive)
+}
+
+// ToMapRedacted returns a map representing the environment variables, with the values of sensitive variables replaced by Redacted.
+// See ToStreamRedacted for details.
+func ToMapRedacted(sensitive func(key string) bool) map[string]string {
+	return stream.CollectMap(ToStreamRedacted(sensitive))
+}
+
+// StreamRedacted returns a stream of the key-value pairs of all the variables in the Env, with the values of sensitive variables replaced by Redacted.
+// See the package-level ToStreamRedacted for details.
+func (e Env) StreamRedacted(sensitive func(key string) bool) stream.Stream[pair.Pair[string, string]] {
+	if sensitive == nil {
+		sensitive = IsSensitive
+	}
+	return stream.Map(
+		e.Stream(),
+		func(p pair.Pair[string, string]) pair.Pair[string, string] {
+			if sensitive(p.First()) {
+				return pair.Of(p.First(), Redacted)
+			}
+			return p
+		},
+	)
+}
+
+// ToMapRedacted returns a map of all the variables in the Env, with the values of sensitive variables replaced by Redacted.
+// See the package-level ToStreamRedacted for details.
+func (e Env) ToMapRedacted(sensitive func(key string) bool) map[string]string {
+	return stream.CollectMap(e.StreamRedacted(sensitive))
+}
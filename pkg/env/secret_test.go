@@ -0,0 +1,148 @@
+package env
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jpfourny/papaya/v2/pkg/opt"
+)
+
+func TestSecret(t *testing.T) {
+	s := NewSecret("hunter2")
+	if s.Reveal() != "hunter2" {
+		t.Errorf("got %q, want %q", s.Reveal(), "hunter2")
+	}
+	for _, format := range []string{"%v", "%+v", "%#v", "%s", "%q", "%x", "%d"} {
+		if got := fmt.Sprintf(format, s); got != Redacted {
+			t.Errorf("%s: got %q, want %q", format, got, Redacted)
+		}
+	}
+	if got := fmt.Sprint(struct{ P Secret[int] }{NewSecret(42)}); got != "{[REDACTED]}" {
+		t.Errorf("got %q, want %q", got, "{[REDACTED]}")
+	}
+
+	b, err := json.Marshal(map[string]any{"password": s})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := string(b); got != `{"password":"[REDACTED]"}` {
+		t.Errorf("got %s, want %s", got, `{"password":"[REDACTED]"}`)
+	}
+}
+
+func TestSecret_UnmarshalText(t *testing.T) {
+	var s Secret[int]
+	if err := s.UnmarshalText([]byte("42")); err != nil || s.Reveal() != 42 {
+		t.Errorf("got %v, %v, want 42", s.Reveal(), err)
+	}
+	err := s.UnmarshalText([]byte("hunter2"))
+	if err == nil || strings.Contains(err.Error(), "hunter2") {
+		t.Errorf("got %v, want error without the secret", err)
+	}
+}
+
+func TestGetSecret(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "secret")
+	writeFile(t, path, "from-file\n")
+
+	e := FromMap(map[string]string{
+		"DIRECT":        "direct",
+		"DIRECT_FILE":   path, // Ignored, since DIRECT is set.
+		"INDIRECT_FILE": path,
+		"BADFILE_FILE":  filepath.Join(dir, "missing"),
+	})
+	if got := e.GetSecret("DIRECT"); got != opt.Of(NewSecret("direct")) {
+		t.Errorf("got %v, want direct", got.GetOrZero().Reveal())
+	}
+	if got := e.GetSecret("INDIRECT"); got != opt.Of(NewSecret("from-file")) {
+		t.Errorf("got %v, want from-file", got.GetOrZero().Reveal())
+	}
+	if got := e.GetSecret("BADFILE"); got.Present() {
+		t.Errorf("got %v, want None", got)
+	}
+	if got := e.GetSecret("MISSING"); got.Present() {
+		t.Errorf("got %v, want None", got)
+	}
+
+	err := e.RequireSecret("BADFILE").Error().GetOrZero()
+	if !errors.Is(err, os.ErrNotExist) || !strings.HasPrefix(err.Error(), `BADFILE_FILE="": `) {
+		t.Errorf("got %v, want not-exist error for BADFILE_FILE", err)
+	}
+	if err = e.RequireSecret("MISSING").Error().GetOrZero(); !errors.Is(err, ErrMissing) {
+		t.Errorf("got %v, want ErrMissing", err)
+	}
+
+	_ = os.Setenv("SECRET_TEST", "os")
+	defer Unset("SECRET_TEST")
+	if got := GetSecret("SECRET_TEST"); got != opt.Of(NewSecret("os")) {
+		t.Errorf("got %v, want os", got.GetOrZero().Reveal())
+	}
+	if got := RequireSecret("SECRET_TEST"); !got.Succeeded() {
+		t.Errorf("got %v, want Success", got)
+	}
+}
+
+func TestBind_Secret(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "secret")
+	writeFile(t, path, "from-file\n")
+
+	type Config struct {
+		Password Secret[string]  `env:"PASSWORD" required:"true"`
+		Token    *Secret[string] `env:"TOKEN"`
+		Pin      Secret[int]     `env:"PIN"`
+	}
+	var cfg Config
+	e := FromMap(map[string]string{"PASSWORD_FILE": path, "TOKEN": "abc", "PIN": "1234"})
+	if err := e.Bind(&cfg, BindOptions{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Password.Reveal() != "from-file" || cfg.Token.Reveal() != "abc" || cfg.Pin.Reveal() != 1234 {
+		t.Errorf("got %q, %q, %d", cfg.Password.Reveal(), cfg.Token.Reveal(), cfg.Pin.Reveal())
+	}
+
+	err := FromMap(map[string]string{"PASSWORD": "x", "PIN": "hunter2"}).Bind(&cfg, BindOptions{})
+	if err == nil || strings.Contains(err.Error(), "hunter2") {
+		t.Errorf("got %v, want error without the secret", err)
+	}
+	if !strings.Contains(err.Error(), `PIN="[REDACTED]" (field Pin): cannot parse secret as int`) {
+		t.Errorf("got %q", err.Error())
+	}
+}
+
+func TestToMapRedacted(t *testing.T) {
+	revert := SetAllMap(map[string]string{"REDACT_DB_PASSWORD": "hunter2", "REDACT_HOST": "localhost"})
+	defer revert()
+
+	m := ToMapRedacted(nil)
+	if m["REDACT_DB_PASSWORD"] != Redacted || m["REDACT_HOST"] != "localhost" {
+		t.Errorf("got %v, %v", m["REDACT_DB_PASSWORD"], m["REDACT_HOST"])
+	}
+
+	m = FromMap(map[string]string{"A": "1", "B": "2"}).ToMapRedacted(func(key string) bool { return key == "A" })
+	if m["A"] != Redacted || m["B"] != "2" {
+		t.Errorf("got %v", m)
+	}
+}
+
+func TestIsSensitive(t *testing.T) {
+	for key, want := range map[string]bool{
+		"DB_PASSWORD":    true,
+		"github_token":   true,
+		"AWS_ACCESS_KEY": true,
+		"STRIPE_API_KEY": true,
+		"CLIENT_SECRET":  true,
+		"HOST":           false,
+		"KEYBOARD":       false,
+	} {
+		if got := IsSensitive(key); got != want {
+			t.Errorf("%s: got %t, want %t", key, got, want)
+		}
+	}
+}
@@ -0,0 +1,292 @@
+package env
+
+import (
+	"os"
+	"strings"
+	"time"
+
+	"github.com/jpfourny/papaya/v2/pkg/constraint"
+	"github.com/jpfourny/papaya/v2/pkg/opt"
+	"github.com/jpfourny/papaya/v2/pkg/pair"
+	"github.com/jpfourny/papaya/v2/pkg/stream"
+	"github.com/jpfourny/papaya/v2/pkg/stream/mapper"
+)
+
+// Source represents a source of environment variables.
+// Libraries may accept a Source rather than reading the process environment directly, so that callers (eg: tests) can inject variables without mutating global state.
+type Source interface {
+	// Lookup returns the value of the variable with the given key, and true if it is set; otherwise, false.
+	Lookup(key string) (string, bool)
+	// Stream returns a stream of the key-value pairs of all the variables in the Source.
+	Stream() stream.Stream[pair.Pair[string, string]]
+}
+
+// Env provides typed access to the variables of a Source.
+// The package-level getters (eg: GetInt) are equivalent to calling the methods of OS().
+// Since methods cannot have type parameters, the numeric getters use fixed types (eg: GetInt and GetInt64), whereas their package-level equivalents are generic.
+// The zero value reads from the process environment.
+type Env struct {
+	src Source
+}
+
+// With returns an Env reading from the given Source.
+func With(src Source) Env {
+	if e, ok := src.(Env); ok {
+		return e
+	}
+	return Env{src: src}
+}
+
+// OS returns an Env reading from the process environment.
+func OS() Env {
+	return Env{src: osSource{}}
+}
+
+// FromMap returns an Env reading from a copy of the given map.
+// The process environment is neither read nor modified, making it suitable for parallel tests.
+//
+// Example usage:
+//
+//	e := env.FromMap(map[string]string{"PORT": "8080"})
+//	out := e.GetInt("PORT") // Some(8080)
+func FromMap(m map[string]string) Env {
+	c := make(map[string]string, len(m))
+	for k, v := range m {
+		c[k] = v
+	}
+	return Env{src: mapSource(c)}
+}
+
+// Layered returns an Env reading from the given Sources in order of precedence.
+// A variable is read from the first Source in which it is set.
+//
+// Example usage:
+//
+//	e := env.Layered(env.FromMap(overrides), env.OS(), env.FromMap(defaults))
+//	out := e.Get("PORT") // From overrides if set there, else from the OS, else from defaults.
+func Layered(sources ...Source) Env {
+	return Env{src: layeredSource(sources)}
+}
+
+// Prefixed returns an Env reading the variables from the given Source whose keys start with the given prefix.
+// The prefix is omitted from the keys seen through the Env.
+//
+// Example usage:
+//
+//	e := env.Prefixed(env.FromMap(map[string]string{"APP_PORT": "8080"}), "APP_")
+//	out := e.Get("PORT") // Some("8080")
+func Prefixed(src Source, prefix string) Env {
+	return Env{src: prefixedSource{src: src, prefix: prefix}}
+}
+
+// Lookup returns the value of the variable with the given key, and true if it is set; otherwise, false.
+func (e Env) Lookup(key string) (string, bool) {
+	return e.source().Lookup(key)
+}
+
+// Stream returns a stream of the key-value pairs of all the variables in the Env.
+func (e Env) Stream() stream.Stream[pair.Pair[string, string]] {
+	return e.source().Stream()
+}
+
+// ToMap returns a map of all the variables in the Env.
+func (e Env) ToMap() map[string]string {
+	return stream.CollectMap(e.Stream())
+}
+
+// Get returns the value of the variable with the given key, if it exists.
+func (e Env) Get(key string) opt.Optional[string] {
+	return opt.Maybe(e.Lookup(key))
+}
+
+// GetBool returns the value of the variable with the given key, if it exists and can be parsed as a boolean.
+func (e Env) GetBool(key string) opt.Optional[bool] {
+	return getBool(e, key)
+}
+
+// GetInt returns the value of the variable with the given key, if it exists and can be parsed as an int.
+func (e Env) GetInt(key string) opt.Optional[int] {
+	return getInt[int](e, key)
+}
+
+// GetInt64 returns the value of the variable with the given key, if it exists and can be parsed as an int64.
+func (e Env) GetInt64(key string) opt.Optional[int64] {
+	return getInt[int64](e, key)
+}
+
+// GetUInt returns the value of the variable with the given key, if it exists and can be parsed as a uint.
+func (e Env) GetUInt(key string) opt.Optional[uint] {
+	return getUInt[uint](e, key)
+}
+
+// GetUInt64 returns the value of the variable with the given key, if it exists and can be parsed as a uint64.
+func (e Env) GetUInt64(key string) opt.Optional[uint64] {
+	return getUInt[uint64](e, key)
+}
+
+// GetFloat returns the value of the variable with the given key, if it exists and can be parsed as a float64.
+func (e Env) GetFloat(key string) opt.Optional[float64] {
+	return getFloat[float64](e, key)
+}
+
+// GetDuration returns the value of the variable with the given key, if it exists and can be parsed as a duration.
+// See time.ParseDuration for details on the expected format.
+func (e Env) GetDuration(key string) opt.Optional[time.Duration] {
+	return getDuration(e, key)
+}
+
+// GetTime returns the value of the variable with the given key, if it exists and can be parsed as a time.Time with the given layout.
+// See time.Parse for details on the expected format.
+func (e Env) GetTime(key string, layout string) opt.Optional[time.Time] {
+	return getTime(e, key, layout)
+}
+
+// GetTimeInLocation returns the value of the variable with the given key, if it exists and can be parsed as a time.Time with the given layout and location.
+// See time.ParseInLocation for details on the expected format.
+func (e Env) GetTimeInLocation(key string, layout string, loc *time.Location) opt.Optional[time.Time] {
+	return getTimeInLocation(e, key, layout, loc)
+}
+
+// Bind populates the fields of the struct pointed to by target from the variables in the Env.
+// See the package-level Bind for details.
+func (e Env) Bind(target any, opts BindOptions) error {
+	return bind(e, target, opts)
+}
+
+func (e Env) source() Source {
+	if e.src == nil {
+		return osSource{}
+	}
+	return e.src
+}
+
+func getBool(src Source, key string) opt.Optional[bool] {
+	return opt.OptionalMap(
+		opt.Maybe(src.Lookup(key)),
+		mapper.TryParseBool[string](),
+	)
+}
+
+func getInt[I constraint.SignedInteger](src Source, key string) opt.Optional[I] {
+	return opt.OptionalMap(
+		opt.Maybe(src.Lookup(key)),
+		mapper.TryParseInt[string, I](10, 64),
+	)
+}
+
+func getUInt[I constraint.UnsignedInteger](src Source, key string) opt.Optional[I] {
+	return opt.OptionalMap(
+		opt.Maybe(src.Lookup(key)),
+		mapper.TryParseUint[string, I](10, 64),
+	)
+}
+
+func getFloat[F constraint.Float](src Source, key string) opt.Optional[F] {
+	return opt.OptionalMap(
+		opt.Maybe(src.Lookup(key)),
+		mapper.TryParseFloat[string, F](64),
+	)
+}
+
+func getDuration(src Source, key string) opt.Optional[time.Duration] {
+	return opt.OptionalMap(
+		opt.Maybe(src.Lookup(key)),
+		mapper.TryParseDuration[string](),
+	)
+}
+
+func getTime(src Source, key string, layout string) opt.Optional[time.Time] {
+	return opt.OptionalMap(
+		opt.Maybe(src.Lookup(key)),
+		mapper.TryParseTime[string](layout),
+	)
+}
+
+func getTimeInLocation(src Source, key string, layout string, loc *time.Location) opt.Optional[time.Time] {
+	return opt.OptionalMap(
+		opt.Maybe(src.Lookup(key)),
+		mapper.TryParseTimeInLocation[string](layout, loc),
+	)
+}
+
+// osSource reads from the process environment.
+type osSource struct{}
+
+func (osSource) Lookup(key string) (string, bool) {
+	return os.LookupEnv(key)
+}
+
+func (osSource) Stream() stream.Stream[pair.Pair[string, string]] {
+	return stream.Map(
+		stream.FromSlice(os.Environ()),
+		func(s string) pair.Pair[string, string] {
+			k, v, _ := strings.Cut(s, "=")
+			return pair.Of(k, v)
+		},
+	)
+}
+
+// mapSource reads from a map.
+type mapSource map[string]string
+
+func (m mapSource) Lookup(key string) (string, bool) {
+	v, ok := m[key]
+	return v, ok
+}
+
+func (m mapSource) Stream() stream.Stream[pair.Pair[string, string]] {
+	return stream.FromMap(m)
+}
+
+// layeredSource reads from the first of its sources in which a variable is set.
+type layeredSource []Source
+
+func (l layeredSource) Lookup(key string) (string, bool) {
+	for _, src := range l {
+		if v, ok := src.Lookup(key); ok {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+func (l layeredSource) Stream() stream.Stream[pair.Pair[string, string]] {
+	return func(yield stream.Consumer[pair.Pair[string, string]]) {
+		seen := make(map[string]struct{})
+		for _, src := range l {
+			ok := true
+			src.Stream()(func(p pair.Pair[string, string]) bool {
+				if _, dup := seen[p.First()]; dup {
+					return true // Shadowed by an earlier source.
+				}
+				seen[p.First()] = struct{}{}
+				ok = yield(p)
+				return ok
+			})
+			if !ok {
+				return // Consumer saw enough.
+			}
+		}
+	}
+}
+
+// prefixedSource reads the variables of its source with the prefix, omitting the prefix from the keys.
+type prefixedSource struct {
+	src    Source
+	prefix string
+}
+
+func (p prefixedSource) Lookup(key string) (string, bool) {
+	return p.src.Lookup(p.prefix + key)
+}
+
+func (p prefixedSource) Stream() stream.Stream[pair.Pair[string, string]] {
+	return stream.Map(
+		stream.Filter(p.src.Stream(), func(kv pair.Pair[string, string]) bool {
+			return strings.HasPrefix(kv.First(), p.prefix)
+		}),
+		func(kv pair.Pair[string, string]) pair.Pair[string, string] {
+			return pair.Of(strings.TrimPrefix(kv.First(), p.prefix), kv.Second())
+		},
+	)
+}
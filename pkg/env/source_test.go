@@ -0,0 +1,188 @@
+package env
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jpfourny/papaya/v2/pkg/opt"
+	"github.com/jpfourny/papaya/v2/pkg/stream/mapper"
+)
+
+func TestFromMap(t *testing.T) {
+	t.Parallel()
+
+	m := map[string]string{
+		"STR":      "foo",
+		"BOOL":     "true",
+		"INT":      "-42",
+		"UINT":     "42",
+		"FLOAT":    "1.5",
+		"DURATION": "2m",
+		"TIME":     "2024-01-02",
+		"BAD":      "nope",
+	}
+	e := FromMap(m)
+	m["STR"] = "changed" // The Env holds a copy.
+
+	if got := e.Get("STR"); got != opt.Of("foo") {
+		t.Errorf("got %v, want %v", got, opt.Of("foo"))
+	}
+	if got := e.Get("MISSING"); got.Present() {
+		t.Errorf("got %v, want None", got)
+	}
+	if got := e.GetBool("BOOL"); got != opt.Of(true) {
+		t.Errorf("got %v, want %v", got, opt.Of(true))
+	}
+	if got := e.GetInt("INT"); got != opt.Of(-42) {
+		t.Errorf("got %v, want %v", got, opt.Of(-42))
+	}
+	if got := e.GetInt64("INT"); got != opt.Of[int64](-42) {
+		t.Errorf("got %v, want %v", got, opt.Of[int64](-42))
+	}
+	if got := e.GetUInt("UINT"); got != opt.Of[uint](42) {
+		t.Errorf("got %v, want %v", got, opt.Of[uint](42))
+	}
+	if got := e.GetUInt64("UINT"); got != opt.Of[uint64](42) {
+		t.Errorf("got %v, want %v", got, opt.Of[uint64](42))
+	}
+	if got := e.GetFloat("FLOAT"); got != opt.Of(1.5) {
+		t.Errorf("got %v, want %v", got, opt.Of(1.5))
+	}
+	if got := e.GetDuration("DURATION"); got != opt.Of(2*time.Minute) {
+		t.Errorf("got %v, want %v", got, opt.Of(2*time.Minute))
+	}
+	want := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	if got := e.GetTime("TIME", time.DateOnly); got != opt.Of(want) {
+		t.Errorf("got %v, want %v", got, opt.Of(want))
+	}
+	if got := e.GetTimeInLocation("TIME", time.DateOnly, time.UTC); got != opt.Of(want) {
+		t.Errorf("got %v, want %v", got, opt.Of(want))
+	}
+	if got := e.GetInt("BAD"); got.Present() {
+		t.Errorf("got %v, want None", got)
+	}
+	if got := len(e.ToMap()); got != len(m) {
+		t.Errorf("got %d variables, want %d", got, len(m))
+	}
+}
+
+func TestLayered(t *testing.T) {
+	t.Parallel()
+
+	e := Layered(
+		FromMap(map[string]string{"A": "1"}),
+		FromMap(map[string]string{"A": "2", "B": "2"}),
+		FromMap(map[string]string{"C": "3"}),
+	)
+	if got := e.GetInt("A"); got != opt.Of(1) {
+		t.Errorf("got %v, want %v", got, opt.Of(1))
+	}
+	if got := e.GetInt("B"); got != opt.Of(2) {
+		t.Errorf("got %v, want %v", got, opt.Of(2))
+	}
+	if got := e.Get("D"); got.Present() {
+		t.Errorf("got %v, want None", got)
+	}
+	want := map[string]string{"A": "1", "B": "2", "C": "3"}
+	if got := e.ToMap(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestPrefixed(t *testing.T) {
+	t.Parallel()
+
+	e := Prefixed(FromMap(map[string]string{"APP_PORT": "8080", "OTHER": "x"}), "APP_")
+	if got := e.GetInt("PORT"); got != opt.Of(8080) {
+		t.Errorf("got %v, want %v", got, opt.Of(8080))
+	}
+	if got := e.Get("OTHER"); got.Present() {
+		t.Errorf("got %v, want None", got)
+	}
+	want := map[string]string{"PORT": "8080"}
+	if got := e.ToMap(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestEnv_Bind(t *testing.T) {
+	t.Parallel()
+
+	type Config struct {
+		Port int `env:"PORT"`
+	}
+	var cfg Config
+	if err := FromMap(map[string]string{"APP_PORT": "8080"}).Bind(&cfg, BindOptions{Prefix: "APP_"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Port != 8080 {
+		t.Errorf("got %d, want %d", cfg.Port, 8080)
+	}
+}
+
+func TestOS(t *testing.T) {
+	_ = os.Setenv("SOURCE_TEST", "foo")
+	defer Unset("SOURCE_TEST")
+
+	var zero Env // Zero value reads from the process environment.
+	for _, e := range []Env{OS(), zero, With(osSource{})} {
+		if got := e.Get("SOURCE_TEST"); got != opt.Of("foo") {
+			t.Errorf("got %v, want %v", got, opt.Of("foo"))
+		}
+		if got := e.ToMap()["SOURCE_TEST"]; got != "foo" {
+			t.Errorf("got %q, want %q", got, "foo")
+		}
+	}
+}
+
+func TestFrom(t *testing.T) {
+	t.Parallel()
+
+	src := FromMap(map[string]string{
+		"PORTS":  "80, 443",
+		"LIMITS": "read=10,write=5",
+		"LEVEL":  "debug",
+		"BAD":    "x",
+	})
+	parseInt := mapper.TryParseInt[string, int](10, 64)
+
+	if got := GetSliceFrom(src, "PORTS", ",", parseInt); !reflect.DeepEqual(got.GetOrZero(), []int{80, 443}) {
+		t.Errorf("got %v, want %v", got, []int{80, 443})
+	}
+	if got := GetSliceFrom(src, "BAD", ",", parseInt); got.Present() {
+		t.Errorf("got %v, want None", got)
+	}
+	if got := RequireSliceFrom(src, "MISSING", ",", parseInt); !errors.Is(got.Error().GetOrZero(), ErrMissing) {
+		t.Errorf("got %v, want %v", got, ErrMissing)
+	}
+	if got := RequireSliceFrom(src, "PORTS", ",", parseInt); !reflect.DeepEqual(got.Value().GetOrZero(), []int{80, 443}) {
+		t.Errorf("got %v, want %v", got, []int{80, 443})
+	}
+
+	wantMap := map[string]int{"read": 10, "write": 5}
+	if got := GetMapFrom(src, "LIMITS", ",", "=", opt.Of[string], parseInt); !reflect.DeepEqual(got.GetOrZero(), wantMap) {
+		t.Errorf("got %v, want %v", got, wantMap)
+	}
+	if got := RequireMapFrom(src, "LIMITS", ",", "=", opt.Of[string], parseInt); !reflect.DeepEqual(got.Value().GetOrZero(), wantMap) {
+		t.Errorf("got %v, want %v", got, wantMap)
+	}
+	if got := RequireMapFrom(src, "BAD", ",", "=", opt.Of[string], parseInt); !got.Failed() {
+		t.Errorf("got %v, want Failure", got)
+	}
+
+	if got := GetEnumFrom(src, "LEVEL", "debug", "info"); got != opt.Of("debug") {
+		t.Errorf("got %v, want %v", got, opt.Of("debug"))
+	}
+	if got := GetEnumFrom(src, "LEVEL", "info", "warn"); got.Present() {
+		t.Errorf("got %v, want None", got)
+	}
+	if got := RequireEnumFrom(src, "LEVEL", "debug", "info"); got.Value().GetOrZero() != "debug" {
+		t.Errorf("got %v, want %v", got, "debug")
+	}
+	if got := RequireEnumFrom(src, "BAD", "debug", "info"); !got.Failed() {
+		t.Errorf("got %v, want Failure", got)
+	}
+}
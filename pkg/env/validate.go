@@ -0,0 +1,156 @@
+package env
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// constraintTags are the struct tags checked by Validate, in the order they are checked.
+var constraintTags = []string{"min", "max", "regex", "oneof"}
+
+// ValidationError aggregates the VarError of every field that violates a constraint.
+type ValidationError struct {
+	Errors []*VarError
+}
+
+func (e *ValidationError) Error() string {
+	return joinVarErrors(fmt.Sprintf("env: %d invalid variable(s)", len(e.Errors)), e.Errors)
+}
+
+func (e *ValidationError) Unwrap() []error {
+	return unwrapVarErrors(e.Errors)
+}
+
+// Validate checks the fields of the given config struct (or pointer to struct) against the constraints declared in their struct tags.
+// It is typically called after Bind, with the same options, so that errors name the keys of the offending variables.
+//
+// The following constraint tags are supported:
+//   - `min:"N"` and `max:"N"` bound the value of a numeric field (including time.Duration, eg: `min:"1s"`), or the length of a string, slice or map field.
+//   - `regex:"PATTERN"` requires a string field to match the regular expression.
+//   - `oneof:"A B C"` requires the value of a field, formatted with fmt.Sprint, to be one of the space-separated options.
+//
+// Constraints on a nil pointer field are not checked; otherwise, they apply to the value pointed to.
+// Constraints on a Secret field apply to its revealed value, which is redacted in errors.
+// All fields are checked, even if some fail.
+// If any constraints are violated, a *ValidationError listing all of them is returned.
+//
+// Example usage:
+//
+//	type Config struct {
+//		Port  int    `env:"PORT" default:"8080" min:"1" max:"65535"`
+//		Level string `env:"LEVEL" default:"info" oneof:"debug info warn error"`
+//	}
+//
+//	var cfg Config
+//	if err := env.Bind(&cfg, env.BindOptions{}); err != nil {
+//		return err
+//	}
+//	if err := env.Validate(cfg, env.BindOptions{}); err != nil {
+//		return err // eg: "env: 1 invalid variable(s):\n\tPORT="0" (field Port): must be at least 1"
+//	}
+func Validate(cfg any, opts BindOptions) error {
+	v, err := structValue("Validate", cfg)
+	if err != nil {
+		return err
+	}
+	b := binder{opts: opts.withDefaults()}
+	var errs []*VarError
+	walkFields(v, opts.Prefix, "", func(fv reflect.Value, sf reflect.StructField, key, path string) {
+		if fv.Kind() == reflect.Pointer {
+			if fv.IsNil() {
+				return
+			}
+			fv = fv.Elem()
+		}
+		value := fmt.Sprint(fv.Interface()) // Redacted for a Secret.
+		if isSecret(fv.Type()) {
+			fv = fv.MethodByName("Reveal").Call(nil)[0]
+		}
+		for _, name := range constraintTags {
+			c, ok := sf.Tag.Lookup(name)
+			if !ok {
+				continue
+			}
+			if err := b.check(fv, name, c); err != nil {
+				errs = append(errs, &VarError{Key: key, Value: value, Field: path, Err: err})
+				break // Report one violation per field.
+			}
+		}
+	})
+	if len(errs) > 0 {
+		return &ValidationError{Errors: errs}
+	}
+	return nil
+}
+
+// check returns an error if the given value violates the named constraint.
+func (b *binder) check(v reflect.Value, name, c string) error {
+	switch name {
+	case "min", "max":
+		d, err := b.compareBound(v, c)
+		if err != nil {
+			return fmt.Errorf("invalid %s constraint %q: %w", name, c, err)
+		}
+		what := "value"
+		if isLengthKind(v.Kind()) {
+			what = "length"
+		}
+		if name == "min" && d < 0 {
+			return fmt.Errorf("%s must be at least %s", what, c)
+		}
+		if name == "max" && d > 0 {
+			return fmt.Errorf("%s must be at most %s", what, c)
+		}
+	case "regex":
+		if v.Kind() != reflect.String {
+			return fmt.Errorf("regex constraint requires a string field; got %s", v.Type())
+		}
+		re, err := regexp.Compile(c)
+		if err != nil {
+			return fmt.Errorf("invalid regex constraint %q: %w", c, err)
+		}
+		if !re.MatchString(v.String()) {
+			return fmt.Errorf("must match %q", c)
+		}
+	case "oneof":
+		options := strings.Fields(c)
+		if !slices.Contains(options, fmt.Sprint(v.Interface())) {
+			return fmt.Errorf("must be one of %q", options)
+		}
+	}
+	return nil
+}
+
+// compareBound compares the given value (or its length) with the given bound, returning a negative number if less, zero if equal, or a positive number if greater.
+func (b *binder) compareBound(v reflect.Value, bound string) (int, error) {
+	if isLengthKind(v.Kind()) {
+		n, err := strconv.Atoi(bound)
+		if err != nil {
+			return 0, err
+		}
+		return cmp.Compare(v.Len(), n), nil
+	}
+
+	bv, err := b.parse(bound, v.Type())
+	if err != nil {
+		return 0, err
+	}
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cmp.Compare(v.Int(), bv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return cmp.Compare(v.Uint(), bv.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return cmp.Compare(v.Float(), bv.Float()), nil
+	}
+	return 0, fmt.Errorf("unsupported field type %s", v.Type())
+}
+
+func isLengthKind(k reflect.Kind) bool {
+	return k == reflect.String || k == reflect.Slice || k == reflect.Map
+}
@@ -0,0 +1,118 @@
+package env
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidate(t *testing.T) {
+	type Config struct {
+		Port    int               `env:"PORT" min:"1" max:"65535"`
+		Ratio   float64           `env:"RATIO" min:"0" max:"1"`
+		Workers uint              `env:"WORKERS" max:"8"`
+		Timeout time.Duration     `env:"TIMEOUT" min:"1s"`
+		Name    string            `env:"NAME" min:"2" regex:"^[a-z]+$"`
+		Level   string            `env:"LEVEL" oneof:"debug info"`
+		Hosts   []string          `env:"HOSTS" min:"1"`
+		Tags    map[string]string `env:"TAGS" max:"1"`
+		Opt     *int              `env:"OPT" min:"10"`
+		Code    int               `env:"CODE" oneof:"200 404"`
+	}
+
+	valid := Config{Port: 80, Ratio: 0.5, Workers: 8, Timeout: time.Second, Name: "ok", Level: "info", Hosts: []string{"a"}, Code: 404}
+	if err := Validate(valid, BindOptions{}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	three := 3
+	invalid := Config{
+		Port:    0,
+		Ratio:   1.5,
+		Workers: 9,
+		Timeout: time.Millisecond,
+		Name:    "Bad",
+		Level:   "trace",
+		Tags:    map[string]string{"a": "1", "b": "2"},
+		Opt:     &three,
+		Code:    500,
+	}
+	err := Validate(&invalid, BindOptions{Prefix: "APP_"})
+	var ve *ValidationError
+	if !errors.As(err, &ve) {
+		t.Fatalf("got %v, want *ValidationError", err)
+	}
+	got := make(map[string]string)
+	for _, e := range ve.Errors {
+		got[e.Key] = e.Err.Error()
+	}
+	want := map[string]string{
+		"APP_PORT":    "value must be at least 1",
+		"APP_RATIO":   "value must be at most 1",
+		"APP_WORKERS": "value must be at most 8",
+		"APP_TIMEOUT": "value must be at least 1s",
+		"APP_NAME":    `must match "^[a-z]+$"`,
+		"APP_LEVEL":   `must be one of ["debug" "info"]`,
+		"APP_HOSTS":   "length must be at least 1",
+		"APP_TAGS":    "length must be at most 1",
+		"APP_OPT":     "value must be at least 10",
+		"APP_CODE":    `must be one of ["200" "404"]`,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if !strings.Contains(err.Error(), `APP_PORT="0" (field Port): value must be at least 1`) {
+		t.Errorf("got %q", err.Error())
+	}
+}
+
+func TestValidate_InvalidConstraints(t *testing.T) {
+	type Config struct {
+		Port  int      `env:"PORT" min:"abc"`
+		Name  string   `env:"NAME" regex:"("`
+		Ratio float64  `env:"RATIO" regex:"x"`
+		Ch    chan int `env:"CH" min:"1"`
+	}
+	err := Validate(Config{}, BindOptions{})
+	var ve *ValidationError
+	if !errors.As(err, &ve) || len(ve.Errors) != 4 {
+		t.Fatalf("got %v, want 4 errors", err)
+	}
+	if _, err = Describe(nil, BindOptions{}); err == nil {
+		t.Errorf("expected error for nil")
+	}
+	if err = Validate((*Config)(nil), BindOptions{}); err == nil {
+		t.Errorf("expected error for nil pointer")
+	}
+}
+
+func TestValidate_Secret(t *testing.T) {
+	type Config struct {
+		Password Secret[string]  `env:"PASSWORD" min:"8"`
+		Mode     *Secret[string] `env:"MODE" oneof:"fast safe"`
+		Pin      Secret[int]     `env:"PIN" max:"9999"`
+	}
+	mode := Secret[string]{value: "safe"}
+	valid := Config{Password: Secret[string]{value: "long enough"}, Mode: &mode, Pin: Secret[int]{value: 1234}}
+	if err := Validate(valid, BindOptions{}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	mode = Secret[string]{value: "hunter1"}
+	invalid := Config{Password: Secret[string]{value: "hunter2"}, Mode: &mode, Pin: Secret[int]{value: 12345}}
+	err := Validate(invalid, BindOptions{})
+	var ve *ValidationError
+	if !errors.As(err, &ve) || len(ve.Errors) != 3 {
+		t.Fatalf("got %v, want 3 errors", err)
+	}
+	for _, s := range []string{"hunter1", "hunter2", "12345"} {
+		if strings.Contains(err.Error(), s) {
+			t.Errorf("error %q reveals secret %q", err.Error(), s)
+		}
+	}
+	if !strings.Contains(err.Error(), `PASSWORD="[REDACTED]" (field Password): length must be at least 8`) {
+		t.Errorf("got %q", err.Error())
+	}
+}
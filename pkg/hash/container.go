@@ -0,0 +1,84 @@
+package hash
+
+import (
+	"github.com/jpfourny/papaya/v2/pkg/cmp"
+	"github.com/jpfourny/papaya/v2/pkg/opt"
+)
+
+// Comparing returns a Hasher of values of type E that considers two values equal if the provided cmp.Comparer returns zero for them, and hashes them using the provided hash function.
+// The hash function must be consistent with the Comparer: values that compare equal must have the same hash.
+// This pairs a Comparer with a hash, so that the same notion of equality can be used with both the sorted and the hashed operators, such as stream.DistinctBy and stream.DistinctByHash.
+//
+// Example usage:
+//
+//	h := hash.Comparing(cmp.CaseInsensitive(), func(s string) uint64 { return hash.String[string]().Hash(strings.ToLower(s)) })
+//	ok := h.Equal("Foo", "FOO") // true
+func Comparing[E any](compare cmp.Comparer[E], hashFunc func(E) uint64) Hasher[E] {
+	return New(hashFunc, compare.Equal)
+}
+
+// Optional returns a Hasher of values of type opt.Optional[E] that compares and hashes the contained values using the provided Hasher.
+// Empty Optionals are considered equal to each other, and a nil Optional is considered empty, as with cmp.Optional.
+//
+// Example usage:
+//
+//	h := hash.Optional(hash.Integer[int]())
+//	ok := h.Equal(opt.Of(1), opt.Of(1)) // true
+//	ok = h.Equal(opt.Empty[int](), nil) // true
+func Optional[E any](h Hasher[E]) Hasher[opt.Optional[E]] {
+	return New(
+		func(o opt.Optional[E]) uint64 {
+			if v, ok := getOptional(o); ok {
+				return Combine(Int(1), h.Hash(v))
+			}
+			return Int(0)
+		},
+		func(a, b opt.Optional[E]) bool {
+			va, oka := getOptional(a)
+			vb, okb := getOptional(b)
+			if !oka || !okb {
+				return oka == okb
+			}
+			return h.Equal(va, vb)
+		},
+	)
+}
+
+// Map returns a Hasher of values of type map[K]V that considers two maps equal if they have the same keys, and the values of each key are equal according to the provided value Hasher.
+// The hash of a map does not depend on the iteration order of its entries; keys are hashed using the provided key Hasher, which must be consistent with the == operator.
+//
+// Example usage:
+//
+//	h := hash.Map(hash.String[string](), hash.Integer[int]())
+//	ok := h.Equal(map[string]int{"a": 1}, map[string]int{"a": 1}) // true
+func Map[K comparable, V any](keyHasher Hasher[K], valueHasher Hasher[V]) Hasher[map[K]V] {
+	return New(
+		func(m map[K]V) uint64 {
+			x := Int(len(m))
+			for k, v := range m {
+				x += Combine(keyHasher.Hash(k), valueHasher.Hash(v)) // Addition is commutative, so the order of entries does not matter.
+			}
+			return x
+		},
+		func(a, b map[K]V) bool {
+			if len(a) != len(b) {
+				return false
+			}
+			for k, va := range a {
+				vb, ok := b[k]
+				if !ok || !valueHasher.Equal(va, vb) {
+					return false
+				}
+			}
+			return true
+		},
+	)
+}
+
+func getOptional[E any](o opt.Optional[E]) (E, bool) {
+	if o == nil {
+		var zero E
+		return zero, false
+	}
+	return o.Get()
+}
@@ -0,0 +1,59 @@
+package hash
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jpfourny/papaya/v2/pkg/cmp"
+	"github.com/jpfourny/papaya/v2/pkg/opt"
+)
+
+func TestComparing(t *testing.T) {
+	h := Comparing(cmp.CaseInsensitive(), func(s string) uint64 { return String[string]().Hash(strings.ToLower(s)) })
+	if !h.Equal("Foo", "FOO") {
+		t.Errorf("expected Foo and FOO to be equal")
+	}
+	if h.Hash("Foo") != h.Hash("FOO") {
+		t.Errorf("expected Foo and FOO to have the same hash")
+	}
+	if h.Equal("Foo", "Bar") {
+		t.Errorf("expected Foo and Bar to differ")
+	}
+}
+
+func TestOptional(t *testing.T) {
+	h := Optional(Integer[int]())
+	if !h.Equal(opt.Of(1), opt.Of(1)) || h.Hash(opt.Of(1)) != h.Hash(opt.Of(1)) {
+		t.Errorf("expected Some(1) values to be equal, with the same hash")
+	}
+	if !h.Equal(opt.Empty[int](), nil) || h.Hash(opt.Empty[int]()) != h.Hash(nil) {
+		t.Errorf("expected nil and None to be equal, with the same hash")
+	}
+	if h.Equal(opt.Of(0), opt.Empty[int]()) {
+		t.Errorf("expected Some(0) and None to differ")
+	}
+	if h.Equal(opt.Of(1), opt.Of(2)) {
+		t.Errorf("expected Some(1) and Some(2) to differ")
+	}
+}
+
+func TestMap(t *testing.T) {
+	h := Map(String[string](), Integer[int]())
+	a := map[string]int{"a": 1, "b": 2, "c": 3}
+	b := map[string]int{"c": 3, "b": 2, "a": 1}
+	if !h.Equal(a, b) || h.Hash(a) != h.Hash(b) {
+		t.Errorf("expected equal maps, with the same hash")
+	}
+	if h.Equal(a, map[string]int{"a": 1, "b": 2, "c": 4}) {
+		t.Errorf("expected maps with different values to differ")
+	}
+	if h.Equal(a, map[string]int{"a": 1, "b": 2, "d": 3}) {
+		t.Errorf("expected maps with different keys to differ")
+	}
+	if h.Equal(a, map[string]int{"a": 1, "b": 2}) {
+		t.Errorf("expected maps of different sizes to differ")
+	}
+	if !h.Equal(nil, map[string]int{}) {
+		t.Errorf("expected nil and empty maps to be equal")
+	}
+}
@@ -0,0 +1,6 @@
+// Package hash provides Hashers, which pair an equality function with a consistent hash function, for storing values of types that are not comparable (eg: slices) in hash tables.
+//
+// Hashers complement cmp.Comparer: where a Comparer requires a total order and yields sorted stores, a Hasher only requires equality, and yields hashed stores with O(1) lookups.
+// Comparing pairs a Comparer with a consistent hash function, and Optional and Map provide Hashers for the types that cmp.Optional and cmp.Map compare.
+// Hash values are seeded randomly per process, so they must not be persisted or shared between processes.
+package hash
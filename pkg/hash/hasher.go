@@ -0,0 +1,192 @@
+package hash
+
+import (
+	"hash/maphash"
+	"math"
+
+	"github.com/jpfourny/papaya/v2/pkg/constraint"
+	"github.com/jpfourny/papaya/v2/pkg/pair"
+)
+
+// Hasher hashes values of type E and compares them for equality.
+// Implementations must be consistent: values that are equal must have the same hash.
+type Hasher[E any] interface {
+	// Hash returns the hash of the given value.
+	Hash(e E) uint64
+
+	// Equal returns true if the given values are equal; false otherwise.
+	Equal(a, b E) bool
+}
+
+// seed is the seed of all hashes computed by this package.
+var seed = maphash.MakeSeed()
+
+// salt is mixed into hashes of fixed-size values, so that they are seeded like those of strings.
+var salt = maphash.String(seed, "")
+
+// New returns a Hasher that uses the provided hash and equality functions.
+// The functions must be consistent: values that are equal must have the same hash.
+//
+// Example usage:
+//
+//	h := hash.New(
+//	  func(p Point) uint64 { return hash.Combine(hash.Int(p.X), hash.Int(p.Y)) },
+//	  func(a, b Point) bool { return a == b },
+//	)
+func New[E any](hash func(E) uint64, equal func(a, b E) bool) Hasher[E] {
+	return funcHasher[E]{hash: hash, equal: equal}
+}
+
+type funcHasher[E any] struct {
+	hash  func(E) uint64
+	equal func(a, b E) bool
+}
+
+func (h funcHasher[E]) Hash(e E) uint64 {
+	return h.hash(e)
+}
+
+func (h funcHasher[E]) Equal(a, b E) bool {
+	return h.equal(a, b)
+}
+
+// Bool returns a Hasher of values of any boolean type E.
+func Bool[E constraint.Boolean]() Hasher[E] {
+	return New(
+		func(b E) uint64 { return mix(uint64(btoi(bool(b)))) },
+		func(a, b E) bool { return a == b },
+	)
+}
+
+// Integer returns a Hasher of values of any integer type E.
+func Integer[E constraint.Integer]() Hasher[E] {
+	return New(
+		func(i E) uint64 { return mix(uint64(i)) },
+		func(a, b E) bool { return a == b },
+	)
+}
+
+// Float returns a Hasher of values of any floating-point type E.
+// As with cmp.Float, all NaN values are considered equal to each other, and negative zero is considered equal to positive zero, so that NaN values may be stored and looked up.
+func Float[E constraint.Float]() Hasher[E] {
+	return New(hashFloat[E], equalFloat[E])
+}
+
+func hashFloat[E constraint.Float](f E) uint64 {
+	switch {
+	case f != f: // NaN
+		return mix(math.Float64bits(math.NaN()))
+	case f == 0: // Negative or positive zero
+		return mix(0)
+	}
+	return mix(math.Float64bits(float64(f)))
+}
+
+func equalFloat[E constraint.Float](a, b E) bool {
+	return a == b || (a != a && b != b)
+}
+
+// String returns a Hasher of values of any string type E.
+func String[E constraint.String]() Hasher[E] {
+	return New(
+		func(s E) uint64 { return maphash.String(seed, string(s)) },
+		func(a, b E) bool { return a == b },
+	)
+}
+
+// Bytes returns a Hasher of byte slices, comparing their contents.
+// A nil slice is considered equal to an empty slice.
+func Bytes() Hasher[[]byte] {
+	return New(
+		func(b []byte) uint64 { return maphash.Bytes(seed, b) },
+		func(a, b []byte) bool { return string(a) == string(b) },
+	)
+}
+
+// Slice returns a Hasher of slices of type []E, comparing and hashing their elements in order using the provided Hasher.
+// A nil slice is considered equal to an empty slice.
+//
+// Example usage:
+//
+//	h := hash.Slice(hash.Integer[int]())
+//	ok := h.Equal([]int{1, 2}, []int{1, 2}) // true
+func Slice[E any](h Hasher[E]) Hasher[[]E] {
+	return New(
+		func(s []E) uint64 {
+			x := mix(uint64(len(s)))
+			for _, e := range s {
+				x = Combine(x, h.Hash(e))
+			}
+			return x
+		},
+		func(a, b []E) bool {
+			if len(a) != len(b) {
+				return false
+			}
+			for i := range a {
+				if !h.Equal(a[i], b[i]) {
+					return false
+				}
+			}
+			return true
+		},
+	)
+}
+
+// Pair returns a Hasher of values of type pair.Pair[A, B], comparing and hashing their elements using the provided Hashers.
+func Pair[A, B any](ha Hasher[A], hb Hasher[B]) Hasher[pair.Pair[A, B]] {
+	return New(
+		func(p pair.Pair[A, B]) uint64 {
+			return Combine(ha.Hash(p.First()), hb.Hash(p.Second()))
+		},
+		func(a, b pair.Pair[A, B]) bool {
+			return ha.Equal(a.First(), b.First()) && hb.Equal(a.Second(), b.Second())
+		},
+	)
+}
+
+// By returns a Hasher of values of type E that compares and hashes the keys of type K extracted from them using the provided Hasher.
+//
+// Example usage:
+//
+//	h := hash.By(func(p Person) string { return p.Email }, hash.String[string]())
+func By[E, K any](key func(E) K, h Hasher[K]) Hasher[E] {
+	return New(
+		func(e E) uint64 { return h.Hash(key(e)) },
+		func(a, b E) bool { return h.Equal(key(a), key(b)) },
+	)
+}
+
+// Int returns the hash of the given integer.
+// It is a shorthand for Integer[int]().Hash, for use in hash functions passed to New.
+func Int(i int) uint64 {
+	return mix(uint64(i))
+}
+
+// Combine returns a hash combining the given hashes, in order.
+// It is intended for hashing composite values in hash functions passed to New.
+func Combine(hashes ...uint64) uint64 {
+	var x uint64
+	for _, h := range hashes {
+		x = mix(x ^ (h + 0x9e3779b97f4a7c15 + x<<6 + x>>2))
+	}
+	return x
+}
+
+// mix returns a seeded hash of the given 64-bit value, using the finalizer of the SplitMix64 generator.
+func mix(x uint64) uint64 {
+	x ^= salt
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
+
+func btoi(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
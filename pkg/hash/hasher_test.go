@@ -0,0 +1,115 @@
+package hash
+
+import (
+	"math"
+	"testing"
+
+	"github.com/jpfourny/papaya/v2/pkg/pair"
+)
+
+// checkHasher verifies that h considers a and b equal (or not, per want), and that equal values have equal hashes.
+func checkHasher[E any](t *testing.T, h Hasher[E], a, b E, want bool) {
+	t.Helper()
+	if got := h.Equal(a, b); got != want {
+		t.Errorf("Equal(%v, %v): got %v, want %v", a, b, got, want)
+	}
+	if got := h.Equal(b, a); got != want {
+		t.Errorf("Equal(%v, %v): got %v, want %v", b, a, got, want)
+	}
+	if want && h.Hash(a) != h.Hash(b) {
+		t.Errorf("Hash(%v) != Hash(%v) for equal values", a, b)
+	}
+}
+
+func TestNew(t *testing.T) {
+	h := New(
+		func(s string) uint64 { return uint64(len(s)) },
+		func(a, b string) bool { return len(a) == len(b) },
+	)
+	checkHasher(t, h, "foo", "bar", true)
+	checkHasher(t, h, "foo", "ba", false)
+	if got := h.Hash("foo"); got != 3 {
+		t.Errorf("got %d, want 3", got)
+	}
+}
+
+func TestBool(t *testing.T) {
+	h := Bool[bool]()
+	checkHasher(t, h, true, true, true)
+	checkHasher(t, h, true, false, false)
+	if h.Hash(true) == h.Hash(false) {
+		t.Errorf("expected different hashes for true and false")
+	}
+}
+
+func TestInteger(t *testing.T) {
+	h := Integer[int64]()
+	checkHasher(t, h, 42, 42, true)
+	checkHasher(t, h, 42, -42, false)
+	if h.Hash(1) == h.Hash(2) {
+		t.Errorf("expected different hashes for 1 and 2")
+	}
+	if Int(7) != Integer[int]().Hash(7) {
+		t.Errorf("expected Int to match Integer[int]")
+	}
+}
+
+func TestFloat(t *testing.T) {
+	h := Float[float64]()
+	checkHasher(t, h, 1.5, 1.5, true)
+	checkHasher(t, h, 1.5, 2.5, false)
+	checkHasher(t, h, math.NaN(), math.NaN(), true)
+	checkHasher(t, h, math.NaN(), 0, false)
+	checkHasher(t, h, math.Copysign(0, -1), 0, true)
+}
+
+func TestString(t *testing.T) {
+	type name string
+	h := String[name]()
+	checkHasher(t, h, "foo", "foo", true)
+	checkHasher(t, h, "foo", "bar", false)
+}
+
+func TestBytes(t *testing.T) {
+	h := Bytes()
+	checkHasher(t, h, []byte("foo"), []byte("foo"), true)
+	checkHasher(t, h, []byte("foo"), []byte("bar"), false)
+	checkHasher(t, h, nil, []byte{}, true)
+}
+
+func TestSlice(t *testing.T) {
+	h := Slice(Integer[int]())
+	checkHasher(t, h, []int{1, 2, 3}, []int{1, 2, 3}, true)
+	checkHasher(t, h, []int{1, 2, 3}, []int{3, 2, 1}, false)
+	checkHasher(t, h, []int{1, 2}, []int{1, 2, 3}, false)
+	checkHasher(t, h, nil, []int{}, true)
+	if h.Hash([]int{1, 2}) == h.Hash([]int{2, 1}) {
+		t.Errorf("expected order to affect the hash")
+	}
+}
+
+func TestPair(t *testing.T) {
+	h := Pair(String[string](), Integer[int]())
+	checkHasher(t, h, pair.Of("a", 1), pair.Of("a", 1), true)
+	checkHasher(t, h, pair.Of("a", 1), pair.Of("a", 2), false)
+	checkHasher(t, h, pair.Of("a", 1), pair.Of("b", 1), false)
+}
+
+func TestBy(t *testing.T) {
+	type person struct {
+		Name  string
+		Email string
+	}
+	h := By(func(p person) string { return p.Email }, String[string]())
+	checkHasher(t, h, person{"Jo", "jo@example.com"}, person{"Joanne", "jo@example.com"}, true)
+	checkHasher(t, h, person{"Jo", "jo@example.com"}, person{"Jo", "joe@example.com"}, false)
+}
+
+func TestCombine(t *testing.T) {
+	if Combine(1, 2) == Combine(2, 1) {
+		t.Errorf("expected order to affect the combined hash")
+	}
+	if Combine(1, 2) != Combine(1, 2) {
+		t.Errorf("expected combined hash to be deterministic")
+	}
+}
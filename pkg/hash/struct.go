@@ -0,0 +1,186 @@
+package hash
+
+import (
+	"fmt"
+	"hash/maphash"
+	"math"
+	"reflect"
+)
+
+// Struct returns a Hasher of values of the struct type T (or pointer to struct) that compares and hashes all their exported fields.
+// Unexported fields are ignored.
+//
+// The fields are resolved by reflection once, when the Hasher is built.
+// An error is returned if T is not a struct (or pointer to struct), or if an exported field is of a type that cannot be hashed.
+//
+// Fields of the following types can be hashed:
+//   - Booleans, integers, floats (as with Float) and strings, including named types based on them.
+//   - Pointers to hashable types, compared by the values they point to, with nil equal only to nil.
+//   - Arrays and slices of hashable types, compared element by element; a nil slice is equal to an empty slice.
+//   - Structs whose exported fields are all hashable.
+//
+// Example usage:
+//
+//	type Point struct {
+//	  X, Y int
+//	  Tags []string
+//	}
+//
+//	h, _ := hash.Struct[Point]()
+//	ok := h.Equal(Point{1, 2, []string{"a"}}, Point{1, 2, []string{"a"}}) // true
+func Struct[T any]() (Hasher[T], error) {
+	t := reflect.TypeFor[T]()
+	st := t
+	if st.Kind() == reflect.Pointer {
+		st = st.Elem()
+	}
+	if st.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("hash: Struct requires a struct or pointer to struct type; got %s", t)
+	}
+	vh, err := valueHasherBuilder{}.build(t, "")
+	if err != nil {
+		return nil, fmt.Errorf("hash: Struct[%s]: %w", t, err)
+	}
+	return New(
+		func(e T) uint64 { return vh.hash(reflect.ValueOf(&e).Elem()) },
+		func(a, b T) bool { return vh.equal(reflect.ValueOf(&a).Elem(), reflect.ValueOf(&b).Elem()) },
+	), nil
+}
+
+// MustStruct behaves like Struct, but panics if an error occurs.
+// It is intended for initializing package-level variables.
+func MustStruct[T any]() Hasher[T] {
+	h, err := Struct[T]()
+	if err != nil {
+		panic(err)
+	}
+	return h
+}
+
+// valueHasher hashes and compares reflected values of the same type.
+type valueHasher struct {
+	hash  func(v reflect.Value) uint64
+	equal func(a, b reflect.Value) bool
+}
+
+// valueHasherBuilder builds hashers of types, reusing those of struct types already being built, so that recursive types are supported.
+type valueHasherBuilder map[reflect.Type]*valueHasher
+
+func (hb valueHasherBuilder) build(t reflect.Type, path string) (*valueHasher, error) {
+	switch t.Kind() {
+	case reflect.Bool:
+		return &valueHasher{
+			hash:  func(v reflect.Value) uint64 { return mix(uint64(btoi(v.Bool()))) },
+			equal: func(a, b reflect.Value) bool { return a.Bool() == b.Bool() },
+		}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return &valueHasher{
+			hash:  func(v reflect.Value) uint64 { return mix(uint64(v.Int())) },
+			equal: func(a, b reflect.Value) bool { return a.Int() == b.Int() },
+		}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return &valueHasher{
+			hash:  func(v reflect.Value) uint64 { return mix(v.Uint()) },
+			equal: func(a, b reflect.Value) bool { return a.Uint() == b.Uint() },
+		}, nil
+	case reflect.Float32, reflect.Float64:
+		return &valueHasher{
+			hash:  func(v reflect.Value) uint64 { return hashFloat(v.Float()) },
+			equal: func(a, b reflect.Value) bool { return equalFloat(a.Float(), b.Float()) },
+		}, nil
+	case reflect.String:
+		return &valueHasher{
+			hash:  func(v reflect.Value) uint64 { return maphash.String(seed, v.String()) },
+			equal: func(a, b reflect.Value) bool { return a.String() == b.String() },
+		}, nil
+	case reflect.Pointer:
+		eh, err := hb.build(t.Elem(), path)
+		if err != nil {
+			return nil, err
+		}
+		return &valueHasher{
+			hash: func(v reflect.Value) uint64 {
+				if v.IsNil() {
+					return mix(math.MaxUint64)
+				}
+				return eh.hash(v.Elem())
+			},
+			equal: func(a, b reflect.Value) bool {
+				if a.IsNil() || b.IsNil() {
+					return a.IsNil() == b.IsNil()
+				}
+				return eh.equal(a.Elem(), b.Elem())
+			},
+		}, nil
+	case reflect.Array, reflect.Slice:
+		eh, err := hb.build(t.Elem(), path+"[]")
+		if err != nil {
+			return nil, err
+		}
+		return &valueHasher{
+			hash: func(v reflect.Value) uint64 {
+				x := mix(uint64(v.Len()))
+				for i := 0; i < v.Len(); i++ {
+					x = Combine(x, eh.hash(v.Index(i)))
+				}
+				return x
+			},
+			equal: func(a, b reflect.Value) bool {
+				if a.Len() != b.Len() {
+					return false
+				}
+				for i := 0; i < a.Len(); i++ {
+					if !eh.equal(a.Index(i), b.Index(i)) {
+						return false
+					}
+				}
+				return true
+			},
+		}, nil
+	case reflect.Struct:
+		if vh, ok := hb[t]; ok {
+			// Recursive type; defer to the hasher being built.
+			return &valueHasher{
+				hash:  func(v reflect.Value) uint64 { return vh.hash(v) },
+				equal: func(a, b reflect.Value) bool { return vh.equal(a, b) },
+			}, nil
+		}
+		vh := &valueHasher{}
+		hb[t] = vh
+		var fields []int
+		var fhs []*valueHasher
+		for i := 0; i < t.NumField(); i++ {
+			sf := t.Field(i)
+			if !sf.IsExported() {
+				continue
+			}
+			name := sf.Name
+			if path != "" {
+				name = path + "." + name
+			}
+			fh, err := hb.build(sf.Type, name)
+			if err != nil {
+				return nil, err
+			}
+			fields = append(fields, i)
+			fhs = append(fhs, fh)
+		}
+		vh.hash = func(v reflect.Value) uint64 {
+			var x uint64
+			for j, i := range fields {
+				x = Combine(x, fhs[j].hash(v.Field(i)))
+			}
+			return x
+		}
+		vh.equal = func(a, b reflect.Value) bool {
+			for j, i := range fields {
+				if !fhs[j].equal(a.Field(i), b.Field(i)) {
+					return false
+				}
+			}
+			return true
+		}
+		return vh, nil
+	}
+	return nil, fmt.Errorf("field %q of type %s cannot be hashed", path, t)
+}
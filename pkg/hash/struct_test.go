@@ -0,0 +1,77 @@
+package hash
+
+import (
+	"strings"
+	"testing"
+)
+
+type address struct {
+	City string
+	Zip  *int
+}
+
+type person struct {
+	Name    string
+	Age     int
+	Score   float64
+	Tags    []string
+	Address address
+	Parent  *person
+	ID      [2]uint8
+	secret  string
+}
+
+func TestStruct(t *testing.T) {
+	h, err := Struct[person]()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	zip1, zip2 := 12345, 12345
+	a := person{Name: "Jo", Age: 30, Tags: []string{"x"}, Address: address{"Paris", &zip1}, Parent: &person{Name: "Al"}, ID: [2]uint8{1, 2}, secret: "a"}
+	b := person{Name: "Jo", Age: 30, Tags: []string{"x"}, Address: address{"Paris", &zip2}, Parent: &person{Name: "Al"}, ID: [2]uint8{1, 2}, secret: "b"}
+	checkHasher(t, h, a, b, true) // Unexported fields are ignored; pointers are compared by value.
+
+	c := b
+	c.Tags = []string{"y"}
+	checkHasher(t, h, a, c, false)
+	c = b
+	c.Address.Zip = nil
+	checkHasher(t, h, a, c, false)
+	c = b
+	c.Parent = &person{Name: "Bo"}
+	checkHasher(t, h, a, c, false)
+	c = b
+	c.ID[1] = 3
+	checkHasher(t, h, a, c, false)
+}
+
+func TestStruct_pointer(t *testing.T) {
+	h, err := Struct[*address]()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkHasher(t, h, &address{City: "Paris"}, &address{City: "Paris"}, true)
+	checkHasher(t, h, nil, nil, true)
+	checkHasher(t, h, nil, &address{}, false)
+}
+
+func TestStruct_errors(t *testing.T) {
+	if _, err := Struct[int](); err == nil || !strings.Contains(err.Error(), "requires a struct") {
+		t.Errorf("got %v, want error for non-struct type", err)
+	}
+	type bad struct {
+		M map[string]int
+	}
+	if _, err := Struct[bad](); err == nil || !strings.Contains(err.Error(), `field "M"`) {
+		t.Errorf("got %v, want error for map field", err)
+	}
+}
+
+func TestMustStruct(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected panic")
+		}
+	}()
+	MustStruct[string]()
+}
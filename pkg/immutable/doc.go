@@ -0,0 +1,9 @@
+// Package immutable provides persistent (immutable) collections that share structure between versions.
+// Operations that would modify a collection instead return a new version, leaving the original unchanged; only the parts of the structure along the modified path are copied.
+// As values are never mutated after construction, collections may be freely shared between goroutines without synchronization.
+//
+// The package provides the following collections:
+//   - List: an indexed sequence, implemented as a persistent vector trie.
+//   - Map: an unordered map of comparable keys, implemented as a hash array mapped trie (HAMT).
+//   - SortedMap: a map ordered by a cmp.Comparer, implemented as a persistent AVL tree.
+package immutable
@@ -0,0 +1,113 @@
+package immutable
+
+import (
+	"hash/maphash"
+	"math"
+	"reflect"
+)
+
+// seed is shared by all maps, so that maps may share structure across versions.
+var seed = maphash.MakeSeed()
+
+// hashOf returns a hash of the given comparable value, consistent with the == operator.
+// Values of basic kinds are hashed directly; composite values (eg: structs and arrays) are hashed field by field, or element by element.
+func hashOf[K comparable](k K) uint64 {
+	switch v := any(k).(type) {
+	case string:
+		return maphash.String(seed, v)
+	case int:
+		return hashUint64(uint64(v))
+	case int64:
+		return hashUint64(uint64(v))
+	case uint64:
+		return hashUint64(v)
+	}
+
+	rv := reflect.ValueOf(k)
+	switch rv.Kind() {
+	case reflect.String:
+		return maphash.String(seed, rv.String())
+	case reflect.Struct, reflect.Array, reflect.Complex64, reflect.Complex128:
+		var h maphash.Hash
+		h.SetSeed(seed)
+		writeValue(&h, rv)
+		return h.Sum64()
+	}
+	if u, ok := scalarBits(rv); ok {
+		return hashUint64(u)
+	}
+	return hashUint64(0) // Nil interface.
+}
+
+// writeValue writes the given comparable value to h, such that values that are equal under the == operator write the same bytes.
+func writeValue(h *maphash.Hash, rv reflect.Value) {
+	switch rv.Kind() {
+	case reflect.String:
+		writeUint64(h, uint64(rv.Len()))
+		h.WriteString(rv.String())
+	case reflect.Complex64, reflect.Complex128:
+		c := rv.Complex()
+		writeUint64(h, floatBits(real(c)))
+		writeUint64(h, floatBits(imag(c)))
+	case reflect.Struct:
+		for i := 0; i < rv.NumField(); i++ {
+			writeValue(h, rv.Field(i))
+		}
+	case reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			writeValue(h, rv.Index(i))
+		}
+	case reflect.Interface:
+		if rv.IsNil() {
+			writeUint64(h, 0)
+			return
+		}
+		writeValue(h, rv.Elem())
+	default:
+		u, _ := scalarBits(rv)
+		writeUint64(h, u)
+	}
+}
+
+// scalarBits returns the bits of the given value of a scalar kind, consistent with the == operator.
+func scalarBits(rv reflect.Value) (uint64, bool) {
+	switch rv.Kind() {
+	case reflect.Bool:
+		if rv.Bool() {
+			return 1, true
+		}
+		return 0, true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return uint64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return rv.Uint(), true
+	case reflect.Float32, reflect.Float64:
+		return floatBits(rv.Float()), true
+	case reflect.Pointer, reflect.Chan, reflect.UnsafePointer:
+		return uint64(rv.Pointer()), true
+	}
+	return 0, false
+}
+
+func floatBits(f float64) uint64 {
+	if f == 0 {
+		f = 0 // Normalize -0 to +0, since they are equal.
+	}
+	return math.Float64bits(f)
+}
+
+func writeUint64(h *maphash.Hash, u uint64) {
+	var b [8]byte
+	for i := range b {
+		b[i] = byte(u >> (8 * i))
+	}
+	h.Write(b[:])
+}
+
+func hashUint64(u uint64) uint64 {
+	var b [8]byte
+	for i := range b {
+		b[i] = byte(u >> (8 * i))
+	}
+	return maphash.Bytes(seed, b[:])
+}
@@ -0,0 +1,252 @@
+package immutable
+
+import (
+	"github.com/jpfourny/papaya/v2/pkg/opt"
+	"github.com/jpfourny/papaya/v2/pkg/stream"
+)
+
+const (
+	listBits  = 5
+	listWidth = 1 << listBits
+	listMask  = listWidth - 1
+)
+
+// List represents an immutable, indexed sequence of elements of type E.
+// It is implemented as a persistent vector trie with a branching factor of 32, with the last (partial) leaf kept in a separate tail for fast appends.
+// Get, Set, Append and DropLast take effectively constant time (O(log32 n)).
+// The zero value is an empty List ready to use.
+type List[E any] struct {
+	size  int
+	shift uint
+	root  *listNode[E]
+	tail  []E
+}
+
+type listNode[E any] struct {
+	children []*listNode[E] // Used by branch nodes.
+	elems    []E            // Used by leaf nodes.
+}
+
+// EmptyList returns an empty List.
+func EmptyList[E any]() List[E] {
+	return List[E]{}
+}
+
+// ListOf returns a List containing the given elements, in order.
+//
+// Example usage:
+//
+//	l := immutable.ListOf(1, 2, 3)
+//	out := stream.DebugString(l.Stream()) // "<1, 2, 3>"
+func ListOf[E any](elems ...E) List[E] {
+	return CollectList(stream.FromSlice(elems))
+}
+
+// CollectList returns a List containing all elements from the given stream, in order.
+// The stream is fully consumed.
+//
+// Example usage:
+//
+//	l := immutable.CollectList(stream.Of(1, 2, 3))
+//	out := l.Len() // 3
+func CollectList[E any](s stream.Stream[E]) List[E] {
+	l := EmptyList[E]()
+	stream.ForEach(s, func(e E) {
+		l = l.Append(e)
+	})
+	return l
+}
+
+// Len returns the number of elements in the List.
+func (l List[E]) Len() int {
+	return l.size
+}
+
+// IsEmpty returns true if the List has no elements; false otherwise.
+func (l List[E]) IsEmpty() bool {
+	return l.size == 0
+}
+
+// Get returns the element at the given index; an empty opt.Optional, if the index is out of range.
+func (l List[E]) Get(i int) opt.Optional[E] {
+	if i < 0 || i >= l.size {
+		return opt.Empty[E]()
+	}
+	return opt.Of(l.leafFor(i)[i&listMask])
+}
+
+// Append returns a new List with the given element added to the end.
+//
+// Example usage:
+//
+//	l1 := immutable.ListOf(1, 2)
+//	l2 := l1.Append(3)
+//	out := stream.DebugString(l2.Stream()) // "<1, 2, 3>"
+//	out = stream.DebugString(l1.Stream()) // "<1, 2>" (unchanged)
+func (l List[E]) Append(e E) List[E] {
+	if len(l.tail) < listWidth {
+		// Room in the tail; copy it with the new element.
+		tail := make([]E, len(l.tail), len(l.tail)+1)
+		copy(tail, l.tail)
+		return List[E]{size: l.size + 1, shift: l.shiftOrDefault(), root: l.root, tail: append(tail, e)}
+	}
+
+	// Tail is full; push it into the tree.
+	tailNode := &listNode[E]{elems: l.tail}
+	shift := l.shift
+	var root *listNode[E]
+	if l.root == nil {
+		root = &listNode[E]{children: []*listNode[E]{tailNode}}
+	} else if (l.size >> listBits) > (1 << shift) {
+		// Root is full; grow the tree by one level.
+		root = &listNode[E]{children: []*listNode[E]{l.root, newListPath(shift, tailNode)}}
+		shift += listBits
+	} else {
+		root = l.pushTail(shift, l.root, tailNode)
+	}
+	return List[E]{size: l.size + 1, shift: shift, root: root, tail: []E{e}}
+}
+
+// Set returns a new List with the element at the given index replaced by the given element.
+// Panics if the index is out of range.
+func (l List[E]) Set(i int, e E) List[E] {
+	if i < 0 || i >= l.size {
+		panic("index out of range")
+	}
+	if i >= l.tailOffset() {
+		tail := append([]E(nil), l.tail...)
+		tail[i-l.tailOffset()] = e
+		return List[E]{size: l.size, shift: l.shift, root: l.root, tail: tail}
+	}
+	return List[E]{size: l.size, shift: l.shift, root: setInListNode(l.shift, l.root, i, e), tail: l.tail}
+}
+
+// DropLast returns a new List with the last element removed.
+// If the List is empty, it is returned as-is.
+func (l List[E]) DropLast() List[E] {
+	switch {
+	case l.size == 0:
+		return l
+	case l.size == 1:
+		return EmptyList[E]()
+	case l.size-l.tailOffset() > 1:
+		// More than one element in the tail; drop the last.
+		return List[E]{size: l.size - 1, shift: l.shift, root: l.root, tail: l.tail[: len(l.tail)-1 : len(l.tail)-1]}
+	}
+
+	// Last element is alone in the tail; pull the last leaf out of the tree to become the new tail.
+	tail := l.leafFor(l.size - 2)
+	root := l.popTail(l.shift, l.root)
+	shift := l.shift
+	if root != nil && shift > listBits && len(root.children) == 1 {
+		// Root has a single child; shrink the tree by one level.
+		root = root.children[0]
+		shift -= listBits
+	}
+	return List[E]{size: l.size - 1, shift: shift, root: root, tail: tail}
+}
+
+// Stream returns a stream of the elements of the List, in order.
+func (l List[E]) Stream() stream.Stream[E] {
+	return func(yield stream.Consumer[E]) {
+		for i := 0; i < l.size; i += listWidth {
+			for _, e := range l.leafFor(i) {
+				if !yield(e) {
+					return // Consumer saw enough.
+				}
+			}
+		}
+	}
+}
+
+// Slice returns a new slice containing the elements of the List, in order.
+func (l List[E]) Slice() []E {
+	return stream.CollectSlice(l.Stream())
+}
+
+// tailOffset returns the index of the first element in the tail.
+func (l List[E]) tailOffset() int {
+	if l.size < listWidth {
+		return 0
+	}
+	return ((l.size - 1) >> listBits) << listBits
+}
+
+func (l List[E]) shiftOrDefault() uint {
+	if l.shift == 0 {
+		return listBits
+	}
+	return l.shift
+}
+
+// leafFor returns the leaf elements containing the given index.
+func (l List[E]) leafFor(i int) []E {
+	if i >= l.tailOffset() {
+		return l.tail
+	}
+	n := l.root
+	for level := l.shift; level > 0; level -= listBits {
+		n = n.children[(i>>level)&listMask]
+	}
+	return n.elems
+}
+
+func (l List[E]) pushTail(level uint, parent *listNode[E], tailNode *listNode[E]) *listNode[E] {
+	subidx := ((l.size - 1) >> level) & listMask
+	n := &listNode[E]{children: append([]*listNode[E](nil), parent.children...)}
+	var child *listNode[E]
+	switch {
+	case level == listBits:
+		child = tailNode
+	case subidx < len(parent.children):
+		child = l.pushTail(level-listBits, parent.children[subidx], tailNode)
+	default:
+		child = newListPath(level-listBits, tailNode)
+	}
+	if subidx < len(n.children) {
+		n.children[subidx] = child
+	} else {
+		n.children = append(n.children, child)
+	}
+	return n
+}
+
+func (l List[E]) popTail(level uint, n *listNode[E]) *listNode[E] {
+	subidx := ((l.size - 2) >> level) & listMask
+	if level > listBits {
+		child := l.popTail(level-listBits, n.children[subidx])
+		if child == nil {
+			if subidx == 0 {
+				return nil
+			}
+			return &listNode[E]{children: n.children[:subidx:subidx]}
+		}
+		children := append([]*listNode[E](nil), n.children...)
+		children[subidx] = child
+		return &listNode[E]{children: children}
+	}
+	if subidx == 0 {
+		return nil
+	}
+	return &listNode[E]{children: n.children[:subidx:subidx]}
+}
+
+// newListPath returns a chain of branch nodes of the given height, ending at the given node.
+func newListPath[E any](level uint, n *listNode[E]) *listNode[E] {
+	for ; level > 0; level -= listBits {
+		n = &listNode[E]{children: []*listNode[E]{n}}
+	}
+	return n
+}
+
+func setInListNode[E any](level uint, n *listNode[E], i int, e E) *listNode[E] {
+	if level == 0 {
+		elems := append([]E(nil), n.elems...)
+		elems[i&listMask] = e
+		return &listNode[E]{elems: elems}
+	}
+	children := append([]*listNode[E](nil), n.children...)
+	subidx := (i >> level) & listMask
+	children[subidx] = setInListNode(level-listBits, n.children[subidx], i, e)
+	return &listNode[E]{children: children}
+}
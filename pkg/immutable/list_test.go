@@ -0,0 +1,117 @@
+package immutable
+
+import (
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/jpfourny/papaya/v2/internal/assert"
+	"github.com/jpfourny/papaya/v2/pkg/opt"
+	"github.com/jpfourny/papaya/v2/pkg/stream"
+)
+
+func TestList_Append(t *testing.T) {
+	var l List[int] // Zero value is ready to use.
+	var want []int
+	versions := make([]List[int], 0, 2000)
+	for i := 0; i < 2000; i++ {
+		versions = append(versions, l)
+		l = l.Append(i)
+		want = append(want, i)
+	}
+	if l.Len() != 2000 {
+		t.Fatalf("got Len() %d, want %d", l.Len(), 2000)
+	}
+	assert.ElementsMatch(t, l.Slice(), want)
+	for i := 0; i < 2000; i++ {
+		if got := l.Get(i); got != opt.Of(i) {
+			t.Fatalf("Get(%d): got %v, want %v", i, got, opt.Of(i))
+		}
+	}
+
+	// Earlier versions are unchanged.
+	for n, v := range versions {
+		if v.Len() != n {
+			t.Fatalf("version %d: got Len() %d", n, v.Len())
+		}
+		assert.ElementsMatch(t, v.Slice(), want[:n])
+	}
+}
+
+func TestList_Get(t *testing.T) {
+	l := ListOf(1, 2, 3)
+	if got := l.Get(-1); got.Present() {
+		t.Errorf("got %v, want None", got)
+	}
+	if got := l.Get(3); got.Present() {
+		t.Errorf("got %v, want None", got)
+	}
+	if got := l.Get(1); got != opt.Of(2) {
+		t.Errorf("got %v, want %v", got, opt.Of(2))
+	}
+}
+
+func TestList_Set(t *testing.T) {
+	base := CollectList(stream.Interval(0, 1100, 1))
+	for _, i := range []int{0, 31, 32, 1023, 1024, 1099} {
+		l := base.Set(i, -1)
+		if got := l.Get(i); got != opt.Of(-1) {
+			t.Errorf("Set(%d): got %v, want %v", i, got, opt.Of(-1))
+		}
+		if got := base.Get(i); got != opt.Of(i) {
+			t.Errorf("Set(%d) modified original: got %v, want %v", i, got, opt.Of(i))
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected Set to panic for index out of range")
+		}
+	}()
+	base.Set(1100, 0)
+}
+
+func TestList_DropLast(t *testing.T) {
+	want := stream.CollectSlice(stream.Interval(0, 40000, 1))
+	l := ListOf(want...)
+	for n := len(want); n > 0; n-- {
+		if l.Len() != n {
+			t.Fatalf("got Len() %d, want %d", l.Len(), n)
+		}
+		if got := l.Get(n - 1); got != opt.Of(n-1) {
+			t.Fatalf("Get(%d): got %v, want %v", n-1, got, opt.Of(n-1))
+		}
+		if n%997 == 0 {
+			assert.ElementsMatch(t, l.Slice(), want[:n])
+			// Appending after dropping must reuse the tree correctly.
+			assert.ElementsMatch(t, l.Append(-1).Slice(), append(slices.Clone(want[:n]), -1))
+		}
+		l = l.DropLast()
+	}
+	if !l.IsEmpty() {
+		t.Errorf("expected IsEmpty() to be true")
+	}
+	if l.DropLast().Len() != 0 {
+		t.Errorf("expected DropLast on empty list to return empty list")
+	}
+}
+
+func TestList_Stream(t *testing.T) {
+	l := ListOf(1, 2, 3)
+	assert.ElementsMatch(t, stream.CollectSlice(stream.Limit(l.Stream(), 2)), []int{1, 2})
+}
+
+func TestList_Concurrent(t *testing.T) {
+	base := ListOf(1, 2, 3)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			l := base.Append(i).Set(0, i)
+			assert.ElementsMatch(t, l.Slice(), []int{i, 2, 3, i})
+		}()
+	}
+	wg.Wait()
+	assert.ElementsMatch(t, base.Slice(), []int{1, 2, 3})
+}
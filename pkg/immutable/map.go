@@ -0,0 +1,308 @@
+package immutable
+
+import (
+	"math/bits"
+
+	"github.com/jpfourny/papaya/v2/pkg/opt"
+	"github.com/jpfourny/papaya/v2/pkg/pair"
+	"github.com/jpfourny/papaya/v2/pkg/stream"
+)
+
+const (
+	hamtBits = 5
+	hamtMask = 1<<hamtBits - 1
+)
+
+// Map represents an immutable, unordered map of keys of type K to values of type V.
+// It is implemented as a hash array mapped trie (HAMT), where each node branches on 5 bits of the key hash.
+// Get, With and Without take effectively constant time (O(log32 n)).
+// The key type K must be comparable.
+// The zero value is an empty Map ready to use.
+type Map[K comparable, V any] struct {
+	root *hamtNode[K, V]
+	size int
+}
+
+// hamtNode represents a node in the trie.
+// The bitmap has a bit set for each occupied slot, and the entries hold the occupied slots in order.
+type hamtNode[K comparable, V any] struct {
+	bitmap  uint32
+	entries []hamtEntry[K, V]
+}
+
+// hamtEntry is either a sub-node, or a bucket of the key-value pairs whose keys share the same full hash.
+type hamtEntry[K comparable, V any] struct {
+	node   *hamtNode[K, V]
+	hash   uint64
+	bucket []pair.Pair[K, V]
+}
+
+// EmptyMap returns an empty Map.
+func EmptyMap[K comparable, V any]() Map[K, V] {
+	return Map[K, V]{}
+}
+
+// MapOf returns a Map containing the key-value pairs of the given builtin map.
+func MapOf[K comparable, V any](m map[K]V) Map[K, V] {
+	return CollectMap(stream.FromMap(m))
+}
+
+// CollectMap returns a Map containing all key-value pair elements from the given stream.
+// If a key occurs more than once, the last value wins.
+// The stream is fully consumed.
+//
+// Example usage:
+//
+//	m := immutable.CollectMap(stream.Of(pair.Of("foo", 1), pair.Of("bar", 2)))
+//	out := m.Get("foo") // Some(1)
+func CollectMap[K comparable, V any](s stream.Stream[pair.Pair[K, V]]) Map[K, V] {
+	m := EmptyMap[K, V]()
+	stream.ForEach(s, func(p pair.Pair[K, V]) {
+		m = m.With(p.First(), p.Second())
+	})
+	return m
+}
+
+// Len returns the number of key-value pairs in the Map.
+func (m Map[K, V]) Len() int {
+	return m.size
+}
+
+// IsEmpty returns true if the Map has no key-value pairs; false otherwise.
+func (m Map[K, V]) IsEmpty() bool {
+	return m.size == 0
+}
+
+// Get returns the value associated with the given key; an empty opt.Optional, if the key is not present.
+func (m Map[K, V]) Get(key K) opt.Optional[V] {
+	hash := hashOf(key)
+	n := m.root
+	for shift := uint(0); n != nil; shift += hamtBits {
+		bit, idx := n.slot(hash, shift)
+		if n.bitmap&bit == 0 {
+			break
+		}
+		e := &n.entries[idx]
+		if e.node != nil {
+			n = e.node
+			continue
+		}
+		if e.hash == hash {
+			for _, p := range e.bucket {
+				if p.First() == key {
+					return opt.Of(p.Second())
+				}
+			}
+		}
+		break
+	}
+	return opt.Empty[V]()
+}
+
+// Contains returns true if the given key is present in the Map; false otherwise.
+func (m Map[K, V]) Contains(key K) bool {
+	return m.Get(key).Present()
+}
+
+// With returns a new Map with the given key associated with the given value, replacing any existing value.
+//
+// Example usage:
+//
+//	m1 := immutable.EmptyMap[string, int]().With("foo", 1)
+//	m2 := m1.With("bar", 2)
+//	out := m1.Len() // 1 (unchanged)
+//	out = m2.Len() // 2
+func (m Map[K, V]) With(key K, value V) Map[K, V] {
+	root := m.root
+	if root == nil {
+		root = &hamtNode[K, V]{}
+	}
+	root, added := root.with(hashOf(key), 0, key, value)
+	size := m.size
+	if added {
+		size++
+	}
+	return Map[K, V]{root: root, size: size}
+}
+
+// Without returns a new Map without the given key.
+// If the key is not present, the Map is returned as-is.
+func (m Map[K, V]) Without(key K) Map[K, V] {
+	if m.root == nil {
+		return m
+	}
+	root, removed := m.root.without(hashOf(key), 0, key)
+	if !removed {
+		return m
+	}
+	return Map[K, V]{root: root, size: m.size - 1}
+}
+
+// Stream returns a stream of the key-value pairs in the Map.
+// The order of the pairs is not guaranteed, but is the same on every call for the same Map.
+func (m Map[K, V]) Stream() stream.Stream[pair.Pair[K, V]] {
+	return func(yield stream.Consumer[pair.Pair[K, V]]) {
+		if m.root != nil {
+			m.root.walk(yield)
+		}
+	}
+}
+
+// Keys returns a stream of the keys in the Map.
+// The order of the keys is not guaranteed, but is the same on every call for the same Map.
+func (m Map[K, V]) Keys() stream.Stream[K] {
+	return stream.UnzipFirst(m.Stream())
+}
+
+// Values returns a stream of the values in the Map.
+// The order of the values is not guaranteed, but is the same on every call for the same Map.
+func (m Map[K, V]) Values() stream.Stream[V] {
+	return stream.UnzipSecond(m.Stream())
+}
+
+// ToMap returns a new builtin map containing the key-value pairs of the Map.
+func (m Map[K, V]) ToMap() map[K]V {
+	return stream.CollectMap(m.Stream())
+}
+
+// slot returns the bitmap bit and entry index for the given hash at the given depth.
+func (n *hamtNode[K, V]) slot(hash uint64, shift uint) (uint32, int) {
+	bit := uint32(1) << ((hash >> shift) & hamtMask)
+	return bit, bits.OnesCount32(n.bitmap & (bit - 1))
+}
+
+func (n *hamtNode[K, V]) with(hash uint64, shift uint, key K, value V) (*hamtNode[K, V], bool) {
+	bit, idx := n.slot(hash, shift)
+	if n.bitmap&bit == 0 {
+		// Empty slot; insert a new bucket.
+		c := n.clone()
+		c.bitmap |= bit
+		c.entries = append(c.entries, hamtEntry[K, V]{})
+		copy(c.entries[idx+1:], c.entries[idx:])
+		c.entries[idx] = hamtEntry[K, V]{hash: hash, bucket: []pair.Pair[K, V]{pair.Of(key, value)}}
+		return c, true
+	}
+
+	e := n.entries[idx]
+	var added bool
+	switch {
+	case e.node != nil: // Descend into the sub-node.
+		e.node, added = e.node.with(hash, shift+hamtBits, key, value)
+	case e.hash == hash: // Same full hash; replace or add to the bucket.
+		e.bucket, added = withInBucket(e.bucket, key, value)
+	default: // Different hash in the slot; split into a sub-node.
+		e = hamtEntry[K, V]{node: newHamtSplit(e, hamtEntry[K, V]{hash: hash, bucket: []pair.Pair[K, V]{pair.Of(key, value)}}, shift+hamtBits)}
+		added = true
+	}
+	c := n.clone()
+	c.entries[idx] = e
+	return c, added
+}
+
+func (n *hamtNode[K, V]) without(hash uint64, shift uint, key K) (*hamtNode[K, V], bool) {
+	bit, idx := n.slot(hash, shift)
+	if n.bitmap&bit == 0 {
+		return n, false
+	}
+
+	e := n.entries[idx]
+	switch {
+	case e.node != nil:
+		child, removed := e.node.without(hash, shift+hamtBits, key)
+		if !removed {
+			return n, false
+		}
+		switch {
+		case len(child.entries) == 0:
+			return n.withoutSlot(bit, idx), true
+		case len(child.entries) == 1 && child.entries[0].node == nil:
+			e = child.entries[0] // Collapse a sub-node holding a single bucket.
+		default:
+			e.node = child
+		}
+	case e.hash == hash:
+		bucket, removed := withoutInBucket(e.bucket, key)
+		if !removed {
+			return n, false
+		}
+		if len(bucket) == 0 {
+			return n.withoutSlot(bit, idx), true
+		}
+		e.bucket = bucket
+	default:
+		return n, false
+	}
+	c := n.clone()
+	c.entries[idx] = e
+	return c, true
+}
+
+func (n *hamtNode[K, V]) withoutSlot(bit uint32, idx int) *hamtNode[K, V] {
+	entries := make([]hamtEntry[K, V], 0, len(n.entries)-1)
+	entries = append(entries, n.entries[:idx]...)
+	entries = append(entries, n.entries[idx+1:]...)
+	return &hamtNode[K, V]{bitmap: n.bitmap &^ bit, entries: entries}
+}
+
+func (n *hamtNode[K, V]) clone() *hamtNode[K, V] {
+	return &hamtNode[K, V]{
+		bitmap:  n.bitmap,
+		entries: append(make([]hamtEntry[K, V], 0, len(n.entries)+1), n.entries...),
+	}
+}
+
+func (n *hamtNode[K, V]) walk(yield stream.Consumer[pair.Pair[K, V]]) bool {
+	for _, e := range n.entries {
+		if e.node != nil {
+			if !e.node.walk(yield) {
+				return false
+			}
+			continue
+		}
+		for _, p := range e.bucket {
+			if !yield(p) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// newHamtSplit returns a node holding the two buckets, which have different hashes, at the given depth.
+func newHamtSplit[K comparable, V any](e1, e2 hamtEntry[K, V], shift uint) *hamtNode[K, V] {
+	i1 := (e1.hash >> shift) & hamtMask
+	i2 := (e2.hash >> shift) & hamtMask
+	switch {
+	case i1 == i2: // Same slot at this depth; split further down.
+		return &hamtNode[K, V]{
+			bitmap:  1 << i1,
+			entries: []hamtEntry[K, V]{{node: newHamtSplit(e1, e2, shift+hamtBits)}},
+		}
+	case i1 < i2:
+		return &hamtNode[K, V]{bitmap: 1<<i1 | 1<<i2, entries: []hamtEntry[K, V]{e1, e2}}
+	default:
+		return &hamtNode[K, V]{bitmap: 1<<i1 | 1<<i2, entries: []hamtEntry[K, V]{e2, e1}}
+	}
+}
+
+func withInBucket[K comparable, V any](bucket []pair.Pair[K, V], key K, value V) ([]pair.Pair[K, V], bool) {
+	c := append(make([]pair.Pair[K, V], 0, len(bucket)+1), bucket...)
+	for i, p := range c {
+		if p.First() == key {
+			c[i] = pair.Of(key, value)
+			return c, false
+		}
+	}
+	return append(c, pair.Of(key, value)), true
+}
+
+func withoutInBucket[K comparable, V any](bucket []pair.Pair[K, V], key K) ([]pair.Pair[K, V], bool) {
+	for i, p := range bucket {
+		if p.First() == key {
+			c := make([]pair.Pair[K, V], 0, len(bucket)-1)
+			c = append(c, bucket[:i]...)
+			return append(c, bucket[i+1:]...), true
+		}
+	}
+	return bucket, false
+}
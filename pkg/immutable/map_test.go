@@ -0,0 +1,181 @@
+package immutable
+
+import (
+	"maps"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/jpfourny/papaya/v2/internal/assert"
+	"github.com/jpfourny/papaya/v2/pkg/opt"
+	"github.com/jpfourny/papaya/v2/pkg/pair"
+	"github.com/jpfourny/papaya/v2/pkg/stream"
+)
+
+func TestMap_WithWithout(t *testing.T) {
+	var m0 Map[string, int] // Zero value is ready to use.
+	m1 := m0.With("foo", 1)
+	m2 := m1.With("bar", 2)
+	m3 := m2.With("foo", 3)
+	m4 := m3.Without("bar")
+
+	if m0.Len() != 0 || m1.Len() != 1 || m2.Len() != 2 || m3.Len() != 2 || m4.Len() != 1 {
+		t.Errorf("got lengths %d, %d, %d, %d, %d", m0.Len(), m1.Len(), m2.Len(), m3.Len(), m4.Len())
+	}
+	if got := m1.Get("foo"); got != opt.Of(1) {
+		t.Errorf("got %v, want %v", got, opt.Of(1))
+	}
+	if got := m3.Get("foo"); got != opt.Of(3) {
+		t.Errorf("got %v, want %v", got, opt.Of(3))
+	}
+	if m4.Contains("bar") || !m3.Contains("bar") {
+		t.Errorf("expected Without to only affect the new version")
+	}
+	if m4.Without("missing").Len() != 1 {
+		t.Errorf("expected Without of missing key to return same map")
+	}
+}
+
+func TestMap_Random(t *testing.T) {
+	rnd := rand.New(rand.NewSource(0))
+	m := EmptyMap[int, int]()
+	want := map[int]int{}
+	var snapshot Map[int, int]
+	var snapshotWant map[int]int
+	for i := 0; i < 20000; i++ {
+		k := rnd.Intn(5000)
+		if rnd.Intn(3) == 0 {
+			m = m.Without(k)
+			delete(want, k)
+		} else {
+			m = m.With(k, i)
+			want[k] = i
+		}
+		if i == 10000 {
+			snapshot, snapshotWant = m, maps.Clone(want)
+		}
+	}
+	checkMap(t, m, want)
+	checkMap(t, snapshot, snapshotWant)
+}
+
+func TestMap_Collisions(t *testing.T) {
+	// Struct keys are hashed field by field; ensure buckets and splits behave with many keys.
+	type key struct {
+		a int
+		b string
+	}
+	m := EmptyMap[key, int]()
+	for i := 0; i < 1000; i++ {
+		m = m.With(key{i, "x"}, i)
+	}
+	for i := 0; i < 1000; i += 2 {
+		m = m.Without(key{i, "x"})
+	}
+	if m.Len() != 500 {
+		t.Fatalf("got Len() %d, want %d", m.Len(), 500)
+	}
+	for i := 0; i < 1000; i++ {
+		if got := m.Get(key{i, "x"}); got.Present() != (i%2 == 1) {
+			t.Fatalf("Get(%d): got %v", i, got)
+		}
+	}
+}
+
+func TestMap_CompositeKeys(t *testing.T) {
+	negZero := math.Copysign(0, -1)
+
+	t.Run("struct", func(t *testing.T) {
+		type key struct{ F float64 }
+		m := EmptyMap[key, int]().With(key{0}, 1)
+		if got := m.Get(key{negZero}); got != opt.Of(1) {
+			t.Errorf("got %v, want %v", got, opt.Of(1))
+		}
+	})
+
+	t.Run("nested", func(t *testing.T) {
+		type inner struct {
+			F [2]float32
+			S string
+		}
+		type key struct {
+			I inner
+			A any
+		}
+		m := EmptyMap[key, int]().With(key{inner{[2]float32{0, 1}, "x"}, 0.0}, 1)
+		k := key{inner{[2]float32{float32(negZero), 1}, "x"}, negZero}
+		if got := m.Get(k); got != opt.Of(1) {
+			t.Errorf("got %v, want %v", got, opt.Of(1))
+		}
+	})
+
+	t.Run("complex", func(t *testing.T) {
+		m := EmptyMap[complex128, int]().With(complex(0, 1), 1)
+		if got := m.Get(complex(negZero, 1)); got != opt.Of(1) {
+			t.Errorf("got %v, want %v", got, opt.Of(1))
+		}
+	})
+
+	t.Run("interface", func(t *testing.T) {
+		type key struct{ F float64 }
+		m := EmptyMap[any, int]().With(key{0}, 1).With(nil, 2)
+		if got := m.Get(key{negZero}); got != opt.Of(1) {
+			t.Errorf("got %v, want %v", got, opt.Of(1))
+		}
+		if got := m.Get(nil); got != opt.Of(2) {
+			t.Errorf("got %v, want %v", got, opt.Of(2))
+		}
+	})
+}
+
+func TestMap_WithInBucket(t *testing.T) {
+	// Simulate a full-hash collision, which cannot be produced reliably via hashOf.
+	bucket := []pair.Pair[string, int]{pair.Of("a", 1)}
+	bucket, added := withInBucket(bucket, "b", 2)
+	if !added || len(bucket) != 2 {
+		t.Fatalf("expected key to be added to bucket")
+	}
+	bucket, added = withInBucket(bucket, "a", 3)
+	if added || bucket[0] != pair.Of("a", 3) {
+		t.Fatalf("expected key to be replaced in bucket")
+	}
+	bucket, removed := withoutInBucket(bucket, "a")
+	if !removed || len(bucket) != 1 || bucket[0] != pair.Of("b", 2) {
+		t.Fatalf("expected key to be removed from bucket")
+	}
+	_, removed = withoutInBucket(bucket, "z")
+	if removed {
+		t.Fatalf("expected missing key not to be removed from bucket")
+	}
+}
+
+func TestCollectMap(t *testing.T) {
+	m := CollectMap(stream.Of(pair.Of("foo", 1), pair.Of("bar", 2), pair.Of("foo", 3)))
+	checkMap(t, m, map[string]int{"foo": 3, "bar": 2})
+	checkMap(t, MapOf(map[string]int{"a": 1}), map[string]int{"a": 1})
+}
+
+func TestMap_Stream(t *testing.T) {
+	m := MapOf(map[int]string{1: "a", 2: "b", 3: "c"})
+	assert.ElementsMatchAnyOrder(t, stream.CollectSlice(m.Keys()), []int{1, 2, 3})
+	assert.ElementsMatchAnyOrder(t, stream.CollectSlice(m.Values()), []string{"a", "b", "c"})
+	if n := stream.Count(stream.Limit(m.Stream(), 2)); n != 2 {
+		t.Errorf("got %d elements, want %d", n, 2)
+	}
+}
+
+func checkMap[K comparable, V comparable](t *testing.T, m Map[K, V], want map[K]V) {
+	t.Helper()
+	if m.Len() != len(want) {
+		t.Fatalf("got Len() %d, want %d", m.Len(), len(want))
+	}
+	got := m.ToMap()
+	if len(got) != len(want) {
+		t.Fatalf("got %d streamed pairs, want %d", len(got), len(want))
+	}
+	for k, v := range want {
+		if got := m.Get(k); got != opt.Of(v) {
+			t.Fatalf("Get(%v): got %v, want %v", k, got, opt.Of(v))
+		}
+	}
+}
@@ -0,0 +1,253 @@
+package immutable
+
+import (
+	"github.com/jpfourny/papaya/v2/pkg/cmp"
+	"github.com/jpfourny/papaya/v2/pkg/opt"
+	"github.com/jpfourny/papaya/v2/pkg/pair"
+	"github.com/jpfourny/papaya/v2/pkg/stream"
+)
+
+// SortedMap represents an immutable map of keys of type K to values of type V, ordered by a cmp.Comparer.
+// It is implemented as a persistent AVL tree; modifications copy only the nodes along the path from the root.
+// Get, With and Without take O(log n) time.
+// A SortedMap must be created with EmptySortedMap or CollectSortedMap.
+type SortedMap[K, V any] struct {
+	compare cmp.Comparer[K]
+	root    *sortedNode[K, V]
+	size    int
+}
+
+type sortedNode[K, V any] struct {
+	key    K
+	value  V
+	height int
+	left   *sortedNode[K, V]
+	right  *sortedNode[K, V]
+}
+
+// EmptySortedMap returns an empty SortedMap that orders keys using the given cmp.Comparer.
+func EmptySortedMap[K, V any](compare cmp.Comparer[K]) SortedMap[K, V] {
+	return SortedMap[K, V]{compare: compare}
+}
+
+// CollectSortedMap returns a SortedMap containing all key-value pair elements from the given stream, ordered by the given cmp.Comparer.
+// If a key occurs more than once, the last value wins.
+// The stream is fully consumed.
+//
+// Example usage:
+//
+//	m := immutable.CollectSortedMap(stream.Of(pair.Of("foo", 1), pair.Of("bar", 2)), cmp.Natural[string]())
+//	out := stream.DebugString(m.Keys()) // "<bar, foo>"
+func CollectSortedMap[K, V any](s stream.Stream[pair.Pair[K, V]], compare cmp.Comparer[K]) SortedMap[K, V] {
+	m := EmptySortedMap[K, V](compare)
+	stream.ForEach(s, func(p pair.Pair[K, V]) {
+		m = m.With(p.First(), p.Second())
+	})
+	return m
+}
+
+// Len returns the number of key-value pairs in the SortedMap.
+func (m SortedMap[K, V]) Len() int {
+	return m.size
+}
+
+// IsEmpty returns true if the SortedMap has no key-value pairs; false otherwise.
+func (m SortedMap[K, V]) IsEmpty() bool {
+	return m.size == 0
+}
+
+// Get returns the value associated with the given key; an empty opt.Optional, if the key is not present.
+func (m SortedMap[K, V]) Get(key K) opt.Optional[V] {
+	n := m.root
+	for n != nil {
+		c := m.compare(key, n.key)
+		switch {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			n = n.right
+		default:
+			return opt.Of(n.value)
+		}
+	}
+	return opt.Empty[V]()
+}
+
+// Contains returns true if the given key is present in the SortedMap; false otherwise.
+func (m SortedMap[K, V]) Contains(key K) bool {
+	return m.Get(key).Present()
+}
+
+// With returns a new SortedMap with the given key associated with the given value, replacing any existing value.
+func (m SortedMap[K, V]) With(key K, value V) SortedMap[K, V] {
+	root, added := m.with(m.root, key, value)
+	size := m.size
+	if added {
+		size++
+	}
+	return SortedMap[K, V]{compare: m.compare, root: root, size: size}
+}
+
+// Without returns a new SortedMap without the given key.
+// If the key is not present, the SortedMap is returned as-is.
+func (m SortedMap[K, V]) Without(key K) SortedMap[K, V] {
+	root, removed := m.without(m.root, key)
+	if !removed {
+		return m
+	}
+	return SortedMap[K, V]{compare: m.compare, root: root, size: m.size - 1}
+}
+
+// Min returns the key-value pair with the smallest key; an empty opt.Optional, if the SortedMap is empty.
+func (m SortedMap[K, V]) Min() opt.Optional[pair.Pair[K, V]] {
+	if m.root == nil {
+		return opt.Empty[pair.Pair[K, V]]()
+	}
+	n := m.root
+	for n.left != nil {
+		n = n.left
+	}
+	return opt.Of(pair.Of(n.key, n.value))
+}
+
+// Max returns the key-value pair with the largest key; an empty opt.Optional, if the SortedMap is empty.
+func (m SortedMap[K, V]) Max() opt.Optional[pair.Pair[K, V]] {
+	if m.root == nil {
+		return opt.Empty[pair.Pair[K, V]]()
+	}
+	n := m.root
+	for n.right != nil {
+		n = n.right
+	}
+	return opt.Of(pair.Of(n.key, n.value))
+}
+
+// Stream returns a stream of the key-value pairs in the SortedMap, ordered by key.
+func (m SortedMap[K, V]) Stream() stream.Stream[pair.Pair[K, V]] {
+	return func(yield stream.Consumer[pair.Pair[K, V]]) {
+		walkSorted(m.root, yield)
+	}
+}
+
+// Keys returns a stream of the keys in the SortedMap, in order.
+func (m SortedMap[K, V]) Keys() stream.Stream[K] {
+	return stream.UnzipFirst(m.Stream())
+}
+
+// Values returns a stream of the values in the SortedMap, ordered by key.
+func (m SortedMap[K, V]) Values() stream.Stream[V] {
+	return stream.UnzipSecond(m.Stream())
+}
+
+func (m SortedMap[K, V]) with(n *sortedNode[K, V], key K, value V) (*sortedNode[K, V], bool) {
+	if n == nil {
+		return &sortedNode[K, V]{key: key, value: value, height: 1}, true
+	}
+	c := *n // Copy the node on the path.
+	var added bool
+	switch d := m.compare(key, n.key); {
+	case d < 0:
+		c.left, added = m.with(n.left, key, value)
+	case d > 0:
+		c.right, added = m.with(n.right, key, value)
+	default:
+		c.value = value
+		return &c, false
+	}
+	return rebalanceSorted(&c), added
+}
+
+func (m SortedMap[K, V]) without(n *sortedNode[K, V], key K) (*sortedNode[K, V], bool) {
+	if n == nil {
+		return nil, false
+	}
+	c := *n // Copy the node on the path.
+	var removed bool
+	switch d := m.compare(key, n.key); {
+	case d < 0:
+		c.left, removed = m.without(n.left, key)
+	case d > 0:
+		c.right, removed = m.without(n.right, key)
+	default:
+		if n.left == nil {
+			return n.right, true
+		}
+		if n.right == nil {
+			return n.left, true
+		}
+		// Replace with the in-order successor.
+		succ := n.right
+		for succ.left != nil {
+			succ = succ.left
+		}
+		c.key, c.value = succ.key, succ.value
+		c.right, _ = m.without(n.right, succ.key)
+		removed = true
+	}
+	if !removed {
+		return n, false
+	}
+	return rebalanceSorted(&c), true
+}
+
+func walkSorted[K, V any](n *sortedNode[K, V], yield stream.Consumer[pair.Pair[K, V]]) bool {
+	if n == nil {
+		return true
+	}
+	return walkSorted(n.left, yield) &&
+		yield(pair.Of(n.key, n.value)) &&
+		walkSorted(n.right, yield)
+}
+
+// rebalanceSorted restores the AVL balance of the given (freshly copied) node, copying any rotated children.
+func rebalanceSorted[K, V any](n *sortedNode[K, V]) *sortedNode[K, V] {
+	updateSorted(n)
+	switch bf := sortedBalance(n); {
+	case bf > 1: // Left-heavy.
+		if sortedBalance(n.left) < 0 {
+			n.left = rotateSortedLeft(n.left)
+		}
+		return rotateSortedRight(n)
+	case bf < -1: // Right-heavy.
+		if sortedBalance(n.right) > 0 {
+			n.right = rotateSortedRight(n.right)
+		}
+		return rotateSortedLeft(n)
+	}
+	return n
+}
+
+func rotateSortedLeft[K, V any](n *sortedNode[K, V]) *sortedNode[K, V] {
+	r := *n.right
+	c := *n
+	c.right = r.left
+	updateSorted(&c)
+	r.left = &c
+	updateSorted(&r)
+	return &r
+}
+
+func rotateSortedRight[K, V any](n *sortedNode[K, V]) *sortedNode[K, V] {
+	l := *n.left
+	c := *n
+	c.left = l.right
+	updateSorted(&c)
+	l.right = &c
+	updateSorted(&l)
+	return &l
+}
+
+func updateSorted[K, V any](n *sortedNode[K, V]) {
+	n.height = 1 + max(sortedHeight(n.left), sortedHeight(n.right))
+}
+
+func sortedHeight[K, V any](n *sortedNode[K, V]) int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+func sortedBalance[K, V any](n *sortedNode[K, V]) int {
+	return sortedHeight(n.left) - sortedHeight(n.right)
+}
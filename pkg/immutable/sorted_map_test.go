@@ -0,0 +1,108 @@
+package immutable
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/jpfourny/papaya/v2/internal/assert"
+	"github.com/jpfourny/papaya/v2/pkg/cmp"
+	"github.com/jpfourny/papaya/v2/pkg/opt"
+	"github.com/jpfourny/papaya/v2/pkg/pair"
+	"github.com/jpfourny/papaya/v2/pkg/stream"
+)
+
+func TestSortedMap_WithWithout(t *testing.T) {
+	m0 := EmptySortedMap[string, int](cmp.Natural[string]())
+	m1 := m0.With("foo", 1)
+	m2 := m1.With("bar", 2)
+	m3 := m2.With("foo", 3)
+	m4 := m3.Without("bar")
+
+	if m0.Len() != 0 || m1.Len() != 1 || m2.Len() != 2 || m3.Len() != 2 || m4.Len() != 1 {
+		t.Errorf("got lengths %d, %d, %d, %d, %d", m0.Len(), m1.Len(), m2.Len(), m3.Len(), m4.Len())
+	}
+	if got := m1.Get("foo"); got != opt.Of(1) {
+		t.Errorf("got %v, want %v", got, opt.Of(1))
+	}
+	if got := m3.Get("foo"); got != opt.Of(3) {
+		t.Errorf("got %v, want %v", got, opt.Of(3))
+	}
+	if m4.Contains("bar") || !m3.Contains("bar") {
+		t.Errorf("expected Without to only affect the new version")
+	}
+	assert.ElementsMatch(t, stream.CollectSlice(m3.Keys()), []string{"bar", "foo"})
+}
+
+func TestSortedMap_MinMax(t *testing.T) {
+	m := CollectSortedMap(stream.Of(pair.Of(2, "b"), pair.Of(1, "a"), pair.Of(3, "c")), cmp.Natural[int]())
+	if got := m.Min(); got != opt.Of(pair.Of(1, "a")) {
+		t.Errorf("got %v, want %v", got, opt.Of(pair.Of(1, "a")))
+	}
+	if got := m.Max(); got != opt.Of(pair.Of(3, "c")) {
+		t.Errorf("got %v, want %v", got, opt.Of(pair.Of(3, "c")))
+	}
+	empty := EmptySortedMap[int, string](cmp.Natural[int]())
+	if empty.Min().Present() || empty.Max().Present() {
+		t.Errorf("expected Min and Max of empty map to be None")
+	}
+}
+
+func TestSortedMap_Random(t *testing.T) {
+	rnd := rand.New(rand.NewSource(0))
+	m := EmptySortedMap[int, int](cmp.Natural[int]())
+	want := map[int]int{}
+	for i := 0; i < 20000; i++ {
+		k := rnd.Intn(5000)
+		if rnd.Intn(3) == 0 {
+			m = m.Without(k)
+			delete(want, k)
+		} else {
+			m = m.With(k, i)
+			want[k] = i
+		}
+	}
+	checkSortedNode(t, m.root)
+	if m.Len() != len(want) {
+		t.Fatalf("got Len() %d, want %d", m.Len(), len(want))
+	}
+
+	keys := stream.CollectSlice(stream.FromMapKeys(want))
+	slices.Sort(keys)
+	assert.ElementsMatch(t, stream.CollectSlice(m.Keys()), keys)
+	for k, v := range want {
+		if got := m.Get(k); got != opt.Of(v) {
+			t.Fatalf("Get(%d): got %v, want %v", k, got, opt.Of(v))
+		}
+	}
+}
+
+func TestSortedMap_Persistence(t *testing.T) {
+	m := CollectSortedMap(stream.Map(stream.Interval(0, 100, 1), func(i int) pair.Pair[int, int] { return pair.Of(i, i) }), cmp.Natural[int]())
+	versions := []SortedMap[int, int]{m}
+	for i := 0; i < 100; i += 3 {
+		m = m.Without(i).With(i+1000, i)
+		versions = append(versions, m)
+	}
+	// The first version is unaffected by all subsequent modifications.
+	assert.ElementsMatch(t, stream.CollectSlice(versions[0].Keys()), stream.CollectSlice(stream.Interval(0, 100, 1)))
+	for _, v := range versions {
+		checkSortedNode(t, v.root)
+	}
+}
+
+// checkSortedNode verifies the AVL balance and height of every node in the subtree, returning its height.
+func checkSortedNode[K, V any](t *testing.T, n *sortedNode[K, V]) int {
+	t.Helper()
+	if n == nil {
+		return 0
+	}
+	l, r := checkSortedNode(t, n.left), checkSortedNode(t, n.right)
+	if l-r < -1 || l-r > 1 {
+		t.Fatalf("node %v is unbalanced: %d", n.key, l-r)
+	}
+	if n.height != 1+max(l, r) {
+		t.Fatalf("node %v has wrong height: %d", n.key, n.height)
+	}
+	return n.height
+}
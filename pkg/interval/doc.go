@@ -0,0 +1,6 @@
+// Package interval provides a generic closed interval type, an interval tree supporting efficient range-overlap queries, and stream operators for working with intervals.
+// Interval bounds may be of any type, ordered by a cmp.Comparer.
+//
+// The interval tree is named Tree, rather than IntervalTree, since it is always referred to with the package name (interval.Tree).
+// MergeOverlapping is provided by this package, rather than by package stream as MergeOverlappingIntervals, because this package depends on package stream for the results of Tree queries; the reverse dependency would be an import cycle.
+package interval
@@ -0,0 +1,66 @@
+package interval
+
+import (
+	"fmt"
+
+	"github.com/jpfourny/papaya/v2/pkg/cmp"
+)
+
+// Interval represents a closed interval [lo, hi] of values of type K.
+// An interval is valid if lo is less than or equal to hi, according to the cmp.Comparer used with it.
+type Interval[K any] struct {
+	lo K
+	hi K
+}
+
+// Of creates a new Interval with the provided lower and upper bounds (inclusive).
+func Of[K any](lo, hi K) Interval[K] {
+	return Interval[K]{lo: lo, hi: hi}
+}
+
+// Point creates a new Interval that contains only the provided value.
+func Point[K any](p K) Interval[K] {
+	return Interval[K]{lo: p, hi: p}
+}
+
+// Lo returns the lower bound (inclusive) of the Interval.
+func (i Interval[K]) Lo() K {
+	return i.lo
+}
+
+// Hi returns the upper bound (inclusive) of the Interval.
+func (i Interval[K]) Hi() K {
+	return i.hi
+}
+
+// Explode returns the lower and upper bounds together as a tuple.
+func (i Interval[K]) Explode() (K, K) {
+	return i.lo, i.hi
+}
+
+// String returns a string representation of the Interval, formatted as "[%#v, %#v]".
+func (i Interval[K]) String() string {
+	return fmt.Sprintf("[%#v, %#v]", i.lo, i.hi)
+}
+
+// Valid returns true if the lower bound of the Interval is less than or equal to the upper bound, using the provided cmp.Comparer.
+func Valid[K any](i Interval[K], compare cmp.Comparer[K]) bool {
+	return compare.LessThanOrEqual(i.lo, i.hi)
+}
+
+// Overlaps returns true if the two intervals have at least one value in common, using the provided cmp.Comparer.
+// Intervals that share only an endpoint are considered overlapping.
+func Overlaps[K any](a, b Interval[K], compare cmp.Comparer[K]) bool {
+	return compare.LessThanOrEqual(a.lo, b.hi) && compare.LessThanOrEqual(b.lo, a.hi)
+}
+
+// Contains returns true if the given value lies within the Interval (inclusive), using the provided cmp.Comparer.
+func Contains[K any](i Interval[K], p K, compare cmp.Comparer[K]) bool {
+	return compare.LessThanOrEqual(i.lo, p) && compare.LessThanOrEqual(p, i.hi)
+}
+
+// Comparer returns a cmp.Comparer that orders intervals by lower bound, then by upper bound, using the provided cmp.Comparer for the bounds.
+func Comparer[K any](compare cmp.Comparer[K]) cmp.Comparer[Interval[K]] {
+	return cmp.ComparingBy(Interval[K].Lo, compare).
+		Then(cmp.ComparingBy(Interval[K].Hi, compare))
+}
@@ -0,0 +1,84 @@
+package interval
+
+import (
+	"testing"
+
+	"github.com/jpfourny/papaya/v2/pkg/cmp"
+)
+
+func TestOf(t *testing.T) {
+	iv := Of(1, 5)
+	if iv.Lo() != 1 || iv.Hi() != 5 {
+		t.Errorf("got %v, want [1, 5]", iv)
+	}
+	lo, hi := iv.Explode()
+	if lo != 1 || hi != 5 {
+		t.Errorf("got (%d, %d), want (1, 5)", lo, hi)
+	}
+	if iv.String() != "[1, 5]" {
+		t.Errorf("got %q, want %q", iv.String(), "[1, 5]")
+	}
+}
+
+func TestPoint(t *testing.T) {
+	iv := Point(3)
+	if iv != Of(3, 3) {
+		t.Errorf("got %v, want [3, 3]", iv)
+	}
+}
+
+func TestValid(t *testing.T) {
+	compare := cmp.Natural[int]()
+	if !Valid(Of(1, 5), compare) {
+		t.Errorf("expected [1, 5] to be valid")
+	}
+	if !Valid(Of(5, 5), compare) {
+		t.Errorf("expected [5, 5] to be valid")
+	}
+	if Valid(Of(5, 1), compare) {
+		t.Errorf("expected [5, 1] to be invalid")
+	}
+}
+
+func TestOverlaps(t *testing.T) {
+	compare := cmp.Natural[int]()
+	tests := []struct {
+		a, b Interval[int]
+		want bool
+	}{
+		{Of(1, 5), Of(3, 8), true},
+		{Of(3, 8), Of(1, 5), true},
+		{Of(1, 5), Of(5, 8), true}, // Shared endpoint.
+		{Of(1, 5), Of(2, 3), true}, // Containment.
+		{Of(1, 5), Of(6, 8), false},
+		{Of(6, 8), Of(1, 5), false},
+	}
+	for _, tt := range tests {
+		if got := Overlaps(tt.a, tt.b, compare); got != tt.want {
+			t.Errorf("Overlaps(%v, %v): got %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestContains(t *testing.T) {
+	compare := cmp.Natural[int]()
+	if !Contains(Of(1, 5), 1, compare) || !Contains(Of(1, 5), 5, compare) || !Contains(Of(1, 5), 3, compare) {
+		t.Errorf("expected [1, 5] to contain 1, 3 and 5")
+	}
+	if Contains(Of(1, 5), 0, compare) || Contains(Of(1, 5), 6, compare) {
+		t.Errorf("expected [1, 5] not to contain 0 or 6")
+	}
+}
+
+func TestComparer(t *testing.T) {
+	compare := Comparer(cmp.Natural[int]())
+	if compare(Of(1, 5), Of(2, 3)) >= 0 {
+		t.Errorf("expected [1, 5] < [2, 3]")
+	}
+	if compare(Of(1, 3), Of(1, 5)) >= 0 {
+		t.Errorf("expected [1, 3] < [1, 5]")
+	}
+	if compare(Of(1, 5), Of(1, 5)) != 0 {
+		t.Errorf("expected [1, 5] == [1, 5]")
+	}
+}
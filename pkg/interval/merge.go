@@ -0,0 +1,51 @@
+package interval
+
+import (
+	"github.com/jpfourny/papaya/v2/pkg/cmp"
+	"github.com/jpfourny/papaya/v2/pkg/stream"
+)
+
+// MergeOverlapping returns a stream of the union of the intervals in the given stream, where overlapping intervals are merged into one.
+// Intervals that share only an endpoint are considered overlapping, and are merged.
+// The resulting intervals are disjoint, and ordered by lower bound.
+// The given stream is fully consumed and sorted before the first element is produced.
+//
+// Example usage:
+//
+//	s := interval.MergeOverlapping(
+//	  stream.Of(
+//	    interval.Of(8, 10),
+//	    interval.Of(1, 3),
+//	    interval.Of(2, 6),
+//	    interval.Of(10, 12),
+//	    interval.Of(15, 18),
+//	  ),
+//	  cmp.Natural[int](),
+//	)
+//	out := stream.DebugString(s) // "<[1, 6], [8, 12], [15, 18]>"
+func MergeOverlapping[K any](s stream.Stream[Interval[K]], compare cmp.Comparer[K]) stream.Stream[Interval[K]] {
+	return func(yield stream.Consumer[Interval[K]]) {
+		var cur Interval[K]
+		started := false
+		stopped := false
+		stream.SortBy(s, Comparer(compare))(func(iv Interval[K]) bool {
+			switch {
+			case !started:
+				cur = iv
+				started = true
+			case compare.LessThanOrEqual(iv.lo, cur.hi): // Overlaps the current interval; extend it.
+				cur.hi = compare.Max(cur.hi, iv.hi)
+			default: // Disjoint; emit the current interval and start a new one.
+				if !yield(cur) {
+					stopped = true
+					return false // Consumer saw enough.
+				}
+				cur = iv
+			}
+			return true
+		})
+		if started && !stopped {
+			yield(cur)
+		}
+	}
+}
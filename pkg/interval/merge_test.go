@@ -0,0 +1,43 @@
+package interval
+
+import (
+	"testing"
+
+	"github.com/jpfourny/papaya/v2/internal/assert"
+	"github.com/jpfourny/papaya/v2/pkg/cmp"
+	"github.com/jpfourny/papaya/v2/pkg/stream"
+)
+
+func TestMergeOverlapping(t *testing.T) {
+	compare := cmp.Natural[int]()
+
+	t.Run("empty", func(t *testing.T) {
+		got := stream.CollectSlice(MergeOverlapping(stream.Empty[Interval[int]](), compare))
+		assert.ElementsMatch(t, got, nil)
+	})
+
+	t.Run("single", func(t *testing.T) {
+		got := stream.CollectSlice(MergeOverlapping(stream.Of(Of(1, 3)), compare))
+		assert.ElementsMatch(t, got, []Interval[int]{Of(1, 3)})
+	})
+
+	t.Run("non-empty", func(t *testing.T) {
+		s := MergeOverlapping(
+			stream.Of(Of(8, 10), Of(1, 3), Of(2, 6), Of(10, 12), Of(15, 18), Of(16, 17)),
+			compare,
+		)
+		got := stream.CollectSlice(s)
+		want := []Interval[int]{Of(1, 6), Of(8, 12), Of(15, 18)}
+		assert.ElementsMatch(t, got, want)
+	})
+
+	t.Run("limited", func(t *testing.T) {
+		s := MergeOverlapping(
+			stream.Of(Of(8, 10), Of(1, 3), Of(2, 6), Of(15, 18)),
+			compare,
+		)
+		got := stream.CollectSlice(stream.Limit(s, 2))
+		want := []Interval[int]{Of(1, 6), Of(8, 10)}
+		assert.ElementsMatch(t, got, want)
+	})
+}
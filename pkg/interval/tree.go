@@ -0,0 +1,271 @@
+package interval
+
+import (
+	"github.com/jpfourny/papaya/v2/pkg/cmp"
+	"github.com/jpfourny/papaya/v2/pkg/opt"
+	"github.com/jpfourny/papaya/v2/pkg/pair"
+	"github.com/jpfourny/papaya/v2/pkg/stream"
+)
+
+// Tree represents an interval tree mapping intervals with bounds of type K to values of type V.
+// Each distinct interval holds at most one value.
+//
+// The tree is a self-balancing (AVL) binary search tree ordered by lower bound, then by upper bound.
+// Each node is augmented with the maximum upper bound in its subtree, so that overlap queries only visit subtrees that can contain matches.
+// Insertion, deletion and lookup take O(log n) time; overlap queries take O(log n + m) time, where m is the number of matches.
+//
+// A Tree is not safe for concurrent use; concurrent reads are safe only if there are no concurrent writes.
+type Tree[K, V any] struct {
+	compare   cmp.Comparer[K]
+	ivCompare cmp.Comparer[Interval[K]]
+	root      *node[K, V]
+	size      int
+}
+
+type node[K, V any] struct {
+	iv     Interval[K]
+	value  V
+	max    K // Maximum upper bound in the subtree rooted at this node.
+	height int
+	left   *node[K, V]
+	right  *node[K, V]
+}
+
+// New returns a new, empty Tree that orders interval bounds using the given cmp.Comparer.
+//
+// Example usage:
+//
+//	t := interval.New[int, string](cmp.Natural[int]())
+//	t.Put(interval.Of(1, 5), "a")
+//	t.Put(interval.Of(4, 8), "b")
+//	t.Put(interval.Of(10, 12), "c")
+//	out := stream.DebugString(t.Overlapping(5, 10)) // "<([1, 5], a), ([4, 8], b), ([10, 12], c)>"
+//	out = stream.DebugString(t.Containing(6)) // "<([4, 8], b)>"
+func New[K, V any](compare cmp.Comparer[K]) *Tree[K, V] {
+	return &Tree[K, V]{
+		compare:   compare,
+		ivCompare: Comparer(compare),
+	}
+}
+
+// Len returns the number of intervals in the Tree.
+func (t *Tree[K, V]) Len() int {
+	return t.size
+}
+
+// Put associates the given value with the given interval, replacing any existing value for the same interval.
+// Returns true if the interval was newly added; false if an existing value was replaced.
+// Panics if the interval is not valid (ie: lower bound is greater than upper bound).
+func (t *Tree[K, V]) Put(iv Interval[K], value V) (added bool) {
+	if !Valid(iv, t.compare) {
+		panic("interval lower bound must not be greater than upper bound")
+	}
+	t.root, added = t.put(t.root, iv, value)
+	if added {
+		t.size++
+	}
+	return
+}
+
+// Get returns the value associated with exactly the given interval; an empty opt.Optional, if the interval is not present.
+func (t *Tree[K, V]) Get(iv Interval[K]) opt.Optional[V] {
+	n := t.root
+	for n != nil {
+		c := t.ivCompare(iv, n.iv)
+		switch {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			n = n.right
+		default:
+			return opt.Of(n.value)
+		}
+	}
+	return opt.Empty[V]()
+}
+
+// Delete removes exactly the given interval from the Tree.
+// Returns true if the interval was present; false otherwise.
+func (t *Tree[K, V]) Delete(iv Interval[K]) (deleted bool) {
+	t.root, deleted = t.delete(t.root, iv)
+	if deleted {
+		t.size--
+	}
+	return
+}
+
+// Overlapping returns a stream of the intervals in the Tree that overlap the closed interval [lo, hi], along with their values.
+// The pairs are ordered by interval lower bound, then upper bound.
+//
+// Example usage:
+//
+//	t := interval.New[int, string](cmp.Natural[int]())
+//	t.Put(interval.Of(1, 5), "a")
+//	t.Put(interval.Of(7, 9), "b")
+//	out := stream.DebugString(t.Overlapping(5, 6)) // "<([1, 5], a)>"
+func (t *Tree[K, V]) Overlapping(lo, hi K) stream.Stream[pair.Pair[Interval[K], V]] {
+	return func(yield stream.Consumer[pair.Pair[Interval[K], V]]) {
+		t.overlapping(t.root, Of(lo, hi), yield)
+	}
+}
+
+// Containing returns a stream of the intervals in the Tree that contain the given point, along with their values.
+// The pairs are ordered by interval lower bound, then upper bound.
+//
+// Example usage:
+//
+//	t := interval.New[int, string](cmp.Natural[int]())
+//	t.Put(interval.Of(1, 5), "a")
+//	t.Put(interval.Of(4, 8), "b")
+//	out := stream.DebugString(t.Containing(4)) // "<([1, 5], a), ([4, 8], b)>"
+func (t *Tree[K, V]) Containing(p K) stream.Stream[pair.Pair[Interval[K], V]] {
+	return t.Overlapping(p, p)
+}
+
+// Stream returns a stream of all intervals in the Tree, along with their values.
+// The pairs are ordered by interval lower bound, then upper bound.
+func (t *Tree[K, V]) Stream() stream.Stream[pair.Pair[Interval[K], V]] {
+	return func(yield stream.Consumer[pair.Pair[Interval[K], V]]) {
+		walk(t.root, yield)
+	}
+}
+
+func (t *Tree[K, V]) put(n *node[K, V], iv Interval[K], value V) (*node[K, V], bool) {
+	if n == nil {
+		return &node[K, V]{iv: iv, value: value, max: iv.hi, height: 1}, true
+	}
+	var added bool
+	c := t.ivCompare(iv, n.iv)
+	switch {
+	case c < 0:
+		n.left, added = t.put(n.left, iv, value)
+	case c > 0:
+		n.right, added = t.put(n.right, iv, value)
+	default:
+		n.value = value
+		return n, false
+	}
+	return t.rebalance(n), added
+}
+
+func (t *Tree[K, V]) delete(n *node[K, V], iv Interval[K]) (*node[K, V], bool) {
+	if n == nil {
+		return nil, false
+	}
+	var deleted bool
+	c := t.ivCompare(iv, n.iv)
+	switch {
+	case c < 0:
+		n.left, deleted = t.delete(n.left, iv)
+	case c > 0:
+		n.right, deleted = t.delete(n.right, iv)
+	default:
+		if n.left == nil {
+			return n.right, true
+		}
+		if n.right == nil {
+			return n.left, true
+		}
+		// Replace with the in-order successor.
+		succ := n.right
+		for succ.left != nil {
+			succ = succ.left
+		}
+		n.iv, n.value = succ.iv, succ.value
+		n.right, _ = t.delete(n.right, succ.iv)
+		deleted = true
+	}
+	if !deleted {
+		return n, false
+	}
+	return t.rebalance(n), true
+}
+
+func (t *Tree[K, V]) overlapping(n *node[K, V], q Interval[K], yield stream.Consumer[pair.Pair[Interval[K], V]]) bool {
+	if n == nil {
+		return true
+	}
+	// The left subtree can only contain overlaps if some interval in it ends at or after the query start.
+	if n.left != nil && t.compare.GreaterThanOrEqual(n.left.max, q.lo) {
+		if !t.overlapping(n.left, q, yield) {
+			return false
+		}
+	}
+	if Overlaps(n.iv, q, t.compare) {
+		if !yield(pair.Of(n.iv, n.value)) {
+			return false
+		}
+	}
+	// The right subtree can only contain overlaps if this interval starts at or before the query end, since intervals to the right start no earlier.
+	if n.right != nil && t.compare.LessThanOrEqual(n.iv.lo, q.hi) && t.compare.GreaterThanOrEqual(n.right.max, q.lo) {
+		return t.overlapping(n.right, q, yield)
+	}
+	return true
+}
+
+func walk[K, V any](n *node[K, V], yield stream.Consumer[pair.Pair[Interval[K], V]]) bool {
+	if n == nil {
+		return true
+	}
+	return walk(n.left, yield) &&
+		yield(pair.Of(n.iv, n.value)) &&
+		walk(n.right, yield)
+}
+
+func (t *Tree[K, V]) rebalance(n *node[K, V]) *node[K, V] {
+	t.update(n)
+	switch bf := balance(n); {
+	case bf > 1: // Left-heavy.
+		if balance(n.left) < 0 {
+			n.left = t.rotateLeft(n.left)
+		}
+		return t.rotateRight(n)
+	case bf < -1: // Right-heavy.
+		if balance(n.right) > 0 {
+			n.right = t.rotateRight(n.right)
+		}
+		return t.rotateLeft(n)
+	}
+	return n
+}
+
+func (t *Tree[K, V]) rotateLeft(n *node[K, V]) *node[K, V] {
+	r := n.right
+	n.right = r.left
+	r.left = n
+	t.update(n)
+	t.update(r)
+	return r
+}
+
+func (t *Tree[K, V]) rotateRight(n *node[K, V]) *node[K, V] {
+	l := n.left
+	n.left = l.right
+	l.right = n
+	t.update(n)
+	t.update(l)
+	return l
+}
+
+// update recomputes the height and maximum upper bound of the node from its children.
+func (t *Tree[K, V]) update(n *node[K, V]) {
+	n.height = 1 + max(height(n.left), height(n.right))
+	n.max = n.iv.hi
+	if n.left != nil {
+		n.max = t.compare.Max(n.max, n.left.max)
+	}
+	if n.right != nil {
+		n.max = t.compare.Max(n.max, n.right.max)
+	}
+}
+
+func height[K, V any](n *node[K, V]) int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+func balance[K, V any](n *node[K, V]) int {
+	return height(n.left) - height(n.right)
+}
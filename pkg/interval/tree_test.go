@@ -0,0 +1,175 @@
+package interval
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/jpfourny/papaya/v2/internal/assert"
+	"github.com/jpfourny/papaya/v2/pkg/cmp"
+	"github.com/jpfourny/papaya/v2/pkg/opt"
+	"github.com/jpfourny/papaya/v2/pkg/pair"
+	"github.com/jpfourny/papaya/v2/pkg/stream"
+)
+
+func newTestTree() *Tree[int, string] {
+	t := New[int, string](cmp.Natural[int]())
+	t.Put(Of(15, 20), "a")
+	t.Put(Of(10, 30), "b")
+	t.Put(Of(17, 19), "c")
+	t.Put(Of(5, 20), "d")
+	t.Put(Of(12, 15), "e")
+	t.Put(Of(30, 40), "f")
+	return t
+}
+
+func TestTree_PutGet(t *testing.T) {
+	tr := newTestTree()
+	if tr.Len() != 6 {
+		t.Errorf("got Len() %d, want %d", tr.Len(), 6)
+	}
+	if got := tr.Get(Of(17, 19)); got != opt.Of("c") {
+		t.Errorf("got %v, want %v", got, opt.Of("c"))
+	}
+	if got := tr.Get(Of(17, 20)); got.Present() {
+		t.Errorf("got %v, want %v", got, opt.Empty[string]())
+	}
+	if tr.Put(Of(17, 19), "cc") {
+		t.Errorf("expected Put to return false for existing interval")
+	}
+	if got := tr.Get(Of(17, 19)); got != opt.Of("cc") {
+		t.Errorf("got %v, want %v", got, opt.Of("cc"))
+	}
+	if tr.Len() != 6 {
+		t.Errorf("got Len() %d, want %d", tr.Len(), 6)
+	}
+}
+
+func TestTree_PutInvalid(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected Put to panic for invalid interval")
+		}
+	}()
+	New[int, string](cmp.Natural[int]()).Put(Of(2, 1), "x")
+}
+
+func TestTree_Delete(t *testing.T) {
+	tr := newTestTree()
+	if tr.Delete(Of(10, 31)) {
+		t.Errorf("expected Delete to return false for missing interval")
+	}
+	if !tr.Delete(Of(10, 30)) {
+		t.Errorf("expected Delete to return true for existing interval")
+	}
+	if tr.Len() != 5 {
+		t.Errorf("got Len() %d, want %d", tr.Len(), 5)
+	}
+	got := stream.CollectSlice(stream.UnzipSecond(tr.Containing(25)))
+	assert.ElementsMatch(t, got, nil) // Only [10, 30] contained 25.
+}
+
+func TestTree_Overlapping(t *testing.T) {
+	tr := newTestTree()
+
+	got := stream.CollectSlice(tr.Overlapping(20, 25))
+	want := []pair.Pair[Interval[int], string]{
+		pair.Of(Of(5, 20), "d"),
+		pair.Of(Of(10, 30), "b"),
+		pair.Of(Of(15, 20), "a"),
+	}
+	assert.ElementsMatch(t, got, want)
+
+	got = stream.CollectSlice(tr.Overlapping(41, 50))
+	assert.ElementsMatch(t, got, nil)
+
+	got = stream.CollectSlice(stream.Limit(tr.Overlapping(0, 100), 2))
+	want = []pair.Pair[Interval[int], string]{
+		pair.Of(Of(5, 20), "d"),
+		pair.Of(Of(10, 30), "b"),
+	}
+	assert.ElementsMatch(t, got, want)
+}
+
+func TestTree_Containing(t *testing.T) {
+	tr := newTestTree()
+	got := stream.CollectSlice(stream.UnzipSecond(tr.Containing(15)))
+	want := []string{"d", "b", "e", "a"}
+	assert.ElementsMatch(t, got, want)
+}
+
+func TestTree_Stream(t *testing.T) {
+	tr := newTestTree()
+	got := stream.CollectSlice(stream.UnzipFirst(tr.Stream()))
+	want := []Interval[int]{Of(5, 20), Of(10, 30), Of(12, 15), Of(15, 20), Of(17, 19), Of(30, 40)}
+	assert.ElementsMatch(t, got, want)
+}
+
+func TestTree_Random(t *testing.T) {
+	compare := cmp.Natural[int]()
+	rnd := rand.New(rand.NewSource(0))
+	tr := New[int, int](compare)
+	var all []Interval[int]
+
+	randomInterval := func() Interval[int] {
+		lo := rnd.Intn(1000)
+		return Of(lo, lo+rnd.Intn(50))
+	}
+
+	for i := 0; i < 2000; i++ {
+		iv := randomInterval()
+		if rnd.Intn(4) == 0 && len(all) > 0 {
+			// Delete an existing interval.
+			j := rnd.Intn(len(all))
+			if !tr.Delete(all[j]) {
+				t.Fatalf("expected Delete(%v) to return true", all[j])
+			}
+			all = slices.Delete(all, j, j+1)
+		} else if tr.Put(iv, i) {
+			all = append(all, iv)
+		}
+		checkInvariants(t, tr, tr.root)
+	}
+	if tr.Len() != len(all) {
+		t.Fatalf("got Len() %d, want %d", tr.Len(), len(all))
+	}
+
+	slices.SortFunc(all, Comparer(compare))
+	for i := 0; i < 200; i++ {
+		q := randomInterval()
+		got := stream.CollectSlice(stream.UnzipFirst(tr.Overlapping(q.Lo(), q.Hi())))
+		var want []Interval[int]
+		for _, iv := range all {
+			if Overlaps(iv, q, compare) {
+				want = append(want, iv)
+			}
+		}
+		assert.ElementsMatch(t, got, want)
+	}
+}
+
+// checkInvariants verifies the AVL balance, height and max-bound augmentation of every node in the subtree.
+func checkInvariants[K, V any](t *testing.T, tr *Tree[K, V], n *node[K, V]) {
+	t.Helper()
+	if n == nil {
+		return
+	}
+	checkInvariants(t, tr, n.left)
+	checkInvariants(t, tr, n.right)
+	if bf := balance(n); bf < -1 || bf > 1 {
+		t.Fatalf("node %v is unbalanced: %d", n.iv, bf)
+	}
+	if n.height != 1+max(height(n.left), height(n.right)) {
+		t.Fatalf("node %v has wrong height: %d", n.iv, n.height)
+	}
+	want := n.iv.Hi()
+	if n.left != nil {
+		want = tr.compare.Max(want, n.left.max)
+	}
+	if n.right != nil {
+		want = tr.compare.Max(want, n.right.max)
+	}
+	if tr.compare(n.max, want) != 0 {
+		t.Fatalf("node %v has wrong max: %v, want %v", n.iv, n.max, want)
+	}
+}
@@ -0,0 +1,144 @@
+package opt
+
+import "github.com/jpfourny/papaya/v2/pkg/pair"
+
+// Zip returns an Optional containing a pair of the values contained in the provided Optionals.
+// If either Optional is empty, an empty Optional is returned.
+//
+// Example usage:
+//
+//	o := opt.Zip(opt.Of(1), opt.Of("a")) // opt.Some((1, "a"))
+//	o = opt.Zip(opt.Of(1), opt.Empty[string]()) // opt.None()
+func Zip[A, B any](a Optional[A], b Optional[B]) Optional[pair.Pair[A, B]] {
+	return ZipWith(a, b, pair.Of[A, B])
+}
+
+// ZipWith returns an Optional containing the result of applying the provided combiner function to the values contained in the provided Optionals.
+// If either Optional is empty, an empty Optional is returned, and the combiner is not called.
+//
+// Example usage:
+//
+//	o := opt.ZipWith(
+//	  opt.Of(1),
+//	  opt.Of(2),
+//	  func(a, b int) int { return a + b },
+//	) // opt.Some(3)
+func ZipWith[A, B, C any](a Optional[A], b Optional[B], combiner func(A, B) C) Optional[C] {
+	if va, ok := a.Get(); ok {
+		if vb, ok := b.Get(); ok {
+			return Of(combiner(va, vb))
+		}
+	}
+	return Empty[C]()
+}
+
+// OrElse returns the provided Optional if it is non-empty; otherwise, the alternative Optional.
+//
+// Example usage:
+//
+//	o := opt.OrElse(opt.Empty[int](), opt.Of(2)) // opt.Some(2)
+//	o = opt.OrElse(opt.Of(1), opt.Of(2)) // opt.Some(1)
+func OrElse[V any](o Optional[V], alt Optional[V]) Optional[V] {
+	if o.Present() {
+		return o
+	}
+	return alt
+}
+
+// OrElseGet returns the provided Optional if it is non-empty; otherwise, the Optional returned by the provided function.
+// The function is called only if the provided Optional is empty.
+func OrElseGet[V any](o Optional[V], f func() Optional[V]) Optional[V] {
+	if o.Present() {
+		return o
+	}
+	return f()
+}
+
+// Flatten returns the inner Optional contained in the provided Optional.
+// If the outer Optional is empty, an empty Optional is returned.
+//
+// Example usage:
+//
+//	o := opt.Flatten(opt.Of(opt.Of(1))) // opt.Some(1)
+//	o = opt.Flatten(opt.Of(opt.Empty[int]())) // opt.None()
+//	o = opt.Flatten(opt.Empty[opt.Optional[int]]()) // opt.None()
+func Flatten[V any](o Optional[Optional[V]]) Optional[V] {
+	if inner, ok := o.Get(); ok && inner != nil {
+		return inner
+	}
+	return Empty[V]()
+}
+
+// Equal returns true if the provided Optionals are both empty, or are both non-empty with values that are equal according to the provided function; false otherwise.
+// A cmp.Comparer's Equal method may be passed as the function.
+//
+// Example usage:
+//
+//	eq := func(a, b int) bool { return a == b }
+//	ok := opt.Equal(opt.Of(1), opt.Of(1), eq) // true
+//	ok = opt.Equal(opt.Empty[int](), opt.Empty[int](), eq) // true
+//	ok = opt.Equal(opt.Of(1), opt.Empty[int](), eq) // false
+func Equal[V any](a, b Optional[V], eq func(V, V) bool) bool {
+	va, oka := a.Get()
+	vb, okb := b.Get()
+	if oka != okb {
+		return false
+	}
+	return !oka || eq(va, vb)
+}
+
+// FromPtr returns an Optional containing the value pointed to by the provided pointer, or an empty Optional if the pointer is nil.
+//
+// Example usage:
+//
+//	i := 1
+//	o := opt.FromPtr(&i) // opt.Some(1)
+//	o = opt.FromPtr[int](nil) // opt.None()
+func FromPtr[V any](p *V) Optional[V] {
+	if p == nil {
+		return Empty[V]()
+	}
+	return Of(*p)
+}
+
+// ToPtr returns a pointer to a copy of the value contained in the provided Optional, or nil if the Optional is empty.
+//
+// Example usage:
+//
+//	p := opt.ToPtr(opt.Of(1)) // *p == 1
+//	p = opt.ToPtr(opt.Empty[int]()) // nil
+func ToPtr[V any](o Optional[V]) *V {
+	if v, ok := o.Get(); ok {
+		return &v
+	}
+	return nil
+}
+
+// ToSlice returns a slice containing the value contained in the provided Optional, or an empty slice if the Optional is empty.
+//
+// Example usage:
+//
+//	s := opt.ToSlice(opt.Of(1)) // []int{1}
+//	s = opt.ToSlice(opt.Empty[int]()) // []int{}
+func ToSlice[V any](o Optional[V]) []V {
+	if v, ok := o.Get(); ok {
+		return []V{v}
+	}
+	return []V{}
+}
+
+// Fold returns the result of applying the ifPresent function to the value contained in the provided Optional, or the result of calling the ifEmpty function if the Optional is empty.
+//
+// Example usage:
+//
+//	s := opt.Fold(
+//	  opt.Of(1),
+//	  func(i int) string { return fmt.Sprintf("got %d", i) },
+//	  func() string { return "nothing" },
+//	) // "got 1"
+func Fold[V, U any](o Optional[V], ifPresent func(V) U, ifEmpty func() U) U {
+	if v, ok := o.Get(); ok {
+		return ifPresent(v)
+	}
+	return ifEmpty()
+}
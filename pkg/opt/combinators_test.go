@@ -0,0 +1,141 @@
+package opt
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/jpfourny/papaya/v2/pkg/pair"
+)
+
+func TestZip(t *testing.T) {
+	t.Run("Some", func(t *testing.T) {
+		o := Zip(Of(1), Of("a"))
+		got, ok := o.Get()
+		if !ok {
+			t.Fatalf("expected Present() to be true")
+		}
+		if want := pair.Of(1, "a"); got != want {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("None", func(t *testing.T) {
+		if Zip(Of(1), Empty[string]()).Present() {
+			t.Errorf("expected Present() to be false")
+		}
+		if Zip(Empty[int](), Of("a")).Present() {
+			t.Errorf("expected Present() to be false")
+		}
+	})
+}
+
+func TestZipWith(t *testing.T) {
+	add := func(a, b int) int { return a + b }
+	if got := ZipWith(Of(1), Of(2), add).GetOrZero(); got != 3 {
+		t.Errorf("got %v, want %v", got, 3)
+	}
+
+	called := false
+	o := ZipWith(Of(1), Empty[int](), func(a, b int) int { called = true; return a + b })
+	if o.Present() || called {
+		t.Errorf("expected empty Optional without calling combiner")
+	}
+}
+
+func TestOrElse(t *testing.T) {
+	if got := OrElse(Of(1), Of(2)).GetOrZero(); got != 1 {
+		t.Errorf("got %v, want %v", got, 1)
+	}
+	if got := OrElse(Empty[int](), Of(2)).GetOrZero(); got != 2 {
+		t.Errorf("got %v, want %v", got, 2)
+	}
+	if OrElse(Empty[int](), Empty[int]()).Present() {
+		t.Errorf("expected Present() to be false")
+	}
+}
+
+func TestOrElseGet(t *testing.T) {
+	called := false
+	alt := func() Optional[int] { called = true; return Of(2) }
+	if got := OrElseGet(Of(1), alt).GetOrZero(); got != 1 || called {
+		t.Errorf("got %v (called=%v), want %v (called=false)", got, called, 1)
+	}
+	if got := OrElseGet(Empty[int](), alt).GetOrZero(); got != 2 || !called {
+		t.Errorf("got %v (called=%v), want %v (called=true)", got, called, 2)
+	}
+}
+
+func TestFlatten(t *testing.T) {
+	if got := Flatten(Of(Of(1))).GetOrZero(); got != 1 {
+		t.Errorf("got %v, want %v", got, 1)
+	}
+	if Flatten(Of(Empty[int]())).Present() {
+		t.Errorf("expected Present() to be false")
+	}
+	if Flatten(Empty[Optional[int]]()).Present() {
+		t.Errorf("expected Present() to be false")
+	}
+	if Flatten[int](Of[Optional[int]](nil)).Present() {
+		t.Errorf("expected Present() to be false")
+	}
+}
+
+func TestEqual(t *testing.T) {
+	eq := func(a, b int) bool { return a == b }
+	tests := []struct {
+		a, b Optional[int]
+		want bool
+	}{
+		{Of(1), Of(1), true},
+		{Of(1), Of(2), false},
+		{Of(1), Empty[int](), false},
+		{Empty[int](), Of(1), false},
+		{Empty[int](), Empty[int](), true},
+		{ValueOf(1), Of(1), true},
+	}
+	for _, tt := range tests {
+		if got := Equal(tt.a, tt.b, eq); got != tt.want {
+			t.Errorf("Equal(%v, %v): got %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestFromPtr(t *testing.T) {
+	i := 1
+	if got := FromPtr(&i).GetOrZero(); got != 1 {
+		t.Errorf("got %v, want %v", got, 1)
+	}
+	if FromPtr[int](nil).Present() {
+		t.Errorf("expected Present() to be false")
+	}
+}
+
+func TestToPtr(t *testing.T) {
+	p := ToPtr(Of(1))
+	if p == nil || *p != 1 {
+		t.Errorf("got %v, want pointer to %v", p, 1)
+	}
+	if p := ToPtr(Empty[int]()); p != nil {
+		t.Errorf("got %v, want nil", p)
+	}
+}
+
+func TestToSlice(t *testing.T) {
+	if got := ToSlice(Of(1)); len(got) != 1 || got[0] != 1 {
+		t.Errorf("got %v, want %v", got, []int{1})
+	}
+	if got := ToSlice(Empty[int]()); got == nil || len(got) != 0 {
+		t.Errorf("got %#v, want %#v", got, []int{})
+	}
+}
+
+func TestFold(t *testing.T) {
+	ifPresent := func(i int) string { return fmt.Sprintf("got %d", i) }
+	ifEmpty := func() string { return "nothing" }
+	if got := Fold(Of(1), ifPresent, ifEmpty); got != "got 1" {
+		t.Errorf("got %v, want %v", got, "got 1")
+	}
+	if got := Fold(Empty[int](), ifPresent, ifEmpty); got != "nothing" {
+		t.Errorf("got %v, want %v", got, "nothing")
+	}
+}
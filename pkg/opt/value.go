@@ -0,0 +1,295 @@
+package opt
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// Value is a concrete, encodable Optional, for use in struct fields such as API DTOs and database models.
+// Unlike Some and None, a Value can be decoded into, since it is a single type holding either state.
+// The zero value is empty (None).
+//
+// Encoding support:
+//   - JSON: an empty Value is encoded as null, and null is decoded as an empty Value.
+//     The `omitempty` option has no effect on a Value, since it is a struct, so an empty Value field is encoded as null rather than omitted.
+//     To omit it, use a *Value field with `omitempty`, left nil when empty; from Go 1.24, the `omitzero` option also omits empty Values, since Value implements IsZero.
+//   - Text: an empty Value is encoded as empty text, and empty text is decoded as an empty Value.
+//     The value is encoded with its encoding.TextMarshaler if it has one, or else formatted as a string, bool or number.
+//   - SQL: an empty Value is stored as NULL (driver.Valuer), and NULL is scanned as an empty Value (sql.Scanner).
+//
+// Example usage:
+//
+//	type User struct {
+//		Name  string             `json:"name"`
+//		Email opt.Value[string]  `json:"email"`
+//		Phone *opt.Value[string] `json:"phone,omitempty"`
+//	}
+//
+//	b, _ := json.Marshal(User{Name: "bob"}) // {"name":"bob","email":null}
+//	b, _ = json.Marshal(User{Name: "bob", Email: opt.ValueOf("bob@example.com")}) // {"name":"bob","email":"bob@example.com"}
+type Value[V any] struct {
+	value V
+	ok    bool
+}
+
+// Assert that Value[V] implements Optional[V] and the encoding interfaces.
+var (
+	_ Optional[any]            = Value[any]{}
+	_ json.Marshaler           = Value[any]{}
+	_ json.Unmarshaler         = (*Value[any])(nil)
+	_ encoding.TextMarshaler   = Value[any]{}
+	_ encoding.TextUnmarshaler = (*Value[any])(nil)
+	_ driver.Valuer            = Value[any]{}
+	_ sql.Scanner              = (*Value[any])(nil)
+)
+
+// ValueOf returns a non-empty Value wrapping the provided value.
+func ValueOf[V any](value V) Value[V] {
+	return Value[V]{value: value, ok: true}
+}
+
+// EmptyValue returns an empty Value.
+func EmptyValue[V any]() Value[V] {
+	return Value[V]{}
+}
+
+// ToValue returns a Value with the same contents as the provided Optional.
+func ToValue[V any](o Optional[V]) Value[V] {
+	if v, ok := o.(Value[V]); ok {
+		return v
+	}
+	value, ok := o.Get()
+	return Value[V]{value: value, ok: ok}
+}
+
+// Optional returns the Value as Some or None.
+func (v Value[V]) Optional() Optional[V] {
+	return Maybe(v.value, v.ok)
+}
+
+func (v Value[V]) Present() bool {
+	return v.ok
+}
+
+// IsZero returns true if the Value is empty; false otherwise.
+// From Go 1.24, this allows the `omitzero` JSON option to omit empty Values.
+func (v Value[V]) IsZero() bool {
+	return !v.ok
+}
+
+func (v Value[V]) Get() (V, bool) {
+	return v.value, v.ok
+}
+
+func (v Value[V]) GetOrZero() V {
+	return v.value
+}
+
+func (v Value[V]) GetOrDefault(defaultValue V) V {
+	if v.ok {
+		return v.value
+	}
+	return defaultValue
+}
+
+func (v Value[V]) GetOrFunc(f func() V) V {
+	if v.ok {
+		return v.value
+	}
+	return f()
+}
+
+func (v Value[V]) IfPresent(f func(V)) bool {
+	if v.ok {
+		f(v.value)
+	}
+	return v.ok
+}
+
+func (v Value[V]) IfPresentElse(f func(V), g func()) bool {
+	if v.ok {
+		f(v.value)
+	} else {
+		g()
+	}
+	return v.ok
+}
+
+func (v Value[V]) Filter(f func(V) bool) Optional[V] {
+	if v.ok && f(v.value) {
+		return v
+	}
+	return EmptyValue[V]()
+}
+
+func (v Value[V]) Tap(f func(V)) Optional[V] {
+	v.IfPresent(f)
+	return v
+}
+
+func (v Value[V]) String() string {
+	return v.Optional().String()
+}
+
+// MarshalJSON encodes the value as JSON, or null if the Value is empty.
+func (v Value[V]) MarshalJSON() ([]byte, error) {
+	if !v.ok {
+		return []byte("null"), nil
+	}
+	return json.Marshal(v.value)
+}
+
+// UnmarshalJSON decodes the given JSON into the value, or sets the Value to empty if the JSON is null.
+func (v *Value[V]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		*v = Value[V]{}
+		return nil
+	}
+	var value V
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	*v = ValueOf(value)
+	return nil
+}
+
+// MarshalText encodes the value as text, or empty text if the Value is empty.
+func (v Value[V]) MarshalText() ([]byte, error) {
+	if !v.ok {
+		return []byte{}, nil
+	}
+	if m, ok := any(v.value).(encoding.TextMarshaler); ok {
+		return m.MarshalText()
+	}
+	rv := reflect.ValueOf(v.value)
+	switch rv.Kind() {
+	case reflect.String:
+		return []byte(rv.String()), nil
+	case reflect.Bool:
+		return strconv.AppendBool(nil, rv.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.AppendInt(nil, rv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.AppendUint(nil, rv.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.AppendFloat(nil, rv.Float(), 'g', -1, rv.Type().Bits()), nil
+	}
+	return nil, fmt.Errorf("opt: cannot marshal %T as text", v.value)
+}
+
+// UnmarshalText decodes the given text into the value, or sets the Value to empty if the text is empty.
+func (v *Value[V]) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*v = Value[V]{}
+		return nil
+	}
+	var value V
+	if err := unmarshalText(text, reflect.ValueOf(&value).Elem()); err != nil {
+		return err
+	}
+	*v = ValueOf(value)
+	return nil
+}
+
+// Value returns the value for storage in a database, or nil (NULL) if the Value is empty.
+// If the value implements driver.Valuer, it is used; otherwise, the value is converted with driver.DefaultParameterConverter.
+func (v Value[V]) Value() (driver.Value, error) {
+	if !v.ok {
+		return nil, nil
+	}
+	if valuer, ok := any(v.value).(driver.Valuer); ok {
+		return valuer.Value()
+	}
+	return driver.DefaultParameterConverter.ConvertValue(v.value)
+}
+
+// Scan reads a value from a database, setting the Value to empty if it is nil (NULL).
+// If a pointer to the value type implements sql.Scanner, it is used; otherwise, the source is converted to the value type.
+func (v *Value[V]) Scan(src any) error {
+	if src == nil {
+		*v = Value[V]{}
+		return nil
+	}
+	var value V
+	if scanner, ok := any(&value).(sql.Scanner); ok {
+		if err := scanner.Scan(src); err != nil {
+			return err
+		}
+		*v = ValueOf(value)
+		return nil
+	}
+
+	dst := reflect.ValueOf(&value).Elem()
+	switch s := src.(type) {
+	case []byte:
+		if dst.Kind() == reflect.Slice && dst.Type().Elem().Kind() == reflect.Uint8 {
+			dst.SetBytes(bytes.Clone(s))
+		} else if err := unmarshalText(s, dst); err != nil {
+			return err
+		}
+	case string:
+		if err := unmarshalText([]byte(s), dst); err != nil {
+			return err
+		}
+	default:
+		sv := reflect.ValueOf(src)
+		switch {
+		case sv.Type().AssignableTo(dst.Type()):
+			dst.Set(sv)
+		case isNumeric(sv.Kind()) && isNumeric(dst.Kind()):
+			dst.Set(sv.Convert(dst.Type()))
+		default:
+			return fmt.Errorf("opt: cannot scan %T into %T", src, value)
+		}
+	}
+	*v = ValueOf(value)
+	return nil
+}
+
+// unmarshalText decodes the given text into the given addressable value.
+func unmarshalText(text []byte, dst reflect.Value) error {
+	if u, ok := dst.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText(text)
+	}
+	s := string(text)
+	switch dst.Kind() {
+	case reflect.String:
+		dst.SetString(s)
+		return nil
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err == nil {
+			dst.SetBool(b)
+		}
+		return err
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(s, 10, dst.Type().Bits())
+		if err == nil {
+			dst.SetInt(i)
+		}
+		return err
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u, err := strconv.ParseUint(s, 10, dst.Type().Bits())
+		if err == nil {
+			dst.SetUint(u)
+		}
+		return err
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, dst.Type().Bits())
+		if err == nil {
+			dst.SetFloat(f)
+		}
+		return err
+	}
+	return fmt.Errorf("opt: cannot unmarshal text into %s", dst.Type())
+}
+
+func isNumeric(k reflect.Kind) bool {
+	return (k >= reflect.Int && k <= reflect.Uintptr) || k == reflect.Float32 || k == reflect.Float64
+}
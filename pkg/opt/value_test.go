@@ -0,0 +1,256 @@
+package opt
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"net/netip"
+	"testing"
+	"time"
+)
+
+func TestValue(t *testing.T) {
+	t.Run("Some", func(t *testing.T) {
+		v := ValueOf(42)
+		if !v.Present() || v.IsZero() {
+			t.Errorf("expected Present() to be true")
+		}
+		if got, ok := v.Get(); got != 42 || !ok {
+			t.Errorf("got %v, %t, want 42, true", got, ok)
+		}
+		if v.GetOrDefault(1) != 42 || v.GetOrFunc(func() int { return 1 }) != 42 {
+			t.Errorf("expected GetOrDefault and GetOrFunc to return 42")
+		}
+		if v.Filter(func(i int) bool { return i > 100 }).Present() {
+			t.Errorf("expected Filter to return empty")
+		}
+		if v.Optional() != Of(42) {
+			t.Errorf("got %v, want %v", v.Optional(), Of(42))
+		}
+		if v.String() != "Some(42)" {
+			t.Errorf("got %q, want %q", v.String(), "Some(42)")
+		}
+		called := false
+		v.Tap(func(int) { called = true })
+		if !called {
+			t.Errorf("expected Tap to call function")
+		}
+	})
+
+	t.Run("None", func(t *testing.T) {
+		var v Value[int] // Zero value is empty.
+		if v.Present() || !v.IsZero() {
+			t.Errorf("expected Present() to be false")
+		}
+		if v.GetOrDefault(1) != 1 || v.GetOrFunc(func() int { return 2 }) != 2 {
+			t.Errorf("expected GetOrDefault and GetOrFunc to return the alternative")
+		}
+		if v.IfPresentElse(func(int) {}, func() {}) {
+			t.Errorf("expected IfPresentElse to return false")
+		}
+		if v.Optional() != Empty[int]() {
+			t.Errorf("got %v, want %v", v.Optional(), Empty[int]())
+		}
+		if v.String() != "None" {
+			t.Errorf("got %q, want %q", v.String(), "None")
+		}
+	})
+
+	t.Run("ToValue", func(t *testing.T) {
+		if ToValue(Of(1)) != ValueOf(1) || ToValue(Empty[int]()) != EmptyValue[int]() || ToValue[int](ValueOf(2)) != ValueOf(2) {
+			t.Errorf("expected ToValue to preserve contents")
+		}
+	})
+}
+
+func TestValue_JSON(t *testing.T) {
+	type dto struct {
+		Name Value[string]     `json:"name"`
+		Age  Value[int]        `json:"age"`
+		Tags Value[[]string]   `json:"tags"`
+		Addr Value[netip.Addr] `json:"addr"`
+	}
+
+	in := dto{Name: ValueOf("bob"), Tags: ValueOf([]string{"a"}), Addr: ValueOf(netip.MustParseAddr("::1"))}
+	b, err := json.Marshal(in)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `{"name":"bob","age":null,"tags":["a"],"addr":"::1"}`
+	if string(b) != want {
+		t.Errorf("got %s, want %s", b, want)
+	}
+
+	var out dto
+	out.Age = ValueOf(1) // Overwritten by null.
+	if err = json.Unmarshal(b, &out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.Name != in.Name || out.Age.Present() || out.Tags.GetOrZero()[0] != "a" || out.Addr != in.Addr {
+		t.Errorf("got %+v, want %+v", out, in)
+	}
+
+	if err = json.Unmarshal([]byte(`{"age":"x"}`), &out); err == nil {
+		t.Errorf("expected error for wrong type")
+	}
+}
+
+func TestValue_JSONOmitEmpty(t *testing.T) {
+	type dto struct {
+		Name  Value[string]  `json:"name,omitempty"`  // No effect on a struct: an empty Value is encoded as null.
+		Email *Value[string] `json:"email,omitempty"` // Omitted when nil.
+	}
+
+	b, err := json.Marshal(dto{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := `{"name":null}`; string(b) != want {
+		t.Errorf("got %s, want %s", b, want)
+	}
+
+	email := ValueOf("bob@example.com")
+	b, err = json.Marshal(dto{Name: ValueOf("bob"), Email: &email})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := `{"name":"bob","email":"bob@example.com"}`; string(b) != want {
+		t.Errorf("got %s, want %s", b, want)
+	}
+
+	var out dto
+	if err = json.Unmarshal([]byte(`{"name":null}`), &out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.Name.Present() || out.Email != nil {
+		t.Errorf("got %+v, want empty", out)
+	}
+}
+
+func TestValue_Text(t *testing.T) {
+	tests := []struct {
+		name string
+		v    interface {
+			MarshalText() ([]byte, error)
+		}
+		want string
+	}{
+		{"string", ValueOf("foo"), "foo"},
+		{"bool", ValueOf(true), "true"},
+		{"int", ValueOf(-1), "-1"},
+		{"uint", ValueOf[uint8](255), "255"},
+		{"float", ValueOf(1.5), "1.5"},
+		{"marshaler", ValueOf(netip.MustParseAddr("10.0.0.1")), "10.0.0.1"},
+		{"empty", EmptyValue[int](), ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := tt.v.MarshalText()
+			if err != nil || string(b) != tt.want {
+				t.Errorf("got %q, %v, want %q", b, err, tt.want)
+			}
+		})
+	}
+
+	var i Value[int]
+	if err := i.UnmarshalText([]byte("42")); err != nil || i != ValueOf(42) {
+		t.Errorf("got %v, %v, want Some(42)", i, err)
+	}
+	if err := i.UnmarshalText(nil); err != nil || i.Present() {
+		t.Errorf("got %v, %v, want None", i, err)
+	}
+	if err := i.UnmarshalText([]byte("x")); err == nil {
+		t.Errorf("expected error")
+	}
+	var a Value[netip.Addr]
+	if err := a.UnmarshalText([]byte("::1")); err != nil || a != ValueOf(netip.IPv6Loopback()) {
+		t.Errorf("got %v, %v, want Some(::1)", a, err)
+	}
+	if _, err := ValueOf(struct{}{}).MarshalText(); err == nil {
+		t.Errorf("expected error for unsupported type")
+	}
+
+	// Round-trip as a map key.
+	m := map[Value[int]]string{ValueOf(1): "one"}
+	b, err := json.Marshal(m)
+	if err != nil || string(b) != `{"1":"one"}` {
+		t.Errorf("got %s, %v", b, err)
+	}
+}
+
+type celsius float64
+
+func (c *celsius) Scan(src any) error {
+	f, ok := src.(float64)
+	if !ok {
+		return errors.New("not a float")
+	}
+	*c = celsius(f)
+	return nil
+}
+
+func TestValue_SQL(t *testing.T) {
+	t.Run("Value", func(t *testing.T) {
+		tests := []struct {
+			name string
+			v    driver.Valuer
+			want driver.Value
+		}{
+			{"null", EmptyValue[string](), nil},
+			{"string", ValueOf("foo"), "foo"},
+			{"int", ValueOf[int32](1), int64(1)},
+			{"valuer", ValueOf(ValueOf("nested")), "nested"},
+		}
+		for _, tt := range tests {
+			got, err := tt.v.Value()
+			if err != nil || got != tt.want {
+				t.Errorf("%s: got %#v, %v, want %#v", tt.name, got, err, tt.want)
+			}
+		}
+	})
+
+	t.Run("Scan", func(t *testing.T) {
+		var s Value[string]
+		if err := s.Scan([]byte("foo")); err != nil || s != ValueOf("foo") {
+			t.Errorf("got %v, %v", s, err)
+		}
+		if err := s.Scan(nil); err != nil || s.Present() {
+			t.Errorf("got %v, %v", s, err)
+		}
+
+		var i Value[int]
+		if err := i.Scan(int64(42)); err != nil || i != ValueOf(42) {
+			t.Errorf("got %v, %v", i, err)
+		}
+		if err := i.Scan("7"); err != nil || i != ValueOf(7) {
+			t.Errorf("got %v, %v", i, err)
+		}
+		if err := i.Scan(true); err == nil {
+			t.Errorf("expected error")
+		}
+
+		var ts Value[time.Time]
+		now := time.Now()
+		if err := ts.Scan(now); err != nil || !ts.GetOrZero().Equal(now) {
+			t.Errorf("got %v, %v", ts, err)
+		}
+
+		var b Value[[]byte]
+		src := []byte{1, 2}
+		if err := b.Scan(src); err != nil || len(b.GetOrZero()) != 2 {
+			t.Errorf("got %v, %v", b, err)
+		}
+		src[0] = 9
+		if b.GetOrZero()[0] != 1 {
+			t.Errorf("expected Scan to copy bytes")
+		}
+
+		var c Value[celsius]
+		if err := c.Scan(21.5); err != nil || c != ValueOf[celsius](21.5) {
+			t.Errorf("got %v, %v", c, err)
+		}
+		if err := c.Scan("x"); err == nil {
+			t.Errorf("expected error from scanner")
+		}
+	})
+}
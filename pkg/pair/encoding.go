@@ -0,0 +1,118 @@
+package pair
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+)
+
+// Assert that Pair and Object implement the encoding interfaces.
+var (
+	_ json.Marshaler           = Pair[any, any]{}
+	_ json.Unmarshaler         = (*Pair[any, any])(nil)
+	_ encoding.TextMarshaler   = Pair[any, any]{}
+	_ encoding.TextUnmarshaler = (*Pair[any, any])(nil)
+	_ json.Marshaler           = Object[any, any]{}
+	_ json.Unmarshaler         = (*Object[any, any])(nil)
+)
+
+// MarshalJSON encodes the Pair as a JSON array of two elements, [first, second].
+// Use Object to encode it as a JSON object instead.
+//
+// Example usage:
+//
+//	b, _ := json.Marshal(pair.Of("a", 1)) // ["a",1]
+func (p Pair[A, B]) MarshalJSON() ([]byte, error) {
+	return json.Marshal([2]any{p.first, p.second})
+}
+
+// UnmarshalJSON decodes the Pair from a JSON array of two elements, [first, second], or from a JSON object with "first" and "second" fields.
+// As with the json package, JSON null leaves the Pair unchanged.
+func (p *Pair[A, B]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		return nil
+	}
+	first, second, err := unmarshalJSON[A, B](data)
+	if err != nil {
+		return err
+	}
+	*p = Of(first, second)
+	return nil
+}
+
+// MarshalText encodes the Pair as text, in the same form as MarshalJSON.
+// This allows a Pair to be used as a key of a map encoded as JSON.
+//
+// Example usage:
+//
+//	b, _ := json.Marshal(map[pair.Pair[string, int]]bool{pair.Of("a", 1): true}) // {"[\"a\",1]":true}
+func (p Pair[A, B]) MarshalText() ([]byte, error) {
+	return p.MarshalJSON()
+}
+
+// UnmarshalText decodes the Pair from text, in any of the forms accepted by UnmarshalJSON.
+func (p *Pair[A, B]) UnmarshalText(text []byte) error {
+	return p.UnmarshalJSON(text)
+}
+
+// Object wraps a Pair so that it is encoded as a JSON object, {"first": first, "second": second}, rather than an array.
+//
+// Example usage:
+//
+//	b, _ := json.Marshal(pair.AsObject(pair.Of("a", 1))) // {"first":"a","second":1}
+type Object[A, B any] struct {
+	Pair[A, B]
+}
+
+// AsObject returns the provided Pair wrapped in an Object.
+func AsObject[A, B any](p Pair[A, B]) Object[A, B] {
+	return Object[A, B]{Pair: p}
+}
+
+// jsonObject is the JSON object representation of a Pair.
+type jsonObject[A, B any] struct {
+	First  A `json:"first"`
+	Second B `json:"second"`
+}
+
+// MarshalJSON encodes the Pair as a JSON object, {"first": first, "second": second}.
+func (o Object[A, B]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonObject[A, B]{First: o.first, Second: o.second})
+}
+
+// UnmarshalJSON decodes the Pair from a JSON object with "first" and "second" fields, or from a JSON array of two elements, [first, second].
+func (o *Object[A, B]) UnmarshalJSON(data []byte) error {
+	return o.Pair.UnmarshalJSON(data)
+}
+
+// MarshalText encodes the Pair as text, in the same form as MarshalJSON.
+func (o Object[A, B]) MarshalText() ([]byte, error) {
+	return o.MarshalJSON()
+}
+
+// unmarshalJSON decodes the elements of a pair from a JSON array or object.
+func unmarshalJSON[A, B any](data []byte) (first A, second B, err error) {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '{' {
+		var obj jsonObject[A, B]
+		if err = json.Unmarshal(data, &obj); err != nil {
+			return
+		}
+		return obj.First, obj.Second, nil
+	}
+
+	var elems []json.RawMessage
+	if err = json.Unmarshal(data, &elems); err != nil {
+		return
+	}
+	if len(elems) != 2 {
+		err = fmt.Errorf("pair: cannot unmarshal JSON array of %d element(s); want 2", len(elems))
+		return
+	}
+	if err = json.Unmarshal(elems[0], &first); err != nil {
+		return
+	}
+	err = json.Unmarshal(elems[1], &second)
+	return
+}
@@ -0,0 +1,120 @@
+package pair
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestPair_MarshalJSON(t *testing.T) {
+	b, err := json.Marshal(Of("foo", 42))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := string(b), `["foo",42]`; got != want {
+		t.Errorf("got %s; want %s", got, want)
+	}
+
+	b, err = json.Marshal(Of(Of(1, 2), []string{"a"}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := string(b), `[[1,2],["a"]]`; got != want {
+		t.Errorf("got %s; want %s", got, want)
+	}
+}
+
+func TestPair_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Pair[string, int]
+	}{
+		{`["foo",42]`, Of("foo", 42)},
+		{` [ "foo" , 42 ] `, Of("foo", 42)},
+		{`{"first":"foo","second":42}`, Of("foo", 42)},
+		{`{"second":42}`, Of("", 42)},
+	}
+	for _, tt := range tests {
+		var got Pair[string, int]
+		if err := json.Unmarshal([]byte(tt.in), &got); err != nil {
+			t.Errorf("Unmarshal(%s): unexpected error: %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Unmarshal(%s) = %v; want %v", tt.in, got, tt.want)
+		}
+	}
+
+	invalid := []string{`["foo"]`, `["foo",42,1]`, `[42,"foo"]`, `"foo"`, `{"first":42}`}
+	for _, in := range invalid {
+		var got Pair[string, int]
+		if err := json.Unmarshal([]byte(in), &got); err == nil {
+			t.Errorf("Unmarshal(%s) = %v; want error", in, got)
+		}
+	}
+
+	t.Run("null", func(t *testing.T) {
+		got := Of("foo", 42)
+		if err := json.Unmarshal([]byte(`null`), &got); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if want := Of("foo", 42); got != want {
+			t.Errorf("got %v; want %v", got, want)
+		}
+	})
+}
+
+func TestPair_MarshalText(t *testing.T) {
+	m := map[Pair[string, int]]bool{Of("foo", 42): true}
+	b, err := json.Marshal(m)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := string(b), `{"[\"foo\",42]":true}`; got != want {
+		t.Errorf("got %s; want %s", got, want)
+	}
+
+	var got map[Pair[string, int]]bool
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 1 || !got[Of("foo", 42)] {
+		t.Errorf("got %v; want %v", got, m)
+	}
+}
+
+func TestObject(t *testing.T) {
+	o := AsObject(Of("foo", 42))
+	if o.First() != "foo" || o.Second() != 42 {
+		t.Errorf("AsObject(...) = %v; want %v", o, Of("foo", 42))
+	}
+
+	b, err := json.Marshal(o)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := string(b), `{"first":"foo","second":42}`; got != want {
+		t.Errorf("got %s; want %s", got, want)
+	}
+
+	b, err = o.MarshalText()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := string(b), `{"first":"foo","second":42}`; got != want {
+		t.Errorf("got %s; want %s", got, want)
+	}
+
+	var got Object[string, int]
+	if err := json.Unmarshal([]byte(`{"first":"bar","second":7}`), &got); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := Of("bar", 7); got.Pair != want {
+		t.Errorf("got %v; want %v", got.Pair, want)
+	}
+	if err := json.Unmarshal([]byte(`["baz",8]`), &got); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := Of("baz", 8); got.Pair != want {
+		t.Errorf("got %v; want %v", got.Pair, want)
+	}
+}
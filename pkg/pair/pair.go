@@ -44,3 +44,44 @@ func (p Pair[A, B]) Reverse() Pair[B, A] {
 func (p Pair[A, B]) String() string {
 	return fmt.Sprintf("(%#v, %#v)", p.first, p.second)
 }
+
+// Swap returns a new Pair with the first and second elements of the provided Pair swapped.
+// It is equivalent to Pair.Reverse, in a form that may be passed as a mapper function (eg: to stream.Map).
+//
+// Example usage:
+//
+//	p := pair.Swap(pair.Of(1, "foo")) // ("foo", 1)
+func Swap[A, B any](p Pair[A, B]) Pair[B, A] {
+	return p.Reverse()
+}
+
+// Map returns a new Pair with the results of applying the provided mapper functions to the first and second elements of the provided Pair.
+//
+// Example usage:
+//
+//	p := pair.Map(
+//	  pair.Of(1, "foo"),
+//	  func(i int) string { return fmt.Sprint(i) },
+//	  strings.ToUpper,
+//	) // ("1", "FOO")
+func Map[A, B, C, D any](p Pair[A, B], mapFirst func(A) C, mapSecond func(B) D) Pair[C, D] {
+	return Pair[C, D]{first: mapFirst(p.first), second: mapSecond(p.second)}
+}
+
+// MapFirst returns a new Pair with the result of applying the provided mapper function to the first element of the provided Pair, and the same second element.
+//
+// Example usage:
+//
+//	p := pair.MapFirst(pair.Of(1, "foo"), func(i int) int { return i * 10 }) // (10, "foo")
+func MapFirst[A, B, C any](p Pair[A, B], mapper func(A) C) Pair[C, B] {
+	return Pair[C, B]{first: mapper(p.first), second: p.second}
+}
+
+// MapSecond returns a new Pair with the same first element as the provided Pair, and the result of applying the provided mapper function to the second element.
+//
+// Example usage:
+//
+//	p := pair.MapSecond(pair.Of(1, "foo"), strings.ToUpper) // (1, "FOO")
+func MapSecond[A, B, C any](p Pair[A, B], mapper func(B) C) Pair[A, C] {
+	return Pair[A, C]{first: p.first, second: mapper(p.second)}
+}
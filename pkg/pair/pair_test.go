@@ -42,3 +42,30 @@ func TestPair_String(t *testing.T) {
 		t.Errorf(`Of(42, "foo").String() = %v; want (42, "foo")`, got)
 	}
 }
+
+func TestSwap(t *testing.T) {
+	if got, want := Swap(Of(42, "foo")), Of("foo", 42); got != want {
+		t.Errorf(`Swap(Of(42, "foo")) = %v; want %v`, got, want)
+	}
+}
+
+func TestMap(t *testing.T) {
+	got := Map(Of(42, "foo"), func(i int) int { return i + 1 }, func(s string) int { return len(s) })
+	if want := Of(43, 3); got != want {
+		t.Errorf(`Map(Of(42, "foo"), ...) = %v; want %v`, got, want)
+	}
+}
+
+func TestMapFirst(t *testing.T) {
+	got := MapFirst(Of(42, "foo"), func(i int) bool { return i > 0 })
+	if want := Of(true, "foo"); got != want {
+		t.Errorf(`MapFirst(Of(42, "foo"), ...) = %v; want %v`, got, want)
+	}
+}
+
+func TestMapSecond(t *testing.T) {
+	got := MapSecond(Of(42, "foo"), func(s string) string { return s + "!" })
+	if want := Of(42, "foo!"); got != want {
+		t.Errorf(`MapSecond(Of(42, "foo"), ...) = %v; want %v`, got, want)
+	}
+}
@@ -0,0 +1,181 @@
+package res
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+)
+
+// FlatMap maps the value of the result to a new result using the provided mapper function.
+// If the result failed, the mapper is not called, and a failed result with the same error is returned.
+// If the result partially succeeded, its error is joined with the error of the mapped result, if any.
+//
+// Example usage:
+//
+//	r := res.FlatMap(
+//	  res.OK("42"),
+//	  func(s string) res.Result[int] { return res.Maybe(strconv.Atoi(s)) },
+//	) // Success(42)
+func FlatMap[T, U any](r Result[T], mapper func(T) Result[U]) Result[U] {
+	if r.Failed() {
+		return Fail[U](r.Error().GetOrZero())
+	}
+	mapped := mapper(r.Value().GetOrZero())
+	if r.Succeeded() {
+		return mapped
+	}
+
+	// Partial success; carry the error into the mapped result.
+	err := r.Error().GetOrZero()
+	if mapped.HasError() {
+		err = errors.Join(err, mapped.Error().GetOrZero())
+	}
+	if mapped.HasValue() {
+		return Partial(mapped.Value().GetOrZero(), err)
+	}
+	return Fail[U](err)
+}
+
+// Recover returns a successful result with the value returned by the provided function for the error of a failed result.
+// Successful and partially successful results are returned unchanged.
+//
+// Example usage:
+//
+//	r := res.Recover(
+//	  res.Fail[int](errors.New("boom")),
+//	  func(err error) int { return -1 },
+//	) // Success(-1)
+func Recover[T any](r Result[T], f func(error) T) Result[T] {
+	if r.Failed() {
+		return OK(f(r.Error().GetOrZero()))
+	}
+	return r
+}
+
+// RecoverWith returns the result returned by the provided function for the error of a failed result.
+// Successful and partially successful results are returned unchanged.
+//
+// Example usage:
+//
+//	r := res.RecoverWith(
+//	  res.Fail[int](os.ErrNotExist),
+//	  func(err error) res.Result[int] {
+//	    if errors.Is(err, os.ErrNotExist) {
+//	      return res.OK(0)
+//	    }
+//	    return res.Fail[int](err)
+//	  },
+//	) // Success(0)
+func RecoverWith[T any](r Result[T], f func(error) Result[T]) Result[T] {
+	if r.Failed() {
+		return f(r.Error().GetOrZero())
+	}
+	return r
+}
+
+// OrElse returns the provided result if it did not fail; otherwise, the alternative result.
+//
+// Example usage:
+//
+//	r := res.OrElse(res.Fail[int](errors.New("boom")), res.OK(2)) // Success(2)
+//	r = res.OrElse(res.OK(1), res.OK(2)) // Success(1)
+func OrElse[T any](r Result[T], alt Result[T]) Result[T] {
+	if r.Failed() {
+		return alt
+	}
+	return r
+}
+
+// PanicError is the error of a failed result returned by Try when the function panics.
+type PanicError struct {
+	Value any    // The value passed to panic.
+	Stack []byte // The stack trace of the goroutine at the time of the panic.
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// Unwrap returns the value passed to panic, if it is an error; nil otherwise.
+func (e *PanicError) Unwrap() error {
+	if err, ok := e.Value.(error); ok {
+		return err
+	}
+	return nil
+}
+
+// Try calls the provided function and returns its value and error as a result, as with Maybe.
+// If the function panics, the panic is recovered, and a failed result with a *PanicError is returned.
+//
+// Example usage:
+//
+//	r := res.Try(func() (int, error) { return strconv.Atoi("42") }) // Success(42)
+//	r = res.Try(func() (int, error) { panic("boom") }) // Failure(panic: boom)
+func Try[T any](f func() (T, error)) (r Result[T]) {
+	defer func() {
+		if v := recover(); v != nil {
+			r = Fail[T](&PanicError{Value: v, Stack: debug.Stack()})
+		}
+	}()
+	return Maybe(f())
+}
+
+// Unwrap returns the value and error of the result, for interoperating with functions that return (T, error).
+// The value is the zero value of type T if the result failed; the error is nil if the result succeeded.
+// Both are returned for a partially successful result.
+//
+// Example usage:
+//
+//	v, err := res.Unwrap(res.OK(42)) // 42, nil
+//	v, err = res.Unwrap(res.Fail[int](errors.New("boom"))) // 0, boom
+func Unwrap[T any](r Result[T]) (T, error) {
+	return r.Value().GetOrZero(), r.Error().GetOrZero()
+}
+
+// Collect returns a successful result with the values of the provided results, if they all succeeded.
+// Otherwise, it fails fast: a failed result with the error of the first result that has one (failed or partially successful) is returned.
+// See CollectAll for a variant that accumulates errors.
+//
+// Example usage:
+//
+//	r := res.Collect([]res.Result[int]{res.OK(1), res.OK(2)}) // Success([]int{1, 2})
+//	r = res.Collect([]res.Result[int]{res.OK(1), res.Fail[int](errors.New("boom"))}) // Failure(boom)
+func Collect[T any](results []Result[T]) Result[[]T] {
+	values := make([]T, 0, len(results))
+	for _, r := range results {
+		if r.HasError() {
+			return Fail[[]T](r.Error().GetOrZero())
+		}
+		values = append(values, r.Value().GetOrZero())
+	}
+	return OK(values)
+}
+
+// CollectAll returns a result with the values of all the provided results that have one, and the errors of all those that have one, joined with errors.Join.
+// The result is successful if none of the results has an error, failed if all of them failed, and partially successful otherwise.
+// An empty slice yields a successful result with no values.
+//
+// Example usage:
+//
+//	r := res.CollectAll([]res.Result[int]{res.OK(1), res.OK(2)}) // Success([]int{1, 2})
+//	r = res.CollectAll([]res.Result[int]{res.OK(1), res.Fail[int](errors.New("boom"))}) // PartialSuccess([]int{1}, boom)
+//	r = res.CollectAll([]res.Result[int]{res.Fail[int](errors.New("boom"))}) // Failure(boom)
+func CollectAll[T any](results []Result[T]) Result[[]T] {
+	values := make([]T, 0, len(results))
+	var errs []error
+	for _, r := range results {
+		r.Value().IfPresent(func(v T) {
+			values = append(values, v)
+		})
+		r.Error().IfPresent(func(err error) {
+			errs = append(errs, err)
+		})
+	}
+	if len(errs) == 0 {
+		return OK(values)
+	}
+	if len(values) == 0 {
+		return Fail[[]T](errors.Join(errs...))
+	}
+	return Partial(values, errors.Join(errs...))
+}
@@ -0,0 +1,190 @@
+package res
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestFlatMap(t *testing.T) {
+	atoi := func(s string) Result[int] { return Maybe(strconv.Atoi(s)) }
+	half := func(i int) Result[int] {
+		if i%2 != 0 {
+			return Partial(i/2, errors.New("odd"))
+		}
+		return OK(i / 2)
+	}
+	fail := func(int) Result[int] { return Fail[int](errors.New("mapped")) }
+
+	tests := []struct {
+		name string
+		got  Result[int]
+		want string
+	}{
+		{"Success", FlatMap(OK("42"), atoi), "Success(42)"},
+		{"Success to Failure", FlatMap(OK("x"), atoi), `Failure(strconv.Atoi: parsing "x": invalid syntax)`},
+		{"Failure", FlatMap(Fail[string](errors.New("error")), atoi), "Failure(error)"},
+		{"PartialSuccess to Success", FlatMap[int, int](Partial(4, errors.New("error")), half), "PartialSuccess(2, error)"},
+		{"PartialSuccess to PartialSuccess", FlatMap[int, int](Partial(5, errors.New("error")), half), "PartialSuccess(2, error\nodd)"},
+		{"PartialSuccess to Failure", FlatMap[int, int](Partial(5, errors.New("error")), fail), "Failure(error\nmapped)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.got.String(); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("mapper not called on Failure", func(t *testing.T) {
+		FlatMap(Fail[int](errors.New("error")), func(int) Result[int] {
+			t.Errorf("expected mapper not to be called")
+			return OK(0)
+		})
+	})
+}
+
+func TestRecover(t *testing.T) {
+	f := func(error) int { return -1 }
+	if got := Recover(Fail[int](errors.New("error")), f).String(); got != "Success(-1)" {
+		t.Errorf("got %q, want %q", got, "Success(-1)")
+	}
+	if got := Recover[int](OK(1), f).String(); got != "Success(1)" {
+		t.Errorf("got %q, want %q", got, "Success(1)")
+	}
+	if got := Recover[int](Partial(1, errors.New("error")), f).String(); got != "PartialSuccess(1, error)" {
+		t.Errorf("got %q, want %q", got, "PartialSuccess(1, error)")
+	}
+}
+
+func TestRecoverWith(t *testing.T) {
+	errNotFound := errors.New("not found")
+	f := func(err error) Result[int] {
+		if errors.Is(err, errNotFound) {
+			return OK(0)
+		}
+		return Fail[int](err)
+	}
+	if got := RecoverWith(Fail[int](errNotFound), f).String(); got != "Success(0)" {
+		t.Errorf("got %q, want %q", got, "Success(0)")
+	}
+	if got := RecoverWith(Fail[int](errors.New("error")), f).String(); got != "Failure(error)" {
+		t.Errorf("got %q, want %q", got, "Failure(error)")
+	}
+	if got := RecoverWith[int](OK(1), f).String(); got != "Success(1)" {
+		t.Errorf("got %q, want %q", got, "Success(1)")
+	}
+}
+
+func TestOrElse(t *testing.T) {
+	if got := OrElse[int](Fail[int](errors.New("error")), OK(2)).String(); got != "Success(2)" {
+		t.Errorf("got %q, want %q", got, "Success(2)")
+	}
+	if got := OrElse[int](OK(1), OK(2)).String(); got != "Success(1)" {
+		t.Errorf("got %q, want %q", got, "Success(1)")
+	}
+	if got := OrElse[int](Partial(1, errors.New("error")), OK(2)).String(); got != "PartialSuccess(1, error)" {
+		t.Errorf("got %q, want %q", got, "PartialSuccess(1, error)")
+	}
+}
+
+func TestTry(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		r := Try(func() (int, error) { return strconv.Atoi("42") })
+		if got := r.String(); got != "Success(42)" {
+			t.Errorf("got %q, want %q", got, "Success(42)")
+		}
+	})
+
+	t.Run("Failure", func(t *testing.T) {
+		r := Try(func() (int, error) { return 0, errors.New("error") })
+		if got := r.String(); got != "Failure(error)" {
+			t.Errorf("got %q, want %q", got, "Failure(error)")
+		}
+	})
+
+	t.Run("panic", func(t *testing.T) {
+		r := Try(func() (int, error) { panic("boom") })
+		if !r.Failed() {
+			t.Fatalf("expected Failed() to be true")
+		}
+		var pe *PanicError
+		if !errors.As(r.Error().GetOrZero(), &pe) {
+			t.Fatalf("expected *PanicError; got %T", r.Error().GetOrZero())
+		}
+		if pe.Value != "boom" || pe.Error() != "panic: boom" || len(pe.Stack) == 0 {
+			t.Errorf("got %+v, want panic value boom with stack", pe)
+		}
+	})
+
+	t.Run("panic with error", func(t *testing.T) {
+		errBoom := errors.New("boom")
+		r := Try(func() (int, error) { panic(errBoom) })
+		if !errors.Is(r.Error().GetOrZero(), errBoom) {
+			t.Errorf("expected error to wrap %v", errBoom)
+		}
+	})
+}
+
+func TestUnwrap(t *testing.T) {
+	v, err := Unwrap[int](OK(42))
+	if v != 42 || err != nil {
+		t.Errorf("got (%v, %v), want (42, <nil>)", v, err)
+	}
+	v, err = Unwrap[int](Fail[int](errors.New("error")))
+	if v != 0 || err == nil || err.Error() != "error" {
+		t.Errorf("got (%v, %v), want (0, error)", v, err)
+	}
+	v, err = Unwrap[int](Partial(42, errors.New("error")))
+	if v != 42 || err == nil || err.Error() != "error" {
+		t.Errorf("got (%v, %v), want (42, error)", v, err)
+	}
+}
+
+func TestCollect(t *testing.T) {
+	r := Collect([]Result[int]{OK(1), OK(2)})
+	if got := r.Value().GetOrZero(); !r.Succeeded() || !slices.Equal(got, []int{1, 2}) {
+		t.Errorf("got %v, want Success([1 2])", r)
+	}
+
+	r = Collect([]Result[int]{OK(1), Fail[int](errors.New("first")), Fail[int](errors.New("second"))})
+	if got := r.String(); got != "Failure(first)" {
+		t.Errorf("got %q, want %q", got, "Failure(first)")
+	}
+
+	r = Collect([]Result[int]{OK(1), Partial(2, errors.New("partial"))})
+	if got := r.String(); got != "Failure(partial)" {
+		t.Errorf("got %q, want %q", got, "Failure(partial)")
+	}
+
+	r = Collect[int](nil)
+	if got := r.Value().GetOrZero(); !r.Succeeded() || got == nil || len(got) != 0 {
+		t.Errorf("got %v, want Success([])", r)
+	}
+}
+
+func TestCollectAll(t *testing.T) {
+	r := CollectAll([]Result[int]{OK(1), OK(2)})
+	if got := r.Value().GetOrZero(); !r.Succeeded() || !slices.Equal(got, []int{1, 2}) {
+		t.Errorf("got %v, want Success([1 2])", r)
+	}
+
+	r = CollectAll([]Result[int]{OK(1), Fail[int](errors.New("first")), Partial(3, errors.New("second"))})
+	if got := r.Value().GetOrZero(); !r.PartiallySucceeded() || !slices.Equal(got, []int{1, 3}) {
+		t.Errorf("got %v, want PartialSuccess([1 3], ...)", r)
+	}
+	if got := r.Error().GetOrZero().Error(); got != "first\nsecond" {
+		t.Errorf("got %q, want %q", got, "first\nsecond")
+	}
+
+	r = CollectAll([]Result[int]{Fail[int](errors.New("first")), Fail[int](errors.New("second"))})
+	if got := r.String(); got != "Failure(first\nsecond)" {
+		t.Errorf("got %q, want %q", got, "Failure(first\nsecond)")
+	}
+
+	r = CollectAll[int](nil)
+	if !r.Succeeded() || len(r.Value().GetOrZero()) != 0 {
+		t.Errorf("got %v, want Success([])", r)
+	}
+}
@@ -1,7 +1,9 @@
 package res
 
 import (
+	"encoding/json"
 	"fmt"
+
 	"github.com/jpfourny/papaya/v2/pkg/opt"
 )
 
@@ -11,8 +13,11 @@ type Failure[T any] struct {
 	Err error
 }
 
-// Assert that Failure[T] implements Result[T]
-var _ Result[any] = Failure[any]{}
+// Assert that Failure[T] implements Result[T] and json.Marshaler.
+var (
+	_ Result[any]    = Failure[any]{}
+	_ json.Marshaler = Failure[any]{}
+)
 
 func (r Failure[T]) Succeeded() bool {
 	return false
@@ -45,3 +50,14 @@ func (r Failure[T]) Error() opt.Optional[error] {
 func (r Failure[T]) String() string {
 	return fmt.Sprintf("Failure(%v)", r.Err)
 }
+
+// Unwrap returns the error of the result, or nil if it has none.
+// To inspect the error of any Result, use the Is and As functions of this package.
+func (r Failure[T]) Unwrap() error {
+	return r.Err
+}
+
+// MarshalJSON returns the JSON encoding of the result, with its error encoded by DefaultErrorCodec; see the MarshalJSON function.
+func (r Failure[T]) MarshalJSON() ([]byte, error) {
+	return MarshalJSON[T](r, DefaultErrorCodec)
+}
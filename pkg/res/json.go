@@ -0,0 +1,145 @@
+package res
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Status values of a result encoded as JSON.
+const (
+	StatusSuccess = "success"
+	StatusPartial = "partial"
+	StatusFailure = "failure"
+)
+
+// ErrorCodec converts errors to and from JSON, for encoding and decoding results.
+type ErrorCodec interface {
+	// EncodeError returns the JSON encoding of the given non-nil error.
+	EncodeError(err error) (json.RawMessage, error)
+
+	// DecodeError returns the error decoded from the given JSON, as encoded by EncodeError.
+	DecodeError(data json.RawMessage) (error, error)
+}
+
+// StringErrorCodec is an ErrorCodec that encodes an error as a JSON string holding its message.
+// An error decoded by StringErrorCodec is created with errors.New, so it retains the message, but not the identity or type, of the original error.
+type StringErrorCodec struct{}
+
+// Assert that StringErrorCodec implements ErrorCodec.
+var _ ErrorCodec = StringErrorCodec{}
+
+func (StringErrorCodec) EncodeError(err error) (json.RawMessage, error) {
+	return json.Marshal(err.Error())
+}
+
+func (StringErrorCodec) DecodeError(data json.RawMessage) (error, error) {
+	var msg string
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return nil, err
+	}
+	return errors.New(msg), nil
+}
+
+// DefaultErrorCodec is the ErrorCodec used by the MarshalJSON methods of Success, PartialSuccess and Failure.
+// It may be replaced during program initialization to change how errors are encoded; it must not be replaced concurrently with encoding.
+var DefaultErrorCodec ErrorCodec = StringErrorCodec{}
+
+// jsonResult is the JSON representation of a result.
+type jsonResult struct {
+	Value  json.RawMessage `json:"value,omitempty"`
+	Error  json.RawMessage `json:"error,omitempty"`
+	Status string          `json:"status"`
+}
+
+// MarshalJSON returns the JSON encoding of the result, with its error encoded by the given ErrorCodec.
+// The result is encoded as an object with a "status" field (StatusSuccess, StatusPartial or StatusFailure), and "value" and "error" fields, if present.
+//
+// Example usage:
+//
+//	b, _ := res.MarshalJSON(res.Partial(42, errors.New("incomplete")), res.StringErrorCodec{})
+//	out := string(b) // {"value":42,"error":"incomplete","status":"partial"}
+func MarshalJSON[T any](r Result[T], codec ErrorCodec) ([]byte, error) {
+	var jr jsonResult
+	switch {
+	case r.Succeeded():
+		jr.Status = StatusSuccess
+	case r.PartiallySucceeded():
+		jr.Status = StatusPartial
+	default:
+		jr.Status = StatusFailure
+	}
+	if val, ok := r.Value().Get(); ok {
+		b, err := json.Marshal(val)
+		if err != nil {
+			return nil, err
+		}
+		jr.Value = b
+	}
+	if e, ok := r.Error().Get(); ok {
+		b, err := codec.EncodeError(e)
+		if err != nil {
+			return nil, err
+		}
+		jr.Error = b
+	}
+	return json.Marshal(jr)
+}
+
+// UnmarshalJSON returns the result decoded from the given JSON, as encoded by MarshalJSON, with its error decoded by the given ErrorCodec.
+//
+// Example usage:
+//
+//	r, _ := res.UnmarshalJSON[int]([]byte(`{"value":42,"status":"success"}`), res.StringErrorCodec{}) // Success(42)
+func UnmarshalJSON[T any](data []byte, codec ErrorCodec) (Result[T], error) {
+	var jr jsonResult
+	if err := json.Unmarshal(data, &jr); err != nil {
+		return nil, err
+	}
+	if jr.Status != StatusSuccess && jr.Status != StatusPartial && jr.Status != StatusFailure {
+		return nil, fmt.Errorf("res: unknown status %q", jr.Status)
+	}
+
+	var val T
+	if jr.Status != StatusFailure && len(jr.Value) > 0 {
+		if err := json.Unmarshal(jr.Value, &val); err != nil {
+			return nil, err
+		}
+	}
+	var e error
+	if jr.Status != StatusSuccess {
+		if len(jr.Error) == 0 {
+			return nil, fmt.Errorf("res: missing error for status %q", jr.Status)
+		}
+		var err error
+		if e, err = codec.DecodeError(jr.Error); err != nil {
+			return nil, err
+		}
+		if e == nil {
+			return nil, fmt.Errorf("res: decoded nil error for status %q", jr.Status)
+		}
+	}
+
+	switch jr.Status {
+	case StatusSuccess:
+		return OK(val), nil
+	case StatusPartial:
+		return Partial(val, e), nil
+	default:
+		return Fail[T](e), nil
+	}
+}
+
+// Is reports whether the error of the result, if any, matches the target, as with errors.Is.
+//
+// Example usage:
+//
+//	ok := res.Is(res.Fail[int](os.ErrNotExist), os.ErrNotExist) // true
+func Is[T any](r Result[T], target error) bool {
+	return errors.Is(r.Error().GetOrZero(), target)
+}
+
+// As finds the first error in the chain of the error of the result, if any, that matches the target, as with errors.As.
+func As[T any](r Result[T], target any) bool {
+	return errors.As(r.Error().GetOrZero(), target)
+}
@@ -0,0 +1,156 @@
+package res
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"testing"
+)
+
+func TestMarshalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		r    json.Marshaler
+		want string
+	}{
+		{"Success", OK(42), `{"value":42,"status":"success"}`},
+		{"PartialSuccess", Partial([]int{1}, errors.New("incomplete")), `{"value":[1],"error":"incomplete","status":"partial"}`},
+		{"Failure", Fail[int](errors.New("boom")), `{"error":"boom","status":"failure"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := json.Marshal(tt.r)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := string(b); got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+// codeCodec encodes errors as objects with a code, preserving the identity of known errors.
+type codeCodec struct{}
+
+func (codeCodec) EncodeError(err error) (json.RawMessage, error) {
+	code := "unknown"
+	if errors.Is(err, os.ErrNotExist) {
+		code = "not_found"
+	}
+	return json.Marshal(map[string]string{"code": code, "message": err.Error()})
+}
+
+func (codeCodec) DecodeError(data json.RawMessage) (error, error) {
+	var m map[string]string
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	if m["code"] == "not_found" {
+		return os.ErrNotExist, nil
+	}
+	return errors.New(m["message"]), nil
+}
+
+func TestMarshalJSON_codec(t *testing.T) {
+	b, err := MarshalJSON[int](Fail[int](os.ErrNotExist), codeCodec{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `{"error":{"code":"not_found","message":"file does not exist"},"status":"failure"}`
+	if got := string(b); got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+
+	r, err := UnmarshalJSON[int](b, codeCodec{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !Is(r, os.ErrNotExist) {
+		t.Errorf("expected decoded error to be os.ErrNotExist; got %v", r)
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"Success", `{"value":42,"status":"success"}`, "Success(42)"},
+		{"PartialSuccess", `{"value":42,"error":"incomplete","status":"partial"}`, "PartialSuccess(42, incomplete)"},
+		{"Failure", `{"error":"boom","status":"failure"}`, "Failure(boom)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := UnmarshalJSON[int]([]byte(tt.in), StringErrorCodec{})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := r.String(); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("round trip", func(t *testing.T) {
+		b, err := json.Marshal(Partial("x", errors.New("incomplete")))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		r, err := UnmarshalJSON[string](b, DefaultErrorCodec)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got, want := r.String(), `PartialSuccess("x", incomplete)`; got != want {
+			t.Errorf("got %q, want %q", got, want)
+		}
+	})
+
+	invalid := []string{
+		`{"value":42}`,
+		`{"value":42,"status":"bogus","error":"x"}`,
+		`{"status":"failure"}`,
+		`{"value":"x","status":"success"}`,
+		`{"error":42,"status":"failure"}`,
+		`[]`,
+	}
+	for _, in := range invalid {
+		if r, err := UnmarshalJSON[int]([]byte(in), StringErrorCodec{}); err == nil {
+			t.Errorf("UnmarshalJSON(%s): got %v, want error", in, r)
+		}
+	}
+}
+
+func TestUnwrap_method(t *testing.T) {
+	if err := OK(1).Unwrap(); err != nil {
+		t.Errorf("got %v, want nil", err)
+	}
+	if err := Fail[int](os.ErrNotExist).Unwrap(); err != os.ErrNotExist {
+		t.Errorf("got %v, want %v", err, os.ErrNotExist)
+	}
+	if err := Partial(1, os.ErrNotExist).Unwrap(); err != os.ErrNotExist {
+		t.Errorf("got %v, want %v", err, os.ErrNotExist)
+	}
+}
+
+func TestIs(t *testing.T) {
+	if !Is[int](Fail[int](os.ErrNotExist), fs.ErrNotExist) {
+		t.Errorf("expected Is to be true")
+	}
+	if Is[int](OK(1), os.ErrNotExist) {
+		t.Errorf("expected Is to be false")
+	}
+}
+
+func TestAs(t *testing.T) {
+	var pe *fs.PathError
+	r := Fail[int](&fs.PathError{Op: "open", Path: "x", Err: fs.ErrNotExist})
+	if !As[int](r, &pe) || pe.Path != "x" {
+		t.Errorf("expected As to find *fs.PathError")
+	}
+	if As[int](OK(1), &pe) {
+		t.Errorf("expected As to be false")
+	}
+}
@@ -1,7 +1,9 @@
 package res
 
 import (
+	"encoding/json"
 	"fmt"
+
 	"github.com/jpfourny/papaya/v2/pkg/opt"
 )
 
@@ -13,8 +15,11 @@ type PartialSuccess[T any] struct {
 	Err error
 }
 
-// Assert that PartialSuccess[T] implements Result[T]
-var _ Result[any] = PartialSuccess[any]{}
+// Assert that PartialSuccess[T] implements Result[T] and json.Marshaler.
+var (
+	_ Result[any]    = PartialSuccess[any]{}
+	_ json.Marshaler = PartialSuccess[any]{}
+)
 
 func (r PartialSuccess[T]) Succeeded() bool {
 	return false
@@ -47,3 +52,14 @@ func (r PartialSuccess[T]) Error() opt.Optional[error] {
 func (r PartialSuccess[T]) String() string {
 	return fmt.Sprintf("PartialSuccess(%#v, %v)", r.Val, r.Err)
 }
+
+// Unwrap returns the error of the result, or nil if it has none.
+// To inspect the error of any Result, use the Is and As functions of this package.
+func (r PartialSuccess[T]) Unwrap() error {
+	return r.Err
+}
+
+// MarshalJSON returns the JSON encoding of the result, with its error encoded by DefaultErrorCodec; see the MarshalJSON function.
+func (r PartialSuccess[T]) MarshalJSON() ([]byte, error) {
+	return MarshalJSON[T](r, DefaultErrorCodec)
+}
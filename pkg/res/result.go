@@ -76,6 +76,20 @@ func Maybe[T any](val T, err error) Result[T] {
 	return OK[T](val)
 }
 
+// FromOptional returns a successful result with the value of the provided Optional, if present; otherwise, a failed result with the provided error.
+// Panics if the Optional is empty and the error is nil.
+//
+// Example usage:
+//
+//	r := res.FromOptional(opt.Of(1), errors.New("missing")) // Success(1)
+//	r = res.FromOptional(opt.Empty[int](), errors.New("missing")) // Failure(missing)
+func FromOptional[T any](o opt.Optional[T], err error) Result[T] {
+	if val, ok := o.Get(); ok {
+		return OK(val)
+	}
+	return Fail[T](err)
+}
+
 // MapValue maps the value of the result to a new value using the provided mapper function.
 // The error of the result, if any, is unchanged.
 func MapValue[T, U any](r Result[T], valueMapper func(T) U) Result[U] {
@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+
+	"github.com/jpfourny/papaya/v2/pkg/opt"
 )
 
 func TestOK(t *testing.T) {
@@ -148,6 +150,28 @@ func TestMaybe(t *testing.T) {
 	})
 }
 
+func TestFromOptional(t *testing.T) {
+	t.Run("Some", func(t *testing.T) {
+		r := FromOptional(opt.Of(42), errors.New("missing"))
+		if !r.Succeeded() {
+			t.Errorf("expected Succeeded() to be true")
+		}
+		if r.Value().GetOrZero() != 42 {
+			t.Errorf("expected Value() to return 42")
+		}
+	})
+
+	t.Run("None", func(t *testing.T) {
+		r := FromOptional(opt.Empty[int](), errors.New("missing"))
+		if !r.Failed() {
+			t.Errorf("expected Failed() to be true")
+		}
+		if r.Error().GetOrZero().Error() != "missing" {
+			t.Errorf("expected Error() to return missing")
+		}
+	})
+}
+
 func TestMapValue(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		r := OK(42)
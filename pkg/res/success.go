@@ -1,7 +1,9 @@
 package res
 
 import (
+	"encoding/json"
 	"fmt"
+
 	"github.com/jpfourny/papaya/v2/pkg/opt"
 )
 
@@ -11,8 +13,11 @@ type Success[T any] struct {
 	Val T
 }
 
-// Assert that Success[T] implements Result[T]
-var _ Result[any] = Success[any]{}
+// Assert that Success[T] implements Result[T] and json.Marshaler.
+var (
+	_ Result[any]    = Success[any]{}
+	_ json.Marshaler = Success[any]{}
+)
 
 func (r Success[T]) Succeeded() bool {
 	return true
@@ -45,3 +50,14 @@ func (r Success[T]) Error() opt.Optional[error] {
 func (r Success[T]) String() string {
 	return fmt.Sprintf("Success(%#v)", r.Val)
 }
+
+// Unwrap returns the error of the result, or nil if it has none.
+// To inspect the error of any Result, use the Is and As functions of this package.
+func (r Success[T]) Unwrap() error {
+	return nil
+}
+
+// MarshalJSON returns the JSON encoding of the result, with its error encoded by DefaultErrorCodec; see the MarshalJSON function.
+func (r Success[T]) MarshalJSON() ([]byte, error) {
+	return MarshalJSON[T](r, DefaultErrorCodec)
+}
@@ -0,0 +1,217 @@
+package resilience
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is the error of a call rejected by a CircuitBreaker because it is open (or half-open, with all trial calls in flight).
+var ErrCircuitOpen = errors.New("resilience: circuit breaker is open")
+
+// State is the state of a CircuitBreaker.
+type State int
+
+const (
+	// StateClosed allows all calls, counting consecutive failures.
+	StateClosed State = iota
+	// StateOpen rejects all calls until the open timeout elapses.
+	StateOpen
+	// StateHalfOpen allows a limited number of trial calls, to probe whether the dependency has recovered.
+	StateHalfOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "half-open"
+	}
+	return "unknown"
+}
+
+// CircuitBreakerOptions configures a CircuitBreaker.
+// The zero value of each field selects its default.
+type CircuitBreakerOptions struct {
+	// FailureThreshold is the number of consecutive failures that opens a closed breaker; default 5.
+	FailureThreshold int
+
+	// OpenTimeout is how long the breaker stays open before allowing trial calls; default 30s.
+	OpenTimeout time.Duration
+
+	// HalfOpenMaxCalls is the number of trial calls allowed in flight while half-open; default 1.
+	HalfOpenMaxCalls int
+
+	// SuccessThreshold is the number of successful trial calls that closes a half-open breaker; default 1.
+	// A single failed trial call opens it again.
+	SuccessThreshold int
+
+	// IsFailure returns true if the given non-nil error counts as a failure; nil means all errors do.
+	// Errors that are not failures count as successes (eg: "not found" responses from a healthy dependency).
+	IsFailure func(err error) bool
+
+	// Now returns the current time; nil means time.Now.
+	Now func() time.Time
+
+	// OnStateChange, if not nil, is called after every state transition.
+	// It is called without holding the breaker's lock, so it may query the breaker.
+	OnStateChange func(from, to State)
+
+	// OnRejected, if not nil, is called whenever a call is rejected.
+	OnRejected func()
+}
+
+func (o CircuitBreakerOptions) withDefaults() CircuitBreakerOptions {
+	if o.FailureThreshold <= 0 {
+		o.FailureThreshold = 5
+	}
+	if o.OpenTimeout <= 0 {
+		o.OpenTimeout = 30 * time.Second
+	}
+	if o.HalfOpenMaxCalls <= 0 {
+		o.HalfOpenMaxCalls = 1
+	}
+	if o.SuccessThreshold <= 0 {
+		o.SuccessThreshold = 1
+	}
+	if o.Now == nil {
+		o.Now = time.Now
+	}
+	return o
+}
+
+// CircuitBreaker is a Guard that stops calling a dependency after repeated failures, giving it time to recover.
+//
+// A closed breaker allows all calls, and opens after FailureThreshold consecutive failures.
+// An open breaker rejects all calls with ErrCircuitOpen, and becomes half-open after OpenTimeout.
+// A half-open breaker allows up to HalfOpenMaxCalls trial calls at once; it closes after SuccessThreshold successful trials, or opens again after a failed one.
+// Outcomes of calls that started before the latest state transition are ignored.
+//
+// It is safe for concurrent use.
+type CircuitBreaker struct {
+	opts CircuitBreakerOptions
+
+	mu         sync.Mutex
+	state      State
+	generation uint64    // Incremented on every transition, to ignore the outcomes of stale calls.
+	failures   int       // Consecutive failures while closed.
+	successes  int       // Successful trials while half-open.
+	inFlight   int       // Trials in flight while half-open.
+	openedAt   time.Time // When the breaker last opened.
+}
+
+// Assert that *CircuitBreaker implements Guard.
+var _ Guard = (*CircuitBreaker)(nil)
+
+// NewCircuitBreaker returns a closed CircuitBreaker with the given options.
+func NewCircuitBreaker(opts CircuitBreakerOptions) *CircuitBreaker {
+	return &CircuitBreaker{opts: opts.withDefaults()}
+}
+
+// State returns the current state of the breaker.
+// An open breaker whose timeout has elapsed is reported as half-open.
+func (cb *CircuitBreaker) State() State {
+	cb.mu.Lock()
+	from := cb.state
+	to := cb.refresh()
+	cb.mu.Unlock()
+	cb.notify(from, to)
+	return to
+}
+
+// Acquire allows a call if the breaker is closed, or if it is half-open with fewer than HalfOpenMaxCalls trials in flight.
+// Returns ErrCircuitOpen otherwise.
+func (cb *CircuitBreaker) Acquire() (func(error), error) {
+	cb.mu.Lock()
+	from := cb.state
+	to := cb.refresh()
+	allowed := true
+	switch to {
+	case StateOpen:
+		allowed = false
+	case StateHalfOpen:
+		if cb.inFlight >= cb.opts.HalfOpenMaxCalls {
+			allowed = false
+		} else {
+			cb.inFlight++
+		}
+	}
+	gen := cb.generation
+	cb.mu.Unlock()
+	cb.notify(from, to)
+
+	if !allowed {
+		if cb.opts.OnRejected != nil {
+			cb.opts.OnRejected()
+		}
+		return nil, ErrCircuitOpen
+	}
+	var once sync.Once
+	return func(err error) {
+		once.Do(func() { cb.record(gen, err) })
+	}, nil
+}
+
+// record updates the breaker with the outcome of a call started in the given generation.
+func (cb *CircuitBreaker) record(gen uint64, err error) {
+	rejected := Rejected(err)
+	failed := err != nil && (cb.opts.IsFailure == nil || cb.opts.IsFailure(err))
+
+	cb.mu.Lock()
+	from := cb.state
+	switch {
+	case gen != cb.generation:
+		// Stale; the call started before the latest transition.
+	case rejected:
+		// The call was not made; free the trial slot without counting an outcome.
+		if cb.state == StateHalfOpen {
+			cb.inFlight--
+		}
+	case cb.state == StateClosed:
+		if !failed {
+			cb.failures = 0
+		} else if cb.failures++; cb.failures >= cb.opts.FailureThreshold {
+			cb.transition(StateOpen)
+		}
+	case cb.state == StateHalfOpen:
+		cb.inFlight--
+		if failed {
+			cb.transition(StateOpen)
+		} else if cb.successes++; cb.successes >= cb.opts.SuccessThreshold {
+			cb.transition(StateClosed)
+		}
+	}
+	to := cb.state
+	cb.mu.Unlock()
+	cb.notify(from, to)
+}
+
+// refresh moves an open breaker to half-open if its timeout has elapsed, and returns the resulting state.
+// The lock must be held.
+func (cb *CircuitBreaker) refresh() State {
+	if cb.state == StateOpen && !cb.opts.Now().Before(cb.openedAt.Add(cb.opts.OpenTimeout)) {
+		cb.transition(StateHalfOpen)
+	}
+	return cb.state
+}
+
+// transition moves the breaker to the given state, resetting its counters.
+// The lock must be held.
+func (cb *CircuitBreaker) transition(to State) {
+	cb.state = to
+	cb.generation++
+	cb.failures, cb.successes, cb.inFlight = 0, 0, 0
+	if to == StateOpen {
+		cb.openedAt = cb.opts.Now()
+	}
+}
+
+// notify calls the OnStateChange hook if the state changed.
+func (cb *CircuitBreaker) notify(from, to State) {
+	if from != to && cb.opts.OnStateChange != nil {
+		cb.opts.OnStateChange(from, to)
+	}
+}
@@ -0,0 +1,164 @@
+package resilience
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+var errDown = errors.New("down")
+
+// fakeTime is a clock that advances only when told to.
+type fakeTime struct {
+	now time.Time
+}
+
+func (f *fakeTime) Now() time.Time {
+	return f.now
+}
+
+func (f *fakeTime) Advance(d time.Duration) {
+	f.now = f.now.Add(d)
+}
+
+// newTestBreaker returns a breaker on a fake clock, recording its state transitions.
+func newTestBreaker(opts CircuitBreakerOptions) (*CircuitBreaker, *fakeTime, *[]string) {
+	clock := &fakeTime{now: time.Unix(0, 0)}
+	var transitions []string
+	opts.Now = clock.Now
+	opts.OnStateChange = func(from, to State) {
+		transitions = append(transitions, fmt.Sprintf("%s->%s", from, to))
+	}
+	return NewCircuitBreaker(opts), clock, &transitions
+}
+
+// call makes a call through the breaker with the given outcome, returning the rejection error, if any.
+func call(cb *CircuitBreaker, err error) error {
+	release, rerr := cb.Acquire()
+	if rerr != nil {
+		return rerr
+	}
+	release(err)
+	return nil
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	t.Run("opens after consecutive failures", func(t *testing.T) {
+		cb, _, transitions := newTestBreaker(CircuitBreakerOptions{FailureThreshold: 3})
+		_ = call(cb, errDown)
+		_ = call(cb, errDown)
+		_ = call(cb, nil) // Resets the count.
+		_ = call(cb, errDown)
+		_ = call(cb, errDown)
+		if got := cb.State(); got != StateClosed {
+			t.Fatalf("got %v, want %v", got, StateClosed)
+		}
+		_ = call(cb, errDown)
+		if got := cb.State(); got != StateOpen {
+			t.Fatalf("got %v, want %v", got, StateOpen)
+		}
+		if err := call(cb, nil); !errors.Is(err, ErrCircuitOpen) {
+			t.Errorf("got %v, want %v", err, ErrCircuitOpen)
+		}
+		if got := fmt.Sprint(*transitions); got != "[closed->open]" {
+			t.Errorf("got transitions %s, want %s", got, "[closed->open]")
+		}
+	})
+
+	t.Run("half-open trial succeeds", func(t *testing.T) {
+		cb, clock, transitions := newTestBreaker(CircuitBreakerOptions{FailureThreshold: 1, OpenTimeout: time.Minute, SuccessThreshold: 2})
+		_ = call(cb, errDown)
+		clock.Advance(59 * time.Second)
+		if got := cb.State(); got != StateOpen {
+			t.Fatalf("got %v, want %v", got, StateOpen)
+		}
+		clock.Advance(time.Second)
+		if err := call(cb, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := cb.State(); got != StateHalfOpen {
+			t.Fatalf("got %v, want %v", got, StateHalfOpen)
+		}
+		_ = call(cb, nil)
+		if got := cb.State(); got != StateClosed {
+			t.Fatalf("got %v, want %v", got, StateClosed)
+		}
+		if got, want := fmt.Sprint(*transitions), "[closed->open open->half-open half-open->closed]"; got != want {
+			t.Errorf("got transitions %s, want %s", got, want)
+		}
+	})
+
+	t.Run("half-open trial fails", func(t *testing.T) {
+		cb, clock, _ := newTestBreaker(CircuitBreakerOptions{FailureThreshold: 1, OpenTimeout: time.Minute})
+		_ = call(cb, errDown)
+		clock.Advance(time.Minute)
+		_ = call(cb, errDown)
+		if got := cb.State(); got != StateOpen {
+			t.Fatalf("got %v, want %v", got, StateOpen)
+		}
+		clock.Advance(30 * time.Second) // Timeout restarts when reopened.
+		if got := cb.State(); got != StateOpen {
+			t.Fatalf("got %v, want %v", got, StateOpen)
+		}
+	})
+
+	t.Run("half-open limits trial calls", func(t *testing.T) {
+		cb, clock, _ := newTestBreaker(CircuitBreakerOptions{FailureThreshold: 1, OpenTimeout: time.Minute, HalfOpenMaxCalls: 2})
+		_ = call(cb, errDown)
+		clock.Advance(time.Minute)
+		r1, err1 := cb.Acquire()
+		_, err2 := cb.Acquire()
+		_, err3 := cb.Acquire()
+		if err1 != nil || err2 != nil || !errors.Is(err3, ErrCircuitOpen) {
+			t.Fatalf("got %v, %v, %v; want 2 trials allowed, then %v", err1, err2, err3, ErrCircuitOpen)
+		}
+		r1(nil)
+		r1(errDown) // Ignored; release is called once.
+		if got := cb.State(); got != StateClosed {
+			t.Errorf("got %v, want %v", got, StateClosed)
+		}
+	})
+
+	t.Run("ignores stale outcomes", func(t *testing.T) {
+		cb, _, _ := newTestBreaker(CircuitBreakerOptions{FailureThreshold: 1})
+		stale, _ := cb.Acquire()
+		_ = call(cb, errDown) // Opens.
+		stale(nil)
+		if got := cb.State(); got != StateOpen {
+			t.Errorf("got %v, want %v", got, StateOpen)
+		}
+	})
+
+	t.Run("classifies failures", func(t *testing.T) {
+		errNotFound := errors.New("not found")
+		cb, _, _ := newTestBreaker(CircuitBreakerOptions{
+			FailureThreshold: 1,
+			IsFailure:        func(err error) bool { return !errors.Is(err, errNotFound) },
+		})
+		_ = call(cb, errNotFound)
+		if got := cb.State(); got != StateClosed {
+			t.Errorf("got %v, want %v", got, StateClosed)
+		}
+	})
+
+	t.Run("reports rejections", func(t *testing.T) {
+		rejected := 0
+		cb, _, _ := newTestBreaker(CircuitBreakerOptions{FailureThreshold: 1, OnRejected: func() { rejected++ }})
+		_ = call(cb, errDown)
+		_ = call(cb, nil)
+		_ = call(cb, nil)
+		if rejected != 2 {
+			t.Errorf("got %d rejection(s), want 2", rejected)
+		}
+	})
+}
+
+func TestState_String(t *testing.T) {
+	tests := map[State]string{StateClosed: "closed", StateOpen: "open", StateHalfOpen: "half-open", State(42): "unknown"}
+	for s, want := range tests {
+		if got := s.String(); got != want {
+			t.Errorf("got %q, want %q", got, want)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package resilience
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrBulkheadFull is the error of a call rejected by a Bulkhead because too many calls are in flight.
+var ErrBulkheadFull = errors.New("resilience: bulkhead is full")
+
+// BulkheadOptions configures a Bulkhead.
+type BulkheadOptions struct {
+	// MaxConcurrent is the maximum number of calls in flight at once; zero or less means 1.
+	MaxConcurrent int
+
+	// MaxWait is how long a call waits for a slot when the Bulkhead is full, before being rejected; zero or less means it is rejected immediately.
+	MaxWait time.Duration
+
+	// OnRejected, if not nil, is called whenever a call is rejected.
+	OnRejected func()
+}
+
+// Bulkhead is a Guard that bounds the number of concurrent calls, so that a slow dependency cannot tie up every caller.
+// It is safe for concurrent use.
+type Bulkhead struct {
+	opts  BulkheadOptions
+	slots chan struct{}
+}
+
+// Assert that *Bulkhead implements Guard.
+var _ Guard = (*Bulkhead)(nil)
+
+// NewBulkhead returns a Bulkhead with the given options.
+func NewBulkhead(opts BulkheadOptions) *Bulkhead {
+	opts.MaxConcurrent = max(opts.MaxConcurrent, 1)
+	return &Bulkhead{
+		opts:  opts,
+		slots: make(chan struct{}, opts.MaxConcurrent),
+	}
+}
+
+// InFlight returns the number of calls currently in flight.
+func (b *Bulkhead) InFlight() int {
+	return len(b.slots)
+}
+
+// Acquire takes a slot for a call, waiting up to MaxWait for one to be released if the Bulkhead is full.
+// Returns ErrBulkheadFull if no slot becomes available.
+func (b *Bulkhead) Acquire() (func(error), error) {
+	select {
+	case b.slots <- struct{}{}:
+		return b.release(), nil
+	default:
+	}
+	if b.opts.MaxWait > 0 {
+		t := time.NewTimer(b.opts.MaxWait)
+		defer t.Stop()
+		select {
+		case b.slots <- struct{}{}:
+			return b.release(), nil
+		case <-t.C:
+		}
+	}
+	if b.opts.OnRejected != nil {
+		b.opts.OnRejected()
+	}
+	return nil, ErrBulkheadFull
+}
+
+// release returns a function that frees the slot taken by a call; only its first call has an effect.
+func (b *Bulkhead) release() func(error) {
+	var once sync.Once
+	return func(error) {
+		once.Do(func() { <-b.slots })
+	}
+}
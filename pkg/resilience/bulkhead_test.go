@@ -0,0 +1,88 @@
+package resilience
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBulkhead(t *testing.T) {
+	t.Run("rejects when full", func(t *testing.T) {
+		rejected := 0
+		b := NewBulkhead(BulkheadOptions{MaxConcurrent: 2, OnRejected: func() { rejected++ }})
+		r1, err1 := b.Acquire()
+		_, err2 := b.Acquire()
+		_, err3 := b.Acquire()
+		if err1 != nil || err2 != nil || !errors.Is(err3, ErrBulkheadFull) {
+			t.Fatalf("got %v, %v, %v; want 2 calls allowed, then %v", err1, err2, err3, ErrBulkheadFull)
+		}
+		if got := b.InFlight(); got != 2 {
+			t.Errorf("got %d in flight, want 2", got)
+		}
+		r1(nil)
+		if _, err := b.Acquire(); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if rejected != 1 {
+			t.Errorf("got %d rejection(s), want 1", rejected)
+		}
+	})
+
+	t.Run("waits for a slot", func(t *testing.T) {
+		b := NewBulkhead(BulkheadOptions{MaxConcurrent: 1, MaxWait: time.Minute})
+		release, _ := b.Acquire()
+		go func() {
+			time.Sleep(time.Millisecond)
+			release(nil)
+		}()
+		if _, err := b.Acquire(); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("release is idempotent", func(t *testing.T) {
+		b := NewBulkhead(BulkheadOptions{MaxConcurrent: 2})
+		r1, _ := b.Acquire()
+		_, _ = b.Acquire()
+		r1(nil)
+		r1(nil) // Must not free the slot of the other call.
+		if got := b.InFlight(); got != 1 {
+			t.Errorf("got %d in flight, want 1", got)
+		}
+		r1(nil) // Must not block once no slot is taken by r1.
+	})
+
+	t.Run("gives up waiting", func(t *testing.T) {
+		b := NewBulkhead(BulkheadOptions{MaxWait: time.Millisecond})
+		_, _ = b.Acquire()
+		if _, err := b.Acquire(); !errors.Is(err, ErrBulkheadFull) {
+			t.Errorf("got %v, want %v", err, ErrBulkheadFull)
+		}
+	})
+
+	t.Run("bounds concurrency", func(t *testing.T) {
+		b := NewBulkhead(BulkheadOptions{MaxConcurrent: 3, MaxWait: time.Minute})
+		var inFlight, peak atomic.Int32
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				Call(b, func() (int, error) {
+					n := inFlight.Add(1)
+					for p := peak.Load(); n > p && !peak.CompareAndSwap(p, n); p = peak.Load() {
+					}
+					time.Sleep(time.Millisecond)
+					inFlight.Add(-1)
+					return 0, nil
+				})
+			}()
+		}
+		wg.Wait()
+		if got := peak.Load(); got > 3 {
+			t.Errorf("got peak of %d in flight, want at most 3", got)
+		}
+	})
+}
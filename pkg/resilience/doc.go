@@ -0,0 +1,6 @@
+// Package resilience provides guards that protect callers from flaky or overloaded dependencies, such as downstream services called from a stream pipeline.
+//
+// A CircuitBreaker stops calling a dependency that keeps failing, giving it time to recover.
+// A Bulkhead bounds the number of concurrent calls to a dependency.
+// Both implement Guard, and may be applied to a function with Call, or to a fallible mapper with Map, yielding a res.Result that fails fast when the guard rejects the call.
+package resilience
@@ -0,0 +1,110 @@
+package resilience
+
+import (
+	"github.com/jpfourny/papaya/v2/pkg/res"
+	"github.com/jpfourny/papaya/v2/pkg/stream"
+)
+
+// Guard decides whether a call may proceed, and observes its outcome.
+type Guard interface {
+	// Acquire returns a release function if a call may proceed; otherwise, an error describing why it was rejected.
+	// The release function must be called exactly once, with the error returned by the call (nil if it succeeded).
+	// If the call was not made because another Guard rejected it, the release function is called with an error created by Reject, for which Rejected returns true, and which must not be counted as an outcome of the call.
+	Acquire() (release func(err error), err error)
+}
+
+// Reject returns an error reporting that a call was rejected with the given error, for the release functions of Guards that allowed the call, but whose call was not made.
+// Guards that combine other Guards, such as Compose, pass it to the release functions of those already acquired when a later one rejects the call.
+//
+// Example usage:
+//
+//	release, _ := first.Acquire()
+//	if _, err := second.Acquire(); err != nil {
+//	  release(resilience.Reject(err)) // Not counted as an outcome by first.
+//	}
+func Reject(err error) error {
+	return rejection{err: err}
+}
+
+// Rejected returns true if the given error was created by Reject; false otherwise.
+// Errors returned by a call are never rejections, even if they wrap ErrCircuitOpen or ErrBulkheadFull (eg: the rejection of a nested call).
+func Rejected(err error) bool {
+	_, ok := err.(rejection)
+	return ok
+}
+
+// rejection is the error created by Reject.
+type rejection struct {
+	err error
+}
+
+func (r rejection) Error() string {
+	return r.err.Error()
+}
+
+func (r rejection) Unwrap() error {
+	return r.err
+}
+
+// Call calls the given function if the Guard allows it, and returns its outcome as a result.
+// If the Guard rejects the call, a failed result with the rejection error is returned without calling the function.
+// If the function panics, the panic is recovered and reported to the Guard as a failure, as with res.Try.
+//
+// Example usage:
+//
+//	cb := resilience.NewCircuitBreaker(resilience.CircuitBreakerOptions{})
+//	r := resilience.Call(cb, func() (string, error) { return fetch(key) })
+func Call[T any](g Guard, fn func() (T, error)) res.Result[T] {
+	release, err := g.Acquire()
+	if err != nil {
+		return res.Fail[T](err)
+	}
+	r := res.Try(fn)
+	release(r.Error().GetOrZero())
+	return r
+}
+
+// Map wraps the given fallible mapper function with the Guard, returning a stream.Mapper that yields the outcome of each call as a result; see Call.
+// Calls rejected by the Guard yield failed results, rather than aborting the stream.
+//
+// Example usage:
+//
+//	cb := resilience.NewCircuitBreaker(resilience.CircuitBreakerOptions{})
+//	s := stream.Map(keys, resilience.Map(cb, fetch)) // Stream of res.Result[string]
+func Map[E, F any](g Guard, m func(E) (F, error)) stream.Mapper[E, res.Result[F]] {
+	return func(e E) res.Result[F] {
+		return Call(g, func() (F, error) {
+			return m(e)
+		})
+	}
+}
+
+// Compose returns a Guard that allows a call only if all the given Guards allow it, acquiring them in order.
+// If a Guard rejects the call, those already acquired are released with the rejection error, wrapped by Reject.
+//
+// Example usage:
+//
+//	g := resilience.Compose(breaker, bulkhead)
+func Compose(guards ...Guard) Guard {
+	return composite(guards)
+}
+
+type composite []Guard
+
+func (c composite) Acquire() (func(error), error) {
+	releases := make([]func(error), 0, len(c))
+	releaseAll := func(err error) {
+		for i := len(releases) - 1; i >= 0; i-- {
+			releases[i](err)
+		}
+	}
+	for _, g := range c {
+		release, err := g.Acquire()
+		if err != nil {
+			releaseAll(Reject(err))
+			return nil, err
+		}
+		releases = append(releases, release)
+	}
+	return releaseAll, nil
+}
@@ -0,0 +1,103 @@
+package resilience
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jpfourny/papaya/v2/internal/assert"
+	"github.com/jpfourny/papaya/v2/pkg/res"
+	"github.com/jpfourny/papaya/v2/pkg/stream"
+)
+
+func TestCall(t *testing.T) {
+	cb := NewCircuitBreaker(CircuitBreakerOptions{FailureThreshold: 1})
+
+	r := Call(cb, func() (int, error) { return 1, nil })
+	if got := r.String(); got != "Success(1)" {
+		t.Errorf("got %q, want %q", got, "Success(1)")
+	}
+
+	r = Call(cb, func() (int, error) { panic("boom") })
+	if got := r.String(); got != "Failure(panic: boom)" {
+		t.Errorf("got %q, want %q", got, "Failure(panic: boom)")
+	}
+
+	called := false
+	r = Call(cb, func() (int, error) { called = true; return 1, nil })
+	if !res.Is(r, ErrCircuitOpen) || called {
+		t.Errorf("got %v (called=%v), want Failure(%v) without calling", r, called, ErrCircuitOpen)
+	}
+}
+
+func TestMap(t *testing.T) {
+	cb := NewCircuitBreaker(CircuitBreakerOptions{FailureThreshold: 2})
+	m := Map(cb, func(i int) (int, error) {
+		if i < 0 {
+			return 0, errDown
+		}
+		return i * 10, nil
+	})
+	s := stream.Map(stream.Of(1, -1, -2, 3), m)
+	got := stream.CollectSlice(stream.Map(s, func(r res.Result[int]) string {
+		return fmt.Sprint(r)
+	}))
+	want := []string{"Success(10)", "Failure(down)", "Failure(down)", "Failure(resilience: circuit breaker is open)"}
+	assert.ElementsMatch(t, got, want)
+}
+
+func TestCompose(t *testing.T) {
+	cb := NewCircuitBreaker(CircuitBreakerOptions{FailureThreshold: 1})
+	b := NewBulkhead(BulkheadOptions{MaxConcurrent: 1})
+	g := Compose(cb, b)
+
+	release, err := g.Acquire()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := g.Acquire(); !errors.Is(err, ErrBulkheadFull) {
+		t.Fatalf("got %v, want %v", err, ErrBulkheadFull)
+	}
+	if got := cb.State(); got != StateClosed {
+		t.Fatalf("got %v, want %v; rejection by bulkhead must not count as a failure", got, StateClosed)
+	}
+	release(errDown) // Releases the bulkhead slot, and opens the breaker.
+	if got := b.InFlight(); got != 0 {
+		t.Errorf("got %d in flight, want 0", got)
+	}
+	if _, err := g.Acquire(); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("got %v, want %v", err, ErrCircuitOpen)
+	}
+}
+
+func TestCompose_NestedRejection(t *testing.T) {
+	inner := NewCircuitBreaker(CircuitBreakerOptions{FailureThreshold: 1})
+	outer := NewCircuitBreaker(CircuitBreakerOptions{FailureThreshold: 2})
+	g := Compose(outer, NewBulkhead(BulkheadOptions{MaxConcurrent: 1}))
+
+	_ = Call(inner, func() (int, error) { return 0, errDown }) // Opens the inner breaker.
+	nested := func() (int, error) {
+		r := Call(inner, func() (int, error) { return 1, nil })
+		return r.Value().GetOrZero(), r.Error().GetOrZero()
+	}
+	for i := 0; i < 2; i++ {
+		if r := Call(g, nested); !res.Is(r, ErrCircuitOpen) {
+			t.Fatalf("got %v, want Failure(%v)", r, ErrCircuitOpen)
+		}
+	}
+	if got := outer.State(); got != StateOpen {
+		t.Errorf("got %v, want %v; errors returned by the call must count as failures, even if they are rejections of a nested call", got, StateOpen)
+	}
+}
+
+func TestRejected(t *testing.T) {
+	if Rejected(ErrCircuitOpen) || Rejected(fmt.Errorf("wrapped: %w", ErrBulkheadFull)) || Rejected(nil) {
+		t.Errorf("expected errors returned by a call not to be rejections")
+	}
+	if !Rejected(Reject(ErrBulkheadFull)) {
+		t.Errorf("expected Reject to create a rejection")
+	}
+	if !errors.Is(Reject(ErrBulkheadFull), ErrBulkheadFull) {
+		t.Errorf("expected rejection to wrap the rejection error")
+	}
+}
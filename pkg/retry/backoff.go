@@ -0,0 +1,65 @@
+package retry
+
+import (
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// Backoff returns the delay to wait after the given number of failed attempts (starting at 1), before the next attempt.
+type Backoff func(attempt int) time.Duration
+
+// Constant returns a Backoff that waits for the given delay after every attempt.
+func Constant(delay time.Duration) Backoff {
+	return func(int) time.Duration {
+		return delay
+	}
+}
+
+// Exponential returns a Backoff that waits for the initial delay after the first attempt, with the delay multiplied by the given factor after each subsequent attempt, up to the given maximum.
+// A maximum of zero or less means the delay is unbounded (other than by the range of time.Duration).
+//
+// Example usage:
+//
+//	b := retry.Exponential(100*time.Millisecond, 2, time.Second)
+//	d := b(1) // 100ms
+//	d = b(3) // 400ms
+//	d = b(10) // 1s
+func Exponential(initial time.Duration, factor float64, maxDelay time.Duration) Backoff {
+	return func(attempt int) time.Duration {
+		limit := maxDelay
+		if limit <= 0 {
+			limit = math.MaxInt64
+		}
+		d := float64(initial) * math.Pow(factor, float64(attempt-1))
+		if d >= float64(limit) || math.IsNaN(d) {
+			return limit
+		}
+		return time.Duration(d)
+	}
+}
+
+// Jitter returns a Backoff that randomizes the delay of the given Backoff by up to the given fraction (between 0 and 1) in either direction.
+// Jitter spreads out the retries of clients that failed at the same time.
+//
+// Example usage:
+//
+//	b := retry.Jitter(retry.Constant(time.Second), 0.2)
+//	d := b(1) // Between 800ms and 1.2s.
+func Jitter(b Backoff, fraction float64) Backoff {
+	fraction = min(max(fraction, 0), 1)
+	return func(attempt int) time.Duration {
+		d := float64(b(attempt)) * (1 - fraction + 2*fraction*rand.Float64())
+		if d >= math.MaxInt64 {
+			return math.MaxInt64 // Avoid overflow.
+		}
+		return time.Duration(d)
+	}
+}
+
+// FullJitter returns a Backoff that waits for a random delay between zero and the delay of the given Backoff.
+func FullJitter(b Backoff) Backoff {
+	return func(attempt int) time.Duration {
+		return time.Duration(float64(b(attempt)) * rand.Float64())
+	}
+}
@@ -0,0 +1,68 @@
+package retry
+
+import (
+	"testing"
+	"time"
+)
+
+func TestConstant(t *testing.T) {
+	b := Constant(time.Second)
+	for attempt := 1; attempt <= 3; attempt++ {
+		if got := b(attempt); got != time.Second {
+			t.Errorf("attempt %d: got %v, want %v", attempt, got, time.Second)
+		}
+	}
+}
+
+func TestExponential(t *testing.T) {
+	b := Exponential(100*time.Millisecond, 2, time.Second)
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{1, 100 * time.Millisecond},
+		{2, 200 * time.Millisecond},
+		{3, 400 * time.Millisecond},
+		{4, 800 * time.Millisecond},
+		{5, time.Second},
+		{100, time.Second},
+	}
+	for _, tt := range tests {
+		if got := b(tt.attempt); got != tt.want {
+			t.Errorf("attempt %d: got %v, want %v", tt.attempt, got, tt.want)
+		}
+	}
+
+	t.Run("unbounded", func(t *testing.T) {
+		b := Exponential(time.Second, 10, 0)
+		if got, want := b(3), 100*time.Second; got != want {
+			t.Errorf("got %v, want %v", got, want)
+		}
+		if got := b(1000); got <= 0 {
+			t.Errorf("got %v, want positive delay", got)
+		}
+	})
+}
+
+func TestJitter(t *testing.T) {
+	b := Jitter(Constant(time.Second), 0.2)
+	for i := 0; i < 100; i++ {
+		if got := b(1); got < 800*time.Millisecond || got > 1200*time.Millisecond {
+			t.Fatalf("got %v, want between 800ms and 1.2s", got)
+		}
+	}
+
+	b = Jitter(Constant(time.Second), 0)
+	if got := b(1); got != time.Second {
+		t.Errorf("got %v, want %v", got, time.Second)
+	}
+}
+
+func TestFullJitter(t *testing.T) {
+	b := FullJitter(Constant(time.Second))
+	for i := 0; i < 100; i++ {
+		if got := b(1); got < 0 || got > time.Second {
+			t.Fatalf("got %v, want between 0 and 1s", got)
+		}
+	}
+}
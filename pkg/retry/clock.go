@@ -0,0 +1,42 @@
+package retry
+
+import (
+	"context"
+	"time"
+)
+
+// Clock provides the current time and waits for durations to elapse.
+// It is injected into a Policy so that tests can control time.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+
+	// Sleep waits for the given duration to elapse, or for the context to be done, whichever happens first.
+	// It returns the error of the context if it is done before the duration elapses; nil otherwise.
+	Sleep(ctx context.Context, d time.Duration) error
+}
+
+// SystemClock returns a Clock backed by the system time.
+func SystemClock() Clock {
+	return systemClock{}
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) Sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,5 @@
+// Package retry provides retrying of fallible operations, with configurable backoff, limits and error classification.
+//
+// The outcome of a retried operation is a res.Result, which is successful if any attempt succeeded, or failed with the last error otherwise.
+// Time is read and waited on through a Clock, which may be replaced in tests to avoid real delays.
+package retry
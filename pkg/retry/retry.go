@@ -0,0 +1,157 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jpfourny/papaya/v2/pkg/res"
+)
+
+// Policy configures how an operation is retried by Do.
+// The zero value retries immediately, with no limit on attempts, until the operation succeeds, fails with a non-retryable error, or the context is done.
+type Policy struct {
+	// MaxAttempts is the maximum number of attempts, including the first; zero or less means no limit.
+	MaxAttempts int
+
+	// MaxElapsed is the maximum time from the start of the first attempt to the start of the last; zero or less means no limit.
+	// No further attempts are made if waiting for the next one would exceed it.
+	MaxElapsed time.Duration
+
+	// Backoff returns the delay before each retry; nil means no delay.
+	Backoff Backoff
+
+	// Retryable returns true if an attempt that failed with the given error may be retried; nil means all errors are retryable.
+	// Errors marked with Permanent are never retried.
+	Retryable func(err error) bool
+
+	// Clock is used to measure elapsed time and wait between attempts; nil means SystemClock.
+	Clock Clock
+}
+
+// DefaultPolicy returns a Policy making up to 3 attempts, with jittered exponential backoff from 100ms up to 10s.
+func DefaultPolicy() Policy {
+	return Policy{
+		MaxAttempts: 3,
+		Backoff:     Jitter(Exponential(100*time.Millisecond, 2, 10*time.Second), 0.2),
+	}
+}
+
+// Error is the error of a failed result returned by Do when it gives up retrying a retryable error.
+type Error struct {
+	Attempts int   // The number of attempts made.
+	Err      error // The error of the last attempt, joined with the error of the context if it is done.
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("retry: gave up after %d attempt(s): %v", e.Attempts, e.Err)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// permanentError marks an error as non-retryable.
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string {
+	return e.err.Error()
+}
+
+func (e *permanentError) Unwrap() error {
+	return e.err
+}
+
+// Permanent wraps the given error so that Do does not retry it, regardless of the Retryable function of the Policy.
+// If the error returned by the function is the one returned by Permanent, Do unwraps it before returning it in a failed result.
+// If the error merely wraps one returned by Permanent (eg: fmt.Errorf("fetch: %w", retry.Permanent(err))), Do returns it as is, since the chain of wrapping errors cannot be rebuilt without the marker.
+// The marker does not change the message of the error, and errors.Is and errors.As see through it, so use those, rather than comparing errors with ==, to inspect the errors of failed results.
+// Returns nil if the error is nil.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// On returns a Retryable function for a Policy that retries only errors matching any of the given targets, as with errors.Is.
+//
+// Example usage:
+//
+//	p := retry.Policy{MaxAttempts: 5, Retryable: retry.On(ErrUnavailable, context.DeadlineExceeded)}
+func On(targets ...error) func(error) bool {
+	return func(err error) bool {
+		for _, target := range targets {
+			if errors.Is(err, target) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Except returns a Retryable function for a Policy that retries all errors except those matching any of the given targets, as with errors.Is.
+func Except(targets ...error) func(error) bool {
+	on := On(targets...)
+	return func(err error) bool {
+		return !on(err)
+	}
+}
+
+// Do calls the given function until it succeeds, or until the Policy says to give up, and returns the outcome as a result.
+// The context is passed to each attempt, and waiting between attempts stops when it is done.
+//
+// A successful result holds the value of the first successful attempt.
+// If an attempt fails with a non-retryable error, a failed result holds that error (see Permanent).
+// If the attempts or elapsed time are exhausted, or the context is done, a failed result holds an *Error wrapping the last error.
+//
+// Example usage:
+//
+//	r := retry.Do(ctx, retry.DefaultPolicy(), func(ctx context.Context) (*http.Response, error) {
+//		return client.Do(req.WithContext(ctx))
+//	})
+func Do[T any](ctx context.Context, p Policy, fn func(ctx context.Context) (T, error)) res.Result[T] {
+	clock := p.Clock
+	if clock == nil {
+		clock = SystemClock()
+	}
+	start := clock.Now()
+
+	for attempt := 1; ; attempt++ {
+		if err := ctx.Err(); err != nil {
+			return res.Fail[T](&Error{Attempts: attempt - 1, Err: err})
+		}
+		v, err := fn(ctx)
+		if err == nil {
+			return res.OK(v)
+		}
+
+		if perm, ok := err.(*permanentError); ok {
+			return res.Fail[T](perm.err)
+		}
+		var perm *permanentError
+		if errors.As(err, &perm) {
+			return res.Fail[T](err)
+		}
+		if p.Retryable != nil && !p.Retryable(err) {
+			return res.Fail[T](err)
+		}
+		if p.MaxAttempts > 0 && attempt >= p.MaxAttempts {
+			return res.Fail[T](&Error{Attempts: attempt, Err: err})
+		}
+
+		var delay time.Duration
+		if p.Backoff != nil {
+			delay = p.Backoff(attempt)
+		}
+		if p.MaxElapsed > 0 && clock.Now().Add(delay).Sub(start) > p.MaxElapsed {
+			return res.Fail[T](&Error{Attempts: attempt, Err: err})
+		}
+		if serr := clock.Sleep(ctx, delay); serr != nil {
+			return res.Fail[T](&Error{Attempts: attempt, Err: errors.Join(err, serr)})
+		}
+	}
+}
@@ -0,0 +1,225 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jpfourny/papaya/v2/pkg/res"
+)
+
+// fakeClock is a Clock whose time advances only when slept on.
+type fakeClock struct {
+	now    time.Time
+	sleeps []time.Duration
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) Sleep(ctx context.Context, d time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	c.sleeps = append(c.sleeps, d)
+	c.now = c.now.Add(d)
+	return nil
+}
+
+var errFlaky = errors.New("flaky")
+
+// failing returns a function that fails with the given error for the given number of calls, then succeeds with the number of calls made.
+func failing(n int, err error) (fn func(context.Context) (int, error), calls *int) {
+	calls = new(int)
+	return func(context.Context) (int, error) {
+		*calls++
+		if *calls <= n {
+			return 0, err
+		}
+		return *calls, nil
+	}, calls
+}
+
+func TestDo(t *testing.T) {
+	t.Run("succeeds first time", func(t *testing.T) {
+		clock := &fakeClock{}
+		fn, calls := failing(0, errFlaky)
+		r := Do(context.Background(), Policy{MaxAttempts: 3, Backoff: Constant(time.Second), Clock: clock}, fn)
+		if got := r.String(); got != "Success(1)" {
+			t.Errorf("got %q, want %q", got, "Success(1)")
+		}
+		if *calls != 1 || len(clock.sleeps) != 0 {
+			t.Errorf("got %d call(s) and sleeps %v, want 1 call and no sleeps", *calls, clock.sleeps)
+		}
+	})
+
+	t.Run("succeeds after retries", func(t *testing.T) {
+		clock := &fakeClock{}
+		fn, _ := failing(2, errFlaky)
+		p := Policy{MaxAttempts: 3, Backoff: Exponential(time.Second, 2, 0), Clock: clock}
+		r := Do(context.Background(), p, fn)
+		if got := r.String(); got != "Success(3)" {
+			t.Errorf("got %q, want %q", got, "Success(3)")
+		}
+		if got := fmt.Sprint(clock.sleeps); got != "[1s 2s]" {
+			t.Errorf("got sleeps %s, want %s", got, "[1s 2s]")
+		}
+	})
+
+	t.Run("gives up after max attempts", func(t *testing.T) {
+		clock := &fakeClock{}
+		fn, calls := failing(10, errFlaky)
+		r := Do(context.Background(), Policy{MaxAttempts: 3, Clock: clock}, fn)
+		var e *Error
+		if !errors.As(r.Error().GetOrZero(), &e) || e.Attempts != 3 {
+			t.Fatalf("got %v, want *Error after 3 attempts", r)
+		}
+		if !res.Is(r, errFlaky) {
+			t.Errorf("expected error to wrap %v", errFlaky)
+		}
+		if got, want := e.Error(), "retry: gave up after 3 attempt(s): flaky"; got != want {
+			t.Errorf("got %q, want %q", got, want)
+		}
+		if *calls != 3 {
+			t.Errorf("got %d call(s), want 3", *calls)
+		}
+	})
+
+	t.Run("gives up after max elapsed", func(t *testing.T) {
+		clock := &fakeClock{}
+		fn, calls := failing(10, errFlaky)
+		p := Policy{MaxElapsed: 5 * time.Second, Backoff: Constant(2 * time.Second), Clock: clock}
+		r := Do(context.Background(), p, fn)
+		var e *Error
+		if !errors.As(r.Error().GetOrZero(), &e) || e.Attempts != 3 {
+			t.Fatalf("got %v, want *Error after 3 attempts", r)
+		}
+		if *calls != 3 || len(clock.sleeps) != 2 {
+			t.Errorf("got %d call(s) and sleeps %v, want 3 calls and 2 sleeps", *calls, clock.sleeps)
+		}
+	})
+
+	t.Run("does not retry non-retryable error", func(t *testing.T) {
+		errFatal := errors.New("fatal")
+		fn, calls := failing(10, errFatal)
+		p := Policy{MaxAttempts: 3, Retryable: On(errFlaky), Clock: &fakeClock{}}
+		r := Do(context.Background(), p, fn)
+		if r.Error().GetOrZero() != errFatal || *calls != 1 {
+			t.Errorf("got %v after %d call(s), want Failure(fatal) after 1 call", r, *calls)
+		}
+	})
+
+	t.Run("does not retry permanent error", func(t *testing.T) {
+		fn, calls := failing(10, Permanent(errFlaky))
+		r := Do(context.Background(), Policy{MaxAttempts: 3, Clock: &fakeClock{}}, fn)
+		if r.Error().GetOrZero() != errFlaky || *calls != 1 {
+			t.Errorf("got %v after %d call(s), want Failure(flaky) after 1 call", r, *calls)
+		}
+	})
+
+	t.Run("does not retry wrapped permanent error", func(t *testing.T) {
+		err := fmt.Errorf("wrapped: %w", Permanent(errFlaky))
+		fn, calls := failing(10, err)
+		r := Do(context.Background(), Policy{MaxAttempts: 3, Clock: &fakeClock{}}, fn)
+		if r.Error().GetOrZero() != err || !res.Is(r, errFlaky) || *calls != 1 {
+			t.Errorf("got %v after %d call(s), want Failure(wrapped: flaky) after 1 call", r, *calls)
+		}
+		var target *Error
+		if got := r.Error().GetOrZero().Error(); got != "wrapped: flaky" || res.As(r, &target) {
+			t.Errorf("got %q, want %q unaffected by the marker", got, "wrapped: flaky")
+		}
+		var pe *pathError
+		wrapped := fmt.Errorf("wrapped: %w", Permanent(&pathError{path: "x"}))
+		fn, _ = failing(10, wrapped)
+		if r := Do(context.Background(), Policy{Clock: &fakeClock{}}, fn); !res.As(r, &pe) || pe.path != "x" {
+			t.Errorf("got %v, want error matching *pathError through the marker", r)
+		}
+	})
+
+	t.Run("stops when context is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		calls := 0
+		fn := func(context.Context) (int, error) {
+			calls++
+			cancel()
+			return 0, errFlaky
+		}
+		r := Do(ctx, Policy{Clock: &fakeClock{}}, fn)
+		if !res.Is(r, errFlaky) || !res.Is(r, context.Canceled) || calls != 1 {
+			t.Errorf("got %v after %d call(s), want failure wrapping flaky and context.Canceled after 1 call", r, calls)
+		}
+	})
+
+	t.Run("does not call function when context is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		fn, calls := failing(0, errFlaky)
+		r := Do(ctx, Policy{}, fn)
+		if !res.Is(r, context.Canceled) || *calls != 0 {
+			t.Errorf("got %v after %d call(s), want failure wrapping context.Canceled after 0 calls", r, *calls)
+		}
+	})
+
+	t.Run("system clock", func(t *testing.T) {
+		fn, _ := failing(1, errFlaky)
+		r := Do(context.Background(), Policy{MaxAttempts: 2, Backoff: Constant(time.Millisecond)}, fn)
+		if got := r.String(); got != "Success(2)" {
+			t.Errorf("got %q, want %q", got, "Success(2)")
+		}
+	})
+}
+
+func TestOn(t *testing.T) {
+	errOther := errors.New("other")
+	f := On(errFlaky)
+	if !f(fmt.Errorf("wrapped: %w", errFlaky)) {
+		t.Errorf("expected wrapped error to be retryable")
+	}
+	if f(errOther) {
+		t.Errorf("expected other error not to be retryable")
+	}
+
+	f = Except(errFlaky)
+	if f(errFlaky) || !f(errOther) {
+		t.Errorf("expected Except to invert On")
+	}
+}
+
+func TestPermanent(t *testing.T) {
+	if Permanent(nil) != nil {
+		t.Errorf("expected nil")
+	}
+	err := Permanent(errFlaky)
+	if !errors.Is(err, errFlaky) || err.Error() != "flaky" {
+		t.Errorf("got %v, want error wrapping flaky", err)
+	}
+}
+
+func TestSystemClock(t *testing.T) {
+	c := SystemClock()
+	start := c.Now()
+	if err := c.Sleep(context.Background(), time.Millisecond); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if c.Now().Sub(start) < time.Millisecond {
+		t.Errorf("expected at least 1ms to elapse")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := c.Sleep(ctx, time.Hour); !errors.Is(err, context.Canceled) {
+		t.Errorf("got %v, want %v", err, context.Canceled)
+	}
+}
+
+// pathError is an error type for tests matching errors with errors.As.
+type pathError struct {
+	path string
+}
+
+func (e *pathError) Error() string {
+	return "bad path " + e.path
+}
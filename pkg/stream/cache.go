@@ -0,0 +1,246 @@
+package stream
+
+import (
+	"iter"
+	"sync"
+)
+
+// Cache returns a stream that caches elements from the given stream.
+// Elements are cached lazily as they are consumed; a consumer that stops early only causes the consumed elements to be cached.
+// Subsequent calls to the returned stream replay the cache, and read from the given stream again if the cache is incomplete, skipping the cached elements.
+// The returned stream is safe for concurrent use.
+//
+// Unlike CacheWith, Cache provides no way to release the given stream, so it does not hold it open between calls.
+// The given stream must therefore produce the same elements on every call if the cached stream may be stopped early, and called again.
+// Use CacheWith for streams that cannot be read twice, such as those created by FromChannel.
+//
+// Example usage:
+//
+//	s := stream.Cache(stream.Of(1, 2, 3))
+//	out := stream.DebugString(stream.Limit(s, 2)) // "<1, 2>" (1 and 2 are cached)
+//	out = stream.DebugString(s) // "<1, 2, 3>" (1 and 2 are replayed from the cache; 3 is read from the source and cached)
+func Cache[E any](s Stream[E]) Stream[E] {
+	return newStreamCache(s, 0, false).stream
+}
+
+// CacheOptions configures the behaviour of a stream returned by CacheWith.
+type CacheOptions struct {
+	// MaxSize is the maximum number of elements retained by the cache.
+	// The cache retains the first MaxSize elements of the source stream; no element is ever evicted to make room for later ones.
+	// Elements beyond the first MaxSize elements are not retained: the first call to the cached stream that reaches them reads them from the source stream where it left off, and later calls read them by calling the source stream again and skipping the first MaxSize elements, which requires the source stream to produce the same elements on every call.
+	// If MaxSize is zero or negative, the cache is unbounded.
+	MaxSize int
+}
+
+// CacheHandle provides control over the cache backing a stream returned by CacheWith.
+type CacheHandle[E any] struct {
+	c *streamCache[E]
+}
+
+// Invalidate discards all cached elements, and releases the source stream if it is held open.
+// The next call to the cached stream reads from the source stream again.
+// Calls to the cached stream that are in progress are unaffected, but do not repopulate the cache.
+func (h CacheHandle[E]) Invalidate() {
+	h.c.invalidate()
+}
+
+// Len returns the number of elements currently held in the cache.
+func (h CacheHandle[E]) Len() int {
+	return h.c.len()
+}
+
+// CacheWith returns a stream that caches elements from the given stream, using the given CacheOptions, along with a CacheHandle to control the cache.
+//
+// Elements are cached lazily as they are consumed.
+// If a consumer stops early, only the elements seen by that consumer are cached, and the source stream is not exhausted.
+// Instead, the source stream is held open, and subsequent calls to the returned stream replay the cached elements, then resume reading from the source stream where the previous call left off.
+// The source stream is called at most once per generation of the cache (see CacheHandle.Invalidate), so non-idempotent streams, such as those created by FromChannel, lose no elements.
+// A source stream that is held open is released once it is exhausted, or once the cache is invalidated; call CacheHandle.Invalidate to release a cache that is no longer needed.
+//
+// The returned stream is safe for concurrent use by multiple goroutines.
+// No lock is held while elements are yielded to the consumer, so consumers may freely call the cached stream again.
+// Reading the source stream does not block calls that only replay cached elements.
+//
+// Example usage:
+//
+//	s, h := stream.CacheWith(stream.Of(1, 2, 3), stream.CacheOptions{MaxSize: 2})
+//	out := stream.DebugString(s) // "<1, 2, 3>" (only 1 and 2 are cached)
+//	n := h.Len() // 2
+//	h.Invalidate()
+//	n = h.Len() // 0
+func CacheWith[E any](s Stream[E], opts CacheOptions) (Stream[E], CacheHandle[E]) {
+	c := newStreamCache(s, opts.MaxSize, true)
+	return c.stream, CacheHandle[E]{c: c}
+}
+
+func newStreamCache[E any](s Stream[E], maxSize int, holdOpen bool) *streamCache[E] {
+	return &streamCache[E]{
+		source:   s,
+		maxSize:  maxSize,
+		holdOpen: holdOpen,
+		cur:      &cacheState[E]{},
+	}
+}
+
+// streamCache holds the current generation of the cache of a source stream.
+type streamCache[E any] struct {
+	source   Stream[E]
+	maxSize  int
+	holdOpen bool // True if the cursor is kept open between calls; otherwise, it is released once no call is in progress.
+	mu       sync.Mutex
+	cur      *cacheState[E]
+}
+
+// cacheState holds one generation of the cache, and the cursor over the source stream from which it is extended.
+// Invalidation replaces the current generation, while calls in progress keep using the one they started with.
+// The cached elements are only ever appended, so elements at a given position never change.
+//
+// The cursor is read while holding the pull lock, but not the lock of the streamCache, so that reading the source does not block calls replaying cached elements.
+// The cursor is only released by the call holding the pull lock, or once no call is in progress, so it is never released while being read.
+type cacheState[E any] struct {
+	pull     sync.Mutex
+	elems    []E
+	next     func() (E, bool) // Cursor over the source stream, positioned after the cached elements; nil if not open.
+	stop     func()
+	complete bool // True if elems holds every element of the source stream.
+	active   int  // Number of calls in progress.
+	detached bool // True once the generation was invalidated.
+}
+
+func (c *streamCache[E]) stream(yield Consumer[E]) {
+	st := c.acquire()
+	defer c.release(st)
+	for pos := 0; ; pos++ {
+		e, ok, full := c.get(st, pos)
+		if full {
+			c.overflow(st, pos, yield)
+			return
+		}
+		if !ok || !yield(e) {
+			return // Source exhausted, or consumer saw enough.
+		}
+	}
+}
+
+func (c *streamCache[E]) acquire() *cacheState[E] {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	st := c.cur
+	st.active++
+	return st
+}
+
+// release ends a call using the given generation, releasing its cursor once no call is in progress, if the generation was invalidated or the cursor is not held open.
+func (c *streamCache[E]) release(st *cacheState[E]) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	st.active--
+	if st.active == 0 && (st.detached || !c.holdOpen) {
+		st.close()
+	}
+}
+
+// get returns the element at the given position, reading it from the source stream, and caching it, if it is not cached yet.
+// If the element is beyond the capacity of the cache, full is true and no element is returned.
+func (c *streamCache[E]) get(st *cacheState[E], pos int) (e E, ok bool, full bool) {
+	if e, ok, full, done := c.lookup(st, pos); done {
+		return e, ok, full
+	}
+
+	st.pull.Lock()
+	defer st.pull.Unlock()
+	if e, ok, full, done := c.lookup(st, pos); done {
+		return e, ok, full // Read by a concurrent call while waiting for the pull lock.
+	}
+	c.mu.Lock()
+	if st.next == nil {
+		// Open the cursor after the cached elements; they are skipped if the source stream was read before.
+		st.next, st.stop = iter.Pull(ToIterSeq(Skip(c.source, int64(len(st.elems)))))
+	}
+	next := st.next
+	c.mu.Unlock()
+
+	e, ok = next()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !ok {
+		st.complete = true
+		st.close()
+		return e, false, false
+	}
+	st.elems = append(st.elems, e)
+	return e, true, false
+}
+
+// lookup returns the element at the given position if it is cached, or if the position is past the end of the source stream or beyond the capacity of the cache.
+// Otherwise, done is false, and the element must be read from the source stream.
+func (c *streamCache[E]) lookup(st *cacheState[E], pos int) (e E, ok bool, full bool, done bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	switch {
+	case pos < len(st.elems):
+		return st.elems[pos], true, false, true
+	case st.complete:
+		return e, false, false, true
+	case c.maxSize > 0 && len(st.elems) >= c.maxSize:
+		return e, false, true, true
+	}
+	return e, false, false, false
+}
+
+// overflow yields the elements beyond the capacity of the cache, which is full at the given position.
+// The first call to get there takes over the cursor, if it is open, so that the remaining elements are read without loss; other calls read the source stream again, skipping the cached elements.
+func (c *streamCache[E]) overflow(st *cacheState[E], pos int, yield Consumer[E]) {
+	st.pull.Lock()
+	c.mu.Lock()
+	next, stop := st.next, st.stop
+	st.next, st.stop = nil, nil
+	c.mu.Unlock()
+	st.pull.Unlock()
+
+	if next != nil {
+		defer stop()
+		for n := 0; ; n++ {
+			e, ok := next()
+			if !ok {
+				if n == 0 {
+					c.mu.Lock()
+					st.complete = true // The source had no elements beyond the cache after all.
+					c.mu.Unlock()
+				}
+				return
+			}
+			if !yield(e) {
+				return // Consumer saw enough.
+			}
+		}
+	}
+
+	Skip(c.source, int64(pos))(yield)
+}
+
+func (c *streamCache[E]) invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	old := c.cur
+	old.detached = true
+	if old.active == 0 {
+		old.close()
+	}
+	c.cur = &cacheState[E]{}
+}
+
+func (c *streamCache[E]) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.cur.elems)
+}
+
+// close releases the cursor, if it is open.
+func (st *cacheState[E]) close() {
+	if st.stop != nil {
+		st.stop()
+	}
+	st.next, st.stop = nil, nil
+}
@@ -0,0 +1,276 @@
+package stream
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/jpfourny/papaya/v2/internal/assert"
+)
+
+func TestCache(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		s := Cache(Empty[int]())
+		got := CollectSlice(s)
+		var want []int
+		assert.ElementsMatch(t, got, want)
+	})
+
+	t.Run("non-empty", func(t *testing.T) {
+		s := Cache(Of(1, 2, 3))
+		got := CollectSlice(s)
+		want := []int{1, 2, 3}
+		assert.ElementsMatch(t, got, want)
+	})
+
+	t.Run("limited", func(t *testing.T) {
+		s, h := CacheWith(Of(1, 2, 3), CacheOptions{})
+		got := CollectSlice(Limit(s, 2)) // Stops stream after 2 elements.
+		want := []int{1, 2}
+		assert.ElementsMatch(t, got, want)
+		if h.Len() != 2 {
+			t.Errorf("got cache length %d, want %d", h.Len(), 2) // Only the consumed elements are cached.
+		}
+
+		got = CollectSlice(s) // Replay from cache without the limit.
+		want = []int{1, 2, 3} // 1 and 2 are replayed from the cache; 3 is read from the source where the first call stopped.
+		assert.ElementsMatch(t, got, want)
+		if h.Len() != 3 {
+			t.Errorf("got cache length %d, want %d", h.Len(), 3)
+		}
+
+		got = CollectSlice(Limit(s, 1)) // Stops stream after 1 elements.
+		want = []int{1}                 // Limit is applied to the cache.
+		assert.ElementsMatch(t, got, want)
+	})
+	t.Run("released-between-calls", func(t *testing.T) {
+		var calls atomic.Int64
+		src := func(yield Consumer[int]) {
+			calls.Add(1)
+			Of(1, 2, 3)(yield)
+		}
+		s := Cache[int](src)
+
+		got := CollectSlice(Limit(s, 2)) // Source is released when the call stops.
+		assert.ElementsMatch(t, got, []int{1, 2})
+
+		got = CollectSlice(s) // Source is called again, skipping the cached elements.
+		assert.ElementsMatch(t, got, []int{1, 2, 3})
+		if calls.Load() != 2 {
+			t.Errorf("got %d calls to source, want %d", calls.Load(), 2)
+		}
+
+		got = CollectSlice(s) // Replays everything; source is not called again.
+		assert.ElementsMatch(t, got, []int{1, 2, 3})
+		if calls.Load() != 2 {
+			t.Errorf("got %d calls to source, want %d", calls.Load(), 2)
+		}
+	})
+}
+
+func TestCacheWith(t *testing.T) {
+	counting := func(calls *atomic.Int64, n *atomic.Int64, s Stream[int]) Stream[int] {
+		return func(yield Consumer[int]) {
+			calls.Add(1)
+			s(func(e int) bool {
+				n.Add(1)
+				return yield(e)
+			})
+		}
+	}
+
+	t.Run("lazy", func(t *testing.T) {
+		var calls, n atomic.Int64
+		s, h := CacheWith(counting(&calls, &n, Of(1, 2, 3, 4)), CacheOptions{})
+
+		got := CollectSlice(Limit(s, 2))
+		assert.ElementsMatch(t, got, []int{1, 2})
+		if n.Load() != 2 {
+			t.Errorf("got %d elements read from source, want %d", n.Load(), 2)
+		}
+		if h.Len() != 2 {
+			t.Errorf("got cache length %d, want %d", h.Len(), 2)
+		}
+
+		got = CollectSlice(s) // Replays 1 and 2; resumes the source to read 3 and 4.
+		assert.ElementsMatch(t, got, []int{1, 2, 3, 4})
+		if h.Len() != 4 {
+			t.Errorf("got cache length %d, want %d", h.Len(), 4)
+		}
+		if n.Load() != 4 {
+			t.Errorf("got %d elements read from source, want %d", n.Load(), 4)
+		}
+
+		got = CollectSlice(s) // Replays everything; source is not called again.
+		assert.ElementsMatch(t, got, []int{1, 2, 3, 4})
+		if calls.Load() != 1 {
+			t.Errorf("got %d calls to source, want %d", calls.Load(), 1)
+		}
+	})
+
+	t.Run("one-shot", func(t *testing.T) {
+		ch := make(chan int, 5)
+		for i := 1; i <= 5; i++ {
+			ch <- i
+		}
+		close(ch)
+		s, h := CacheWith(FromChannel(ch), CacheOptions{})
+
+		got := CollectSlice(Limit(s, 2)) // Stops after 2 elements; the channel is not drained.
+		assert.ElementsMatch(t, got, []int{1, 2})
+		if h.Len() != 2 {
+			t.Errorf("got cache length %d, want %d", h.Len(), 2)
+		}
+
+		got = CollectSlice(s) // Replays 1 and 2; resumes reading the channel from 3, losing nothing.
+		assert.ElementsMatch(t, got, []int{1, 2, 3, 4, 5})
+		if h.Len() != 5 {
+			t.Errorf("got cache length %d, want %d", h.Len(), 5)
+		}
+
+		got = CollectSlice(s) // Replays everything.
+		assert.ElementsMatch(t, got, []int{1, 2, 3, 4, 5})
+	})
+
+	t.Run("one-shot-max-size", func(t *testing.T) {
+		ch := make(chan int, 4)
+		for i := 1; i <= 4; i++ {
+			ch <- i
+		}
+		close(ch)
+		s, h := CacheWith(FromChannel(ch), CacheOptions{MaxSize: 2})
+
+		got := CollectSlice(Limit(s, 1))
+		assert.ElementsMatch(t, got, []int{1})
+
+		got = CollectSlice(s) // Elements beyond the cap are read from where the source left off.
+		assert.ElementsMatch(t, got, []int{1, 2, 3, 4})
+		if h.Len() != 2 {
+			t.Errorf("got cache length %d, want %d", h.Len(), 2)
+		}
+	})
+
+	t.Run("replay-while-reading", func(t *testing.T) {
+		ch := make(chan int, 1)
+		ch <- 1
+		s, h := CacheWith(FromChannel(ch), CacheOptions{})
+		assert.ElementsMatch(t, CollectSlice(Limit(s, 1)), []int{1})
+
+		reading := make(chan struct{})
+		done := make(chan []int)
+		go func() {
+			done <- CollectSlice(Peek(s, func(e int) {
+				if e == 1 {
+					close(reading)
+				}
+			}))
+		}()
+		<-reading // The call above is now blocked reading the channel.
+
+		got := CollectSlice(Limit(s, 1)) // Replays the cache without waiting for the channel.
+		assert.ElementsMatch(t, got, []int{1})
+
+		ch <- 2
+		close(ch)
+		assert.ElementsMatch(t, <-done, []int{1, 2})
+		if h.Len() != 2 {
+			t.Errorf("got cache length %d, want %d", h.Len(), 2)
+		}
+	})
+
+	t.Run("max-size", func(t *testing.T) {
+		var calls, n atomic.Int64
+		s, h := CacheWith(counting(&calls, &n, Of(1, 2, 3, 4)), CacheOptions{MaxSize: 2})
+
+		got := CollectSlice(s)
+		assert.ElementsMatch(t, got, []int{1, 2, 3, 4})
+		if h.Len() != 2 {
+			t.Errorf("got cache length %d, want %d", h.Len(), 2)
+		}
+
+		got = CollectSlice(Limit(s, 2)) // Served from cache.
+		assert.ElementsMatch(t, got, []int{1, 2})
+		if calls.Load() != 1 {
+			t.Errorf("got %d calls to source, want %d", calls.Load(), 1)
+		}
+
+		got = CollectSlice(s) // Elements beyond the cap are not retained, so the source is called again.
+		assert.ElementsMatch(t, got, []int{1, 2, 3, 4})
+		if calls.Load() != 2 {
+			t.Errorf("got %d calls to source, want %d", calls.Load(), 2)
+		}
+	})
+
+	t.Run("invalidate", func(t *testing.T) {
+		var calls, n atomic.Int64
+		s, h := CacheWith(counting(&calls, &n, Of(1, 2, 3)), CacheOptions{})
+
+		got := CollectSlice(s)
+		assert.ElementsMatch(t, got, []int{1, 2, 3})
+		h.Invalidate()
+		if h.Len() != 0 {
+			t.Errorf("got cache length %d, want %d", h.Len(), 0)
+		}
+
+		got = CollectSlice(s)
+		assert.ElementsMatch(t, got, []int{1, 2, 3})
+		if calls.Load() != 2 {
+			t.Errorf("got %d calls to source, want %d", calls.Load(), 2)
+		}
+		if h.Len() != 3 {
+			t.Errorf("got cache length %d, want %d", h.Len(), 3)
+		}
+	})
+
+	t.Run("invalidate-in-progress", func(t *testing.T) {
+		s, h := CacheWith(Of(1, 2, 3), CacheOptions{})
+
+		var got []int
+		s(func(e int) bool {
+			got = append(got, e)
+			if e == 1 {
+				h.Invalidate() // In-progress call must not repopulate the cache.
+			}
+			return true
+		})
+		assert.ElementsMatch(t, got, []int{1, 2, 3})
+		if h.Len() != 0 {
+			t.Errorf("got cache length %d, want %d", h.Len(), 0)
+		}
+	})
+
+	t.Run("reentrant", func(t *testing.T) {
+		s, _ := CacheWith(Of(1, 2, 3), CacheOptions{})
+
+		var got []int
+		ForEach(s, func(e int) {
+			got = append(got, e)
+			got = append(got, CollectSlice(Limit(s, 1))...)
+		})
+		assert.ElementsMatch(t, got, []int{1, 1, 2, 1, 3, 1})
+	})
+
+	t.Run("concurrent", func(t *testing.T) {
+		src := CollectSlice(Interval(0, 1000, 1))
+		s, h := CacheWith(FromSlice(src), CacheOptions{})
+
+		var wg sync.WaitGroup
+		results := make([][]int, 8)
+		for i := range results {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				results[i] = CollectSlice(Limit(s, int64(100*(i+1))))
+			}()
+		}
+		wg.Wait()
+
+		for i, got := range results {
+			assert.ElementsMatch(t, got, src[:100*(i+1)])
+		}
+		assert.ElementsMatch(t, CollectSlice(s), src)
+		if h.Len() != 1000 {
+			t.Errorf("got cache length %d, want %d", h.Len(), 1000)
+		}
+	})
+}
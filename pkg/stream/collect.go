@@ -2,11 +2,25 @@ package stream
 
 import (
 	"context"
+	"github.com/jpfourny/papaya/v2/pkg/stream/collector"
 	"github.com/jpfourny/papaya/v2/pkg/stream/mapper"
 
 	"github.com/jpfourny/papaya/v2/pkg/pair"
 )
 
+// Collect reduces the elements of the stream into a result using the given collector.Collector.
+// The stream is fully consumed.
+//
+// Example usage:
+//
+//	out := stream.Collect(
+//	  stream.Of("a", "bb", "ccc"),
+//	  collector.Mapping(func(s string) int { return len(s) }, collector.Summing[int]()),
+//	) // 6
+func Collect[E, A, R any](s Stream[E], c collector.Collector[E, A, R]) R {
+	return Aggregate(s, c.Supply(), c.Accumulate, c.Finish)
+}
+
 // CollectSlice returns a slice containing all elements from the stream.
 // The stream is fully consumed.
 //
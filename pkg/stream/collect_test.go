@@ -6,6 +6,7 @@ import (
 
 	"github.com/jpfourny/papaya/v2/internal/assert"
 	"github.com/jpfourny/papaya/v2/pkg/pair"
+	"github.com/jpfourny/papaya/v2/pkg/stream/collector"
 )
 
 func TestCollectSlice(t *testing.T) {
@@ -152,3 +153,22 @@ func TestCollectChannelAsyncCtx(t *testing.T) {
 		}
 	})
 }
+
+func TestCollect(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		got := Collect(Empty[int](), collector.Summing[int]())
+		if got != 0 {
+			t.Fatalf("got %#v, want %#v", got, 0)
+		}
+	})
+
+	t.Run("non-empty", func(t *testing.T) {
+		got := Collect(
+			Of("a", "bb", "ccc"),
+			collector.Mapping(func(s string) int { return len(s) }, collector.Summing[int]()),
+		)
+		if got != 6 {
+			t.Fatalf("got %#v, want %#v", got, 6)
+		}
+	})
+}
@@ -0,0 +1,314 @@
+package collector
+
+import (
+	"strings"
+
+	"github.com/jpfourny/papaya/v2/internal/kvstore"
+	"github.com/jpfourny/papaya/v2/pkg/cmp"
+	"github.com/jpfourny/papaya/v2/pkg/constraint"
+	"github.com/jpfourny/papaya/v2/pkg/hash"
+	"github.com/jpfourny/papaya/v2/pkg/opt"
+	"github.com/jpfourny/papaya/v2/pkg/pair"
+)
+
+// Collector describes a reduction of values of type E into a result of type R, through an intermediate accumulated value of type A.
+// The accumulated value is created by Supply, then combined with each value by Accumulate, and finally transformed into the result by Finish.
+// Accumulate may modify and return the accumulated value it is given; a new one is supplied for each reduction.
+type Collector[E, A, R any] struct {
+	Supply     func() A
+	Accumulate func(a A, e E) A
+	Finish     func(a A) R
+}
+
+// New returns a Collector with the provided functions.
+//
+// Example usage:
+//
+//	c := collector.New(
+//	  func() int { return 0 },
+//	  func(a int, s string) int { return a + len(s) },
+//	  func(a int) int { return a },
+//	) // Collects the total length of strings.
+func New[E, A, R any](supply func() A, accumulate func(a A, e E) A, finish func(a A) R) Collector[E, A, R] {
+	return Collector[E, A, R]{
+		Supply:     supply,
+		Accumulate: accumulate,
+		Finish:     finish,
+	}
+}
+
+// ToSlice returns a Collector that collects values into a slice, in order.
+// The result is empty, not nil, if there are no values.
+//
+// Example usage:
+//
+//	out := stream.Collect(stream.Of(1, 2, 3), collector.ToSlice[int]()) // []int{1, 2, 3}
+func ToSlice[E any]() Collector[E, []E, []E] {
+	return New(
+		func() []E { return []E{} },
+		func(a []E, e E) []E { return append(a, e) },
+		identity[[]E],
+	)
+}
+
+// ToSet returns a Collector that collects distinct values of comparable type E into a set, represented by a map.
+//
+// Example usage:
+//
+//	out := stream.Collect(stream.Of(1, 2, 1), collector.ToSet[int]()) // map[int]struct{}{1: {}, 2: {}}
+func ToSet[E comparable]() Collector[E, map[E]struct{}, map[E]struct{}] {
+	return New(
+		func() map[E]struct{} { return map[E]struct{}{} },
+		func(a map[E]struct{}, e E) map[E]struct{} {
+			a[e] = struct{}{}
+			return a
+		},
+		identity[map[E]struct{}],
+	)
+}
+
+// ToSortedSet returns a Collector that collects distinct values, compared by the provided cmp.Comparer, into a slice in sorted order.
+//
+// Example usage:
+//
+//	out := stream.Collect(stream.Of(3, 1, 3), collector.ToSortedSet(cmp.Natural[int]())) // []int{1, 3}
+func ToSortedSet[E any](compare cmp.Comparer[E]) Collector[E, Store[E, struct{}], []E] {
+	return toSet(kvstore.SortedMaker[E, struct{}](compare))
+}
+
+// ToHashedSet returns a Collector that collects distinct values, hashed and compared by the provided hash.Hasher, into a slice in order of first occurrence.
+//
+// Example usage:
+//
+//	out := stream.Collect(stream.Of([]int{1}, []int{1}), collector.ToHashedSet(hash.Slice(hash.Integer[int]()))) // [][]int{{1}}
+func ToHashedSet[E any](hasher hash.Hasher[E]) Collector[E, Store[E, struct{}], []E] {
+	return toSet(kvstore.HashedMaker[E, struct{}](hasher))
+}
+
+func toSet[E any](kv kvstore.Maker[E, struct{}]) Collector[E, Store[E, struct{}], []E] {
+	return New(
+		supplyStore(kv),
+		func(a Store[E, struct{}], e E) Store[E, struct{}] {
+			a.kv.Put(e, struct{}{})
+			return a
+		},
+		func(a Store[E, struct{}]) []E {
+			es := make([]E, 0, a.kv.Size())
+			a.kv.ForEachKey(func(e E) bool {
+				es = append(es, e)
+				return true
+			})
+			return es
+		},
+	)
+}
+
+// Counting returns a Collector that counts values.
+//
+// Example usage:
+//
+//	out := stream.Collect(stream.Of("a", "b"), collector.Counting[string]()) // 2
+func Counting[E any]() Collector[E, int64, int64] {
+	return New(
+		func() int64 { return 0 },
+		func(a int64, _ E) int64 { return a + 1 },
+		identity[int64],
+	)
+}
+
+// Summing returns a Collector that sums numeric values.
+// The result is zero if there are no values.
+// To sum a numeric property of values, compose it with Mapping.
+//
+// Example usage:
+//
+//	out := stream.Collect(stream.Of(1, 2, 3), collector.Summing[int]()) // 6
+func Summing[E constraint.Numeric]() Collector[E, E, E] {
+	return New(
+		func() E { return 0 },
+		func(a E, e E) E { return a + e },
+		identity[E],
+	)
+}
+
+// MinBy returns a Collector that finds the minimum value, as determined by the provided cmp.Comparer.
+// The result is empty if there are no values; if several values are minimal, the first one is kept.
+//
+// Example usage:
+//
+//	out := stream.Collect(stream.Of(3, 1, 2), collector.MinBy(cmp.Natural[int]())) // Some(1)
+func MinBy[E any](compare cmp.Comparer[E]) Collector[E, opt.Optional[E], opt.Optional[E]] {
+	return reducing(func(a, e E) bool { return compare.LessThan(e, a) })
+}
+
+// MaxBy returns a Collector that finds the maximum value, as determined by the provided cmp.Comparer.
+// The result is empty if there are no values; if several values are maximal, the first one is kept.
+//
+// Example usage:
+//
+//	out := stream.Collect(stream.Of(3, 1, 2), collector.MaxBy(cmp.Natural[int]())) // Some(3)
+func MaxBy[E any](compare cmp.Comparer[E]) Collector[E, opt.Optional[E], opt.Optional[E]] {
+	return reducing(func(a, e E) bool { return compare.GreaterThan(e, a) })
+}
+
+// reducing returns a Collector that keeps the first value, replacing it with each later value for which replace returns true.
+func reducing[E any](replace func(a, e E) bool) Collector[E, opt.Optional[E], opt.Optional[E]] {
+	return New(
+		opt.Empty[E],
+		func(a opt.Optional[E], e E) opt.Optional[E] {
+			if v, ok := a.Get(); ok && !replace(v, e) {
+				return a
+			}
+			return opt.Of(e)
+		},
+		identity[opt.Optional[E]],
+	)
+}
+
+// Joining returns a Collector that concatenates strings, in order, placing the provided separator between them.
+//
+// Example usage:
+//
+//	out := stream.Collect(stream.Of("a", "b", "c"), collector.Joining(", ")) // "a, b, c"
+func Joining(sep string) Collector[string, []string, string] {
+	return New(
+		func() []string { return nil },
+		func(a []string, s string) []string { return append(a, s) },
+		func(a []string) string { return strings.Join(a, sep) },
+	)
+}
+
+// Mapping returns a Collector that maps each value with the provided function before passing it to the downstream Collector.
+//
+// Example usage:
+//
+//	out := stream.Collect(
+//	  stream.Of("a", "bb", "ccc"),
+//	  collector.Mapping(func(s string) int { return len(s) }, collector.Summing[int]()),
+//	) // 6
+func Mapping[E, F, A, R any](mapper func(E) F, downstream Collector[F, A, R]) Collector[E, A, R] {
+	return New(
+		downstream.Supply,
+		func(a A, e E) A { return downstream.Accumulate(a, mapper(e)) },
+		downstream.Finish,
+	)
+}
+
+// Filtering returns a Collector that passes only the values that satisfy the provided predicate to the downstream Collector.
+//
+// Example usage:
+//
+//	out := stream.Collect(
+//	  stream.Of(1, 2, 3, 4),
+//	  collector.Filtering(func(i int) bool { return i%2 == 0 }, collector.ToSlice[int]()),
+//	) // []int{2, 4}
+func Filtering[E, A, R any](pred func(E) bool, downstream Collector[E, A, R]) Collector[E, A, R] {
+	return New(
+		downstream.Supply,
+		func(a A, e E) A {
+			if pred(e) {
+				return downstream.Accumulate(a, e)
+			}
+			return a
+		},
+		downstream.Finish,
+	)
+}
+
+// GroupingBy returns a Collector that groups values by the key of comparable type K returned by the provided function, and collects the values of each group with the downstream Collector.
+// The result maps each key to the result of the downstream Collector for its group.
+// It may itself be used as a downstream Collector, for nested grouping.
+//
+// Example usage:
+//
+//	out := stream.Collect(
+//	  stream.Of("apple", "avocado", "banana"),
+//	  collector.GroupingBy(func(s string) byte { return s[0] }, collector.Counting[string]()),
+//	) // map[byte]int64{'a': 2, 'b': 1}
+func GroupingBy[E any, K comparable, A, R any](key func(E) K, downstream Collector[E, A, R]) Collector[E, map[K]A, map[K]R] {
+	return New(
+		func() map[K]A { return map[K]A{} },
+		func(a map[K]A, e E) map[K]A {
+			k := key(e)
+			g, ok := a[k]
+			if !ok {
+				g = downstream.Supply()
+			}
+			a[k] = downstream.Accumulate(g, e)
+			return a
+		},
+		func(a map[K]A) map[K]R {
+			m := make(map[K]R, len(a))
+			for k, v := range a {
+				m[k] = downstream.Finish(v)
+			}
+			return m
+		},
+	)
+}
+
+// GroupingBySortedKey returns a Collector that groups values by the key returned by the provided function, compared by the provided cmp.Comparer, and collects the values of each group with the downstream Collector.
+// The result is a slice of key-result pairs, ordered by key.
+//
+// Example usage:
+//
+//	out := stream.Collect(
+//	  stream.Of("banana", "apple", "avocado"),
+//	  collector.GroupingBySortedKey(func(s string) byte { return s[0] }, cmp.Natural[byte](), collector.Counting[string]()),
+//	) // []pair.Pair[byte, int64]{('a', 2), ('b', 1)}
+func GroupingBySortedKey[E, K, A, R any](key func(E) K, keyCompare cmp.Comparer[K], downstream Collector[E, A, R]) Collector[E, Store[K, A], []pair.Pair[K, R]] {
+	return grouping(key, kvstore.SortedMaker[K, A](keyCompare), downstream)
+}
+
+// GroupingByHashedKey returns a Collector that groups values by the key returned by the provided function, hashed and compared by the provided hash.Hasher, and collects the values of each group with the downstream Collector.
+// The result is a slice of key-result pairs, ordered by the first occurrence of their key.
+//
+// Example usage:
+//
+//	out := stream.Collect(
+//	  stream.Of([]int{1}, []int{2}, []int{1}),
+//	  collector.GroupingByHashedKey(func(s []int) []int { return s }, hash.Slice(hash.Integer[int]()), collector.Counting[[]int]()),
+//	) // []pair.Pair[[]int, int64]{([1], 2), ([2], 1)}
+func GroupingByHashedKey[E, K, A, R any](key func(E) K, keyHasher hash.Hasher[K], downstream Collector[E, A, R]) Collector[E, Store[K, A], []pair.Pair[K, R]] {
+	return grouping(key, kvstore.HashedMaker[K, A](keyHasher), downstream)
+}
+
+func grouping[E, K, A, R any](key func(E) K, kv kvstore.Maker[K, A], downstream Collector[E, A, R]) Collector[E, Store[K, A], []pair.Pair[K, R]] {
+	return New(
+		supplyStore(kv),
+		func(a Store[K, A], e E) Store[K, A] {
+			k := key(e)
+			g := a.kv.Get(k).GetOrFunc(downstream.Supply)
+			a.kv.Put(k, downstream.Accumulate(g, e))
+			return a
+		},
+		func(a Store[K, A]) []pair.Pair[K, R] {
+			ps := make([]pair.Pair[K, R], 0, a.kv.Size())
+			a.kv.ForEach(func(k K, v A) bool {
+				ps = append(ps, pair.Of(k, downstream.Finish(v)))
+				return true
+			})
+			return ps
+		},
+	)
+}
+
+// Store is the accumulated value of the Collectors that keep values in a sorted or hashed store, such as ToSortedSet and GroupingBySortedKey.
+// Its contents are only accessible to those Collectors, but it allows their types to be named.
+//
+// Example usage:
+//
+//	var c collector.Collector[string, collector.Store[string, struct{}], []string] = collector.ToSortedSet(cmp.Natural[string]())
+type Store[K, V any] struct {
+	kv kvstore.Store[K, V]
+}
+
+func supplyStore[K, V any](kv kvstore.Maker[K, V]) func() Store[K, V] {
+	return func() Store[K, V] {
+		return Store[K, V]{kv: kv()}
+	}
+}
+
+func identity[E any](e E) E {
+	return e
+}
@@ -0,0 +1,190 @@
+package collector
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jpfourny/papaya/v2/pkg/cmp"
+	"github.com/jpfourny/papaya/v2/pkg/hash"
+	"github.com/jpfourny/papaya/v2/pkg/opt"
+	"github.com/jpfourny/papaya/v2/pkg/pair"
+)
+
+// collect runs the given Collector against the given values.
+func collect[E, A, R any](c Collector[E, A, R], es ...E) R {
+	a := c.Supply()
+	for _, e := range es {
+		a = c.Accumulate(a, e)
+	}
+	return c.Finish(a)
+}
+
+func TestNew(t *testing.T) {
+	c := New(
+		func() int { return 0 },
+		func(a int, s string) int { return a + len(s) },
+		func(a int) int { return a * 2 },
+	)
+	if got := collect(c, "a", "bb"); got != 6 {
+		t.Errorf("got %#v, want %#v", got, 6)
+	}
+}
+
+func TestToSlice(t *testing.T) {
+	if got := collect(ToSlice[int]()); got == nil || len(got) != 0 {
+		t.Errorf("got %#v, want empty slice", got)
+	}
+	got := collect(ToSlice[int](), 3, 1, 2)
+	want := []int{3, 1, 2}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %#v, want %#v", got, want)
+	}
+}
+
+func TestToSet(t *testing.T) {
+	got := collect(ToSet[int](), 1, 2, 1)
+	want := map[int]struct{}{1: {}, 2: {}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %#v, want %#v", got, want)
+	}
+}
+
+func TestToSortedSet(t *testing.T) {
+	got := collect(ToSortedSet(cmp.Natural[int]()), 3, 1, 3, 2)
+	want := []int{1, 2, 3}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %#v, want %#v", got, want)
+	}
+}
+
+func TestStore(t *testing.T) {
+	// The types of Collectors using a Store can be named, eg: in a helper returning one.
+	names := func() Collector[string, Store[byte, []string], []pair.Pair[byte, []string]] {
+		return GroupingBySortedKey(func(s string) byte { return s[0] }, cmp.Natural[byte](), ToSlice[string]())
+	}
+	var set Collector[int, Store[int, struct{}], []int] = ToHashedSet(hash.Integer[int]())
+
+	got := collect(names(), "bob", "al")
+	want := []pair.Pair[byte, []string]{pair.Of(byte('a'), []string{"al"}), pair.Of(byte('b'), []string{"bob"})}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %#v, want %#v", got, want)
+	}
+	if got := collect(set, 2, 1, 2); !reflect.DeepEqual(got, []int{2, 1}) {
+		t.Errorf("got %#v, want %#v", got, []int{2, 1})
+	}
+}
+
+func TestToHashedSet(t *testing.T) {
+	got := collect(ToHashedSet(hash.Slice(hash.Integer[int]())), []int{2}, []int{1}, []int{2})
+	want := [][]int{{2}, {1}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %#v, want %#v", got, want)
+	}
+}
+
+func TestCounting(t *testing.T) {
+	if got := collect(Counting[string]()); got != 0 {
+		t.Errorf("got %#v, want %#v", got, 0)
+	}
+	if got := collect(Counting[string](), "a", "b"); got != 2 {
+		t.Errorf("got %#v, want %#v", got, 2)
+	}
+}
+
+func TestSumming(t *testing.T) {
+	if got := collect(Summing[float64](), 1.5, 2.5); got != 4 {
+		t.Errorf("got %#v, want %#v", got, 4.0)
+	}
+}
+
+func TestMinBy(t *testing.T) {
+	c := MinBy(cmp.Comparing(func(p pair.Pair[int, string]) int { return p.First() }))
+	if got := collect(c); got.Present() {
+		t.Errorf("got %#v, want empty", got)
+	}
+	got := collect(c, pair.Of(2, "a"), pair.Of(1, "b"), pair.Of(1, "c"))
+	want := opt.Of(pair.Of(1, "b")) // First of the minimal values.
+	if got != want {
+		t.Errorf("got %#v, want %#v", got, want)
+	}
+}
+
+func TestMaxBy(t *testing.T) {
+	c := MaxBy(cmp.Comparing(func(p pair.Pair[int, string]) int { return p.First() }))
+	got := collect(c, pair.Of(1, "a"), pair.Of(2, "b"), pair.Of(2, "c"))
+	want := opt.Of(pair.Of(2, "b")) // First of the maximal values.
+	if got != want {
+		t.Errorf("got %#v, want %#v", got, want)
+	}
+}
+
+func TestJoining(t *testing.T) {
+	if got := collect(Joining(", ")); got != "" {
+		t.Errorf("got %#v, want %#v", got, "")
+	}
+	if got := collect(Joining(", "), "a", "b", "c"); got != "a, b, c" {
+		t.Errorf("got %#v, want %#v", got, "a, b, c")
+	}
+}
+
+func TestMapping(t *testing.T) {
+	c := Mapping(func(s string) int { return len(s) }, Summing[int]())
+	if got := collect(c, "a", "bb", "ccc"); got != 6 {
+		t.Errorf("got %#v, want %#v", got, 6)
+	}
+}
+
+func TestFiltering(t *testing.T) {
+	c := Filtering(func(i int) bool { return i%2 == 0 }, ToSlice[int]())
+	got := collect(c, 1, 2, 3, 4)
+	want := []int{2, 4}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %#v, want %#v", got, want)
+	}
+}
+
+func TestGroupingBy(t *testing.T) {
+	c := GroupingBy(func(s string) byte { return s[0] }, Counting[string]())
+	got := collect(c, "apple", "banana", "avocado")
+	want := map[byte]int64{'a': 2, 'b': 1}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %#v, want %#v", got, want)
+	}
+}
+
+func TestGroupingBy_nested(t *testing.T) {
+	c := GroupingBy(
+		func(s string) byte { return s[0] },
+		GroupingBySortedKey(func(s string) int { return len(s) }, cmp.Natural[int](), ToSlice[string]()),
+	)
+	got := collect(c, "ab", "abc", "b", "ad", "bcd")
+	want := map[byte][]pair.Pair[int, []string]{
+		'a': {pair.Of(2, []string{"ab", "ad"}), pair.Of(3, []string{"abc"})},
+		'b': {pair.Of(1, []string{"b"}), pair.Of(3, []string{"bcd"})},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %#v, want %#v", got, want)
+	}
+}
+
+func TestGroupingBySortedKey(t *testing.T) {
+	c := GroupingBySortedKey(func(s string) byte { return s[0] }, cmp.Natural[byte](), Joining("+"))
+	got := collect(c, "banana", "apple", "avocado", "blueberry")
+	want := []pair.Pair[byte, string]{pair.Of(byte('a'), "apple+avocado"), pair.Of(byte('b'), "banana+blueberry")}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %#v, want %#v", got, want)
+	}
+}
+
+func TestGroupingByHashedKey(t *testing.T) {
+	c := GroupingByHashedKey(
+		func(s []int) []int { return s[:1] },
+		hash.Slice(hash.Integer[int]()),
+		Mapping(func(s []int) int { return len(s) }, Summing[int]()),
+	)
+	got := collect(c, []int{2, 0}, []int{1}, []int{2, 0, 0})
+	want := []pair.Pair[[]int, int]{pair.Of([]int{2}, 5), pair.Of([]int{1}, 1)}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %#v, want %#v", got, want)
+	}
+}
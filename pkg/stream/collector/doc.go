@@ -0,0 +1,4 @@
+// Package collector provides Collectors, which describe how to reduce a stream of values into a result, and which may be composed.
+//
+// A Collector is run against a stream with stream.Collect, or used as the downstream Collector of a grouping operation such as stream.GroupingBy or GroupingBy, to aggregate the values of each group.
+package collector
@@ -7,6 +7,7 @@ import (
 	"github.com/jpfourny/papaya/v2/pkg/pair"
 	"github.com/jpfourny/papaya/v2/pkg/stream/mapper"
 	"github.com/jpfourny/papaya/v2/pkg/stream/pred"
+	"github.com/jpfourny/papaya/v2/pkg/tuple"
 )
 
 // Combiner represents a function that combines two elements of type E1 and E2 into an element of type F.
@@ -88,6 +89,32 @@ func Zip[E, F any](s1 Stream[E], s2 Stream[F]) Stream[pair.Pair[E, F]] {
 	return Combine(s1, s2, pair.Of[E, F])
 }
 
+// Zip3 returns a stream that combines the corresponding elements of three streams into tuples.
+// The resulting stream will have the same number of elements as the shortest of the input streams.
+//
+// Example usage:
+//
+//	s := stream.Zip3(stream.Of(1, 2, 3), stream.Of("foo", "bar"), stream.Of(true, false, true))
+//	out := stream.DebugString(s) // "<(1, "foo", true), (2, "bar", false)>"
+func Zip3[E1, E2, E3 any](s1 Stream[E1], s2 Stream[E2], s3 Stream[E3]) Stream[tuple.T3[E1, E2, E3]] {
+	return Combine(Zip(s1, s2), s3, func(p pair.Pair[E1, E2], e3 E3) tuple.T3[E1, E2, E3] {
+		return tuple.Of3(p.First(), p.Second(), e3)
+	})
+}
+
+// Zip4 returns a stream that combines the corresponding elements of four streams into tuples.
+// The resulting stream will have the same number of elements as the shortest of the input streams.
+//
+// Example usage:
+//
+//	s := stream.Zip4(stream.Of(1, 2), stream.Of("foo", "bar"), stream.Of(true, false), stream.Of(1.5, 2.5))
+//	out := stream.DebugString(s) // "<(1, "foo", true, 1.5), (2, "bar", false, 2.5)>"
+func Zip4[E1, E2, E3, E4 any](s1 Stream[E1], s2 Stream[E2], s3 Stream[E3], s4 Stream[E4]) Stream[tuple.T4[E1, E2, E3, E4]] {
+	return Combine(Zip3(s1, s2, s3), s4, func(t tuple.T3[E1, E2, E3], e4 E4) tuple.T4[E1, E2, E3, E4] {
+		return tuple.Of4(t.First(), t.Second(), t.Third(), e4)
+	})
+}
+
 // ZipWithIndex returns a stream that pairs each element in the input stream with its index, starting at the given offset.
 // The index type I must be an integer type.
 //
@@ -128,3 +155,21 @@ func UnzipFirst[E, F any](s Stream[pair.Pair[E, F]]) Stream[E] {
 func UnzipSecond[E, F any](s Stream[pair.Pair[E, F]]) Stream[F] {
 	return Map(s, pair.Pair[E, F].Second)
 }
+
+// Unzip3 returns three streams that contain the first, second and third elements, respectively, of each tuple in the input stream.
+// Each of the returned streams consumes the input stream independently.
+//
+// Example usage:
+//
+//	s1, s2, s3 := stream.Unzip3(
+//	  stream.Of(
+//	    tuple.Of3(1, "foo", true),
+//	    tuple.Of3(2, "bar", false),
+//	  ),
+//	)
+//	out := stream.DebugString(s2) // "<foo, bar>"
+func Unzip3[E1, E2, E3 any](s Stream[tuple.T3[E1, E2, E3]]) (Stream[E1], Stream[E2], Stream[E3]) {
+	return Map(s, tuple.T3[E1, E2, E3].First),
+		Map(s, tuple.T3[E1, E2, E3].Second),
+		Map(s, tuple.T3[E1, E2, E3].Third)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/jpfourny/papaya/v2/internal/assert"
 	"github.com/jpfourny/papaya/v2/pkg/opt"
 	"github.com/jpfourny/papaya/v2/pkg/pair"
+	"github.com/jpfourny/papaya/v2/pkg/tuple"
 )
 
 func TestCombine(t *testing.T) {
@@ -113,6 +114,42 @@ func TestZip(t *testing.T) {
 	})
 }
 
+func TestZip3(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		s := Zip3(Empty[int](), Of("foo"), Of(true))
+		got := CollectSlice(s)
+		var want []tuple.T3[int, string, bool]
+		assert.ElementsMatch(t, got, want)
+	})
+
+	t.Run("non-empty", func(t *testing.T) {
+		s := Zip3(Of(1, 2, 3), Of("foo", "bar"), Of(true, false, true))
+		got := CollectSlice(s)
+		want := []tuple.T3[int, string, bool]{
+			tuple.Of3(1, "foo", true),
+			tuple.Of3(2, "bar", false),
+		}
+		assert.ElementsMatch(t, got, want)
+	})
+
+	t.Run("limited", func(t *testing.T) {
+		s := Zip3(Of(1, 2, 3), Of("foo", "bar", "baz"), Of(true, false, true))
+		got := CollectSlice(Limit(s, 1))
+		want := []tuple.T3[int, string, bool]{tuple.Of3(1, "foo", true)}
+		assert.ElementsMatch(t, got, want)
+	})
+}
+
+func TestZip4(t *testing.T) {
+	s := Zip4(Of(1, 2), Of("foo", "bar"), Of(true, false, true), Of(1.5, 2.5))
+	got := CollectSlice(s)
+	want := []tuple.T4[int, string, bool, float64]{
+		tuple.Of4(1, "foo", true, 1.5),
+		tuple.Of4(2, "bar", false, 2.5),
+	}
+	assert.ElementsMatch(t, got, want)
+}
+
 func TestZipWithIndex(t *testing.T) {
 	t.Run("empty", func(t *testing.T) {
 		s := ZipWithIndex(Empty[int](), 0)
@@ -191,3 +228,10 @@ func TestUnzipSecond(t *testing.T) {
 		assert.ElementsMatch(t, got, want)
 	})
 }
+
+func TestUnzip3(t *testing.T) {
+	s1, s2, s3 := Unzip3(Of(tuple.Of3(1, "foo", true), tuple.Of3(2, "bar", false)))
+	assert.ElementsMatch(t, CollectSlice(s1), []int{1, 2})
+	assert.ElementsMatch(t, CollectSlice(s2), []string{"foo", "bar"})
+	assert.ElementsMatch(t, CollectSlice(s3), []bool{true, false})
+}
@@ -1,4 +1,9 @@
 // Package stream provides an abstraction for working with a stream of values supporting sequential computational operations.
 // Streams are lazy; computation on the source data is only performed when the terminal operation is initiated, and source elements are consumed only as needed.
 // It is inspired by Java's `Stream` class, but uses functional composition, rather than fluent-style chaining.
+//
+// Operators that compare elements come in several forms.
+// The plain form (eg: Distinct) requires a comparable type, the *By form (eg: DistinctBy) takes a cmp.Comparer and uses a sorted store, and the *ByHash form (eg: DistinctByHash) takes a hash.Hasher and uses a hashed store.
+// Operators that group by key are named after how the keys are stored: the *BySortedKey form (eg: GroupBySortedKey) takes a cmp.Comparer, and the *ByHashedKey form (eg: GroupByHashedKey) takes a hash.Hasher; likewise for *BySortedValue and *ByHashedValue.
+// The cmp.Comparer or hash.Hasher is passed after the streams, except in variadic operators (eg: IntersectionAllBy and IntersectionAllByHash), where it must come first.
 package stream
@@ -3,6 +3,7 @@ package stream
 import (
 	"github.com/jpfourny/papaya/v2/internal/kvstore"
 	"github.com/jpfourny/papaya/v2/pkg/cmp"
+	"github.com/jpfourny/papaya/v2/pkg/hash"
 	"github.com/jpfourny/papaya/v2/pkg/pair"
 )
 
@@ -123,6 +124,17 @@ func DistinctBy[E any](s Stream[E], compare cmp.Comparer[E]) Stream[E] {
 	return distinct(s, kvstore.SortedMaker[E, struct{}](compare))
 }
 
+// DistinctByHash returns a stream that only contains distinct elements using the given hash.Hasher to hash and compare elements.
+// Unlike DistinctBy, it only requires equality, not a total order, and it runs in O(1) time per element.
+//
+// Example usage:
+//
+//	s := stream.DistinctByHash(stream.Of([]int{1, 2}, []int{1, 2}, []int{3}), hash.Slice(hash.Integer[int]()))
+//	out := stream.DebugString(s) // "<[1 2], [3]>"
+func DistinctByHash[E any](s Stream[E], hasher hash.Hasher[E]) Stream[E] {
+	return distinct(s, kvstore.HashedMaker[E, struct{}](hasher))
+}
+
 func distinct[E any](s Stream[E], kv kvstore.Maker[E, struct{}]) Stream[E] {
 	return func(yield Consumer[E]) {
 		seen := kv()
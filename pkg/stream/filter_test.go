@@ -2,7 +2,9 @@ package stream
 
 import (
 	"github.com/jpfourny/papaya/v2/pkg/cmp"
+	"github.com/jpfourny/papaya/v2/pkg/hash"
 	"github.com/jpfourny/papaya/v2/pkg/pair"
+	"reflect"
 	"testing"
 
 	"github.com/jpfourny/papaya/v2/internal/assert"
@@ -102,3 +104,12 @@ func TestDistinctBy(t *testing.T) {
 	want := []int{1, 2, 3}
 	assert.ElementsMatch(t, got, want)
 }
+
+func TestDistinctByHash(t *testing.T) {
+	s := DistinctByHash(Of([]int{1, 2}, []int{3}, []int{1, 2}, nil, []int{}), hash.Slice(hash.Integer[int]()))
+	got := CollectSlice(s)
+	want := [][]int{{1, 2}, {3}, nil}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %#v, want %#v", got, want)
+	}
+}
@@ -3,6 +3,7 @@ package stream
 import (
 	"context"
 
+	"github.com/jpfourny/papaya/v2/pkg/opt"
 	"github.com/jpfourny/papaya/v2/pkg/pair"
 )
 
@@ -128,6 +129,23 @@ func FromMapValues[K comparable, V any](m map[K]V) Stream[V] {
 	}
 }
 
+// FromOptional takes an opt.Optional and returns a stream of its value, if present; otherwise, an empty stream.
+//
+// Example usage:
+//
+//	s := stream.FromOptional(opt.Of(1))
+//	out := stream.DebugString(s) // "<1>"
+//
+//	s = stream.FromOptional(opt.Empty[int]())
+//	out = stream.DebugString(s) // "<>"
+func FromOptional[E any](o opt.Optional[E]) Stream[E] {
+	return func(yield Consumer[E]) {
+		if e, ok := o.Get(); ok {
+			yield(e)
+		}
+	}
+}
+
 // FromChannel returns a stream that reads elements from the given channel until it is closed.
 //
 //	Note: If the channel is not closed, the stream will block forever.
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/jpfourny/papaya/v2/internal/assert"
+	"github.com/jpfourny/papaya/v2/pkg/opt"
 	"github.com/jpfourny/papaya/v2/pkg/pair"
 )
 
@@ -201,6 +202,22 @@ func TestFromMapValues(t *testing.T) {
 	})
 }
 
+func TestFromOptional(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		s := FromOptional(opt.Empty[int]())
+		got := CollectSlice(s)
+		var want []int
+		assert.ElementsMatch(t, got, want)
+	})
+
+	t.Run("non-empty", func(t *testing.T) {
+		s := FromOptional(opt.Of(1))
+		got := CollectSlice(s)
+		want := []int{1}
+		assert.ElementsMatch(t, got, want)
+	})
+}
+
 func TestFromChannel(t *testing.T) {
 	t.Run("empty", func(t *testing.T) {
 		ch := make(chan int)
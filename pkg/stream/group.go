@@ -4,7 +4,9 @@ import (
 	"github.com/jpfourny/papaya/v2/internal/kvstore"
 	"github.com/jpfourny/papaya/v2/pkg/cmp"
 	"github.com/jpfourny/papaya/v2/pkg/constraint"
+	"github.com/jpfourny/papaya/v2/pkg/hash"
 	"github.com/jpfourny/papaya/v2/pkg/pair"
+	"github.com/jpfourny/papaya/v2/pkg/stream/collector"
 	"github.com/jpfourny/papaya/v2/pkg/stream/mapper"
 )
 
@@ -46,6 +48,26 @@ func GroupBySortedKey[K any, V any](s Stream[pair.Pair[K, V]], keyCompare cmp.Co
 	return groupByKey(s, kvstore.SortedMaker[K, []V](keyCompare))
 }
 
+// GroupByHashedKey returns a stream that values key-value pairs by key using the given hash.Hasher to hash and compare keys.
+// The resulting stream contains key-value pairs where the key is the same, and the value is a slice of all the values that had that key.
+// Unlike GroupBySortedKey, it only requires equality of keys, not a total order; keys that are not comparable (eg: slices) are supported.
+// The key-value pairs are ordered by the first occurrence of their key.
+//
+// Example usage:
+//
+//	s := stream.GroupByHashedKey(
+//	  stream.Of(
+//	    pair.Of([]string{"foo"}, 1),
+//	    pair.Of([]string{"bar"}, 2),
+//	    pair.Of([]string{"foo"}, 3),
+//	  ),
+//	  hash.Slice(hash.String[string]()), // Hash keys by their elements
+//	)
+//	out := stream.DebugString(s) // "<([foo], [1, 3]), ([bar], [2])>"
+func GroupByHashedKey[K any, V any](s Stream[pair.Pair[K, V]], keyHasher hash.Hasher[K]) Stream[pair.Pair[K, []V]] {
+	return groupByKey(s, kvstore.HashedMaker[K, []V](keyHasher))
+}
+
 func groupByKey[K any, V any](s Stream[pair.Pair[K, V]], kv kvstore.Maker[K, []V]) Stream[pair.Pair[K, []V]] {
 	return func(yield Consumer[pair.Pair[K, []V]]) {
 		groups := kv()
@@ -61,6 +83,66 @@ func groupByKey[K any, V any](s Stream[pair.Pair[K, V]], kv kvstore.Maker[K, []V
 	}
 }
 
+// GroupingBy returns a stream that groups elements by the key returned by the given Mapper, and collects the elements of each group using the given downstream collector.Collector.
+// The resulting stream contains key-value pairs where the value is the result of the downstream collector for the elements that had that key.
+// The key type K must be comparable.
+// The order of the key-value pairs is not guaranteed.
+//
+// Example usage:
+//
+//	s := stream.GroupingBy(
+//	  stream.Of("apple", "banana", "avocado"),
+//	  func(s string) string { return s[:1] }, // Group by first letter
+//	  collector.Counting[string](),           // Count the elements of each group
+//	)
+//	out := stream.DebugString(s) // "<(a, 2), (b, 1)>"
+func GroupingBy[E any, K comparable, A, R any](s Stream[E], key Mapper[E, K], downstream collector.Collector[E, A, R]) Stream[pair.Pair[K, R]] {
+	return func(yield Consumer[pair.Pair[K, R]]) {
+		FromMap(Collect(s, collector.GroupingBy(key, downstream)))(yield)
+	}
+}
+
+// GroupingBySortedKey returns a stream that groups elements by the key returned by the given Mapper, using the given cmp.Comparer to compare keys, and collects the elements of each group using the given downstream collector.Collector.
+// The resulting stream contains key-value pairs where the value is the result of the downstream collector for the elements that had that key.
+// The order of the key-value pairs is determined by the given cmp.Comparer.
+//
+// Example usage:
+//
+//	s := stream.GroupingBySortedKey(
+//	  stream.Of("banana", "apple", "avocado", "blueberry"),
+//	  func(s string) string { return s[:1] }, // Group by first letter
+//	  cmp.Natural[string](),                  // Compare keys naturally
+//	  collector.Joining("+"),                 // Join the elements of each group
+//	)
+//	out := stream.DebugString(s) // "<(a, apple+avocado), (b, banana+blueberry)>"
+func GroupingBySortedKey[E, K, A, R any](s Stream[E], key Mapper[E, K], keyCompare cmp.Comparer[K], downstream collector.Collector[E, A, R]) Stream[pair.Pair[K, R]] {
+	return groupingBy(s, collector.GroupingBySortedKey(key, keyCompare, downstream))
+}
+
+// GroupingByHashedKey returns a stream that groups elements by the key returned by the given Mapper, using the given hash.Hasher to hash and compare keys, and collects the elements of each group using the given downstream collector.Collector.
+// The resulting stream contains key-value pairs where the value is the result of the downstream collector for the elements that had that key.
+// The key-value pairs are ordered by the first occurrence of their key.
+//
+// Example usage:
+//
+//	s := stream.GroupingByHashedKey(
+//	  stream.Of([]int{1, 2}, []int{3}, []int{1, 2}),
+//	  mapper.Identity[[]int](),         // Group by the slices themselves
+//	  hash.Slice(hash.Integer[int]()), // Hash keys by their elements
+//	  collector.Counting[[]int](),     // Count the elements of each group
+//	)
+//	out := stream.DebugString(s) // "<([1, 2], 2), ([3], 1)>"
+func GroupingByHashedKey[E, K, A, R any](s Stream[E], key Mapper[E, K], keyHasher hash.Hasher[K], downstream collector.Collector[E, A, R]) Stream[pair.Pair[K, R]] {
+	return groupingBy(s, collector.GroupingByHashedKey(key, keyHasher, downstream))
+}
+
+// groupingBy returns a stream of the key-result pairs produced by the given grouping collector.Collector, which is applied when the stream is consumed.
+func groupingBy[E, A, K, R any](s Stream[E], c collector.Collector[E, A, []pair.Pair[K, R]]) Stream[pair.Pair[K, R]] {
+	return func(yield Consumer[pair.Pair[K, R]]) {
+		FromSlice(Collect(s, c))(yield)
+	}
+}
+
 // ReduceByKey returns a stream that reduces key-value pairs by key using the given Reducer to reduce values.
 // The resulting stream contains key-value pairs where the key is the same, and the value is the result of reducing all the values that had that key.
 // The order of the elements is not guaranteed.
@@ -104,6 +186,28 @@ func ReduceBySortedKey[K any, V any](s Stream[pair.Pair[K, V]], keyCompare cmp.C
 	return reduceByKey(s, kvstore.SortedMaker[K, V](keyCompare), reduce)
 }
 
+// ReduceByHashedKey returns a stream that reduces key-value pairs by key using the given hash.Hasher to hash and compare keys and the given Reducer to reduce values.
+// The resulting stream contains key-value pairs where the key is the same, and the value is the result of reducing all the values that had that key.
+// The elements are ordered by the first occurrence of their key.
+//
+// Example usage:
+//
+//	s := stream.ReduceByHashedKey(
+//	  stream.Of(
+//	    pair.Of("foo", 1),
+//	    pair.Of("bar", 2),
+//	    pair.Of("foo", 3),
+//	  ),
+//	  hash.String[string](), // Hash keys as strings
+//	  func(a, b int) int { // Reduce values with addition
+//	    return a + b
+//	  },
+//	)
+//	out := stream.DebugString(s) // "<("foo", 4), ("bar", 2)>"
+func ReduceByHashedKey[K any, V any](s Stream[pair.Pair[K, V]], keyHasher hash.Hasher[K], reduce Reducer[V]) Stream[pair.Pair[K, V]] {
+	return reduceByKey(s, kvstore.HashedMaker[K, V](keyHasher), reduce)
+}
+
 func reduceByKey[K any, V any](s Stream[pair.Pair[K, V]], kv kvstore.Maker[K, V], reduce Reducer[V]) Stream[pair.Pair[K, V]] {
 	return func(yield Consumer[pair.Pair[K, V]]) {
 		groups := kv()
@@ -181,6 +285,31 @@ func AggregateBySortedKey[K any, V, A, F any](s Stream[pair.Pair[K, V]], keyComp
 	return aggregateByKey(s, kvstore.SortedMaker[K, A](keyCompare), identity, accumulate, finish)
 }
 
+// AggregateByHashedKey returns a stream that aggregates key-value pairs by key using the given hash.Hasher to hash and compare keys.
+// It behaves like AggregateBySortedKey, except that the elements are ordered by the first occurrence of their key.
+//
+// Example usage:
+//
+//	s := stream.AggregateByHashedKey(
+//	  stream.Of(
+//	    pair.Of("foo", 1),
+//	    pair.Of("bar", 2),
+//	    pair.Of("foo", 3),
+//	  ),
+//	  hash.String[string](), // Hash keys as strings
+//	  0, // Initial value
+//	  func(a int, b int) int { // Accumulate values with addition
+//	    return a + b
+//	  },
+//	  func(a int) string { // Finish values with string conversion
+//	    return fmt.Sprintf("%d", a)
+//	  },
+//	)
+//	out := stream.DebugString(s) // "<("foo", "4"), ("bar", "2")>"
+func AggregateByHashedKey[K any, V, A, F any](s Stream[pair.Pair[K, V]], keyHasher hash.Hasher[K], identity A, accumulate Accumulator[A, V], finish Finisher[A, F]) Stream[pair.Pair[K, F]] {
+	return aggregateByKey(s, kvstore.HashedMaker[K, A](keyHasher), identity, accumulate, finish)
+}
+
 func aggregateByKey[K any, V, A, F any](s Stream[pair.Pair[K, V]], kv kvstore.Maker[K, A], identity A, accumulate Accumulator[A, V], finish Finisher[A, F]) Stream[pair.Pair[K, F]] {
 	return func(yield Consumer[pair.Pair[K, F]]) {
 		groups := kv()
@@ -283,6 +412,49 @@ func CountBySortedValue[V any](s Stream[V], valueCompare cmp.Comparer[V]) Stream
 	)
 }
 
+// CountByHashedKey returns a stream that counts the number of elements for each key using the given hash.Hasher to hash and compare keys.
+// The resulting stream contains key-value pairs where the key is the same, and the value is the number of elements that had that key.
+// The elements are ordered by the first occurrence of their key.
+//
+// Example usage:
+//
+//	s := stream.CountByHashedKey(
+//	  stream.Of(
+//	    pair.Of("foo", 1),
+//	    pair.Of("bar", 2),
+//	    pair.Of("foo", 3),
+//	  ),
+//	  hash.String[string](), // Hash keys as strings
+//	)
+//	out := stream.DebugString(s) // "<("foo", 2), ("bar", 1)>"
+func CountByHashedKey[K any, V any](s Stream[pair.Pair[K, V]], keyHasher hash.Hasher[K]) Stream[pair.Pair[K, int64]] {
+	return AggregateByHashedKey(
+		s,
+		keyHasher,
+		int64(0), // Initialize with 0.
+		func(a int64, _ V) int64 { return a + 1 }, // Accumulate: Add 1 to count.
+		mapper.Identity[int64](),                  // Finish: Return the count as is.
+	)
+}
+
+// CountByHashedValue returns a stream that counts the number of elements for each value using the given hash.Hasher to hash and compare values.
+// The resulting stream contains key-value pairs where the key is the same, and the value is the number of elements that had that value.
+// The elements are ordered by the first occurrence of their value.
+//
+// Example usage:
+//
+//	s := stream.CountByHashedValue(
+//	  stream.Of([]int{1}, []int{2}, []int{1}),
+//	  hash.Slice(hash.Integer[int]()), // Hash values by their elements
+//	)
+//	out := stream.DebugString(s) // "<([1], 2), ([2], 1)>"
+func CountByHashedValue[V any](s Stream[V], valueHasher hash.Hasher[V]) Stream[pair.Pair[V, int64]] {
+	return CountByHashedKey(
+		Map(s, func(v V) pair.Pair[V, struct{}] { return pair.Of(v, struct{}{}) }),
+		valueHasher,
+	)
+}
+
 // MinByKey returns a stream that finds the minimum value for each key.
 // The resulting stream contains key-value pairs where the key is the same, and the value is the minimum value that had that key.
 // The key type K must be comparable.
@@ -1,11 +1,14 @@
 package stream
 
 import (
+	"fmt"
 	"reflect"
 	"testing"
 
 	"github.com/jpfourny/papaya/v2/pkg/cmp"
+	"github.com/jpfourny/papaya/v2/pkg/hash"
 	"github.com/jpfourny/papaya/v2/pkg/pair"
+	"github.com/jpfourny/papaya/v2/pkg/stream/collector"
 )
 
 func TestGroupByKey(t *testing.T) {
@@ -849,3 +852,153 @@ func TestSumBySortedKey(t *testing.T) {
 		}
 	})
 }
+
+func TestGroupByHashedKey(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		s := GroupByHashedKey(Empty[pair.Pair[[]int, string]](), hash.Slice(hash.Integer[int]()))
+		got := CollectSlice(s)
+		if len(got) != 0 {
+			t.Fatalf("got %#v, want empty", got)
+		}
+	})
+
+	t.Run("non-empty", func(t *testing.T) {
+		s := GroupByHashedKey(Of(
+			pair.Of([]int{1}, "one"),
+			pair.Of([]int{2}, "two"),
+			pair.Of([]int{1}, "uno"),
+			pair.Of([]int{3}, "three"),
+			pair.Of([]int{2}, "dos"),
+		), hash.Slice(hash.Integer[int]()))
+		got := CollectSlice(s)
+		want := []pair.Pair[[]int, []string]{
+			pair.Of([]int{1}, []string{"one", "uno"}),
+			pair.Of([]int{2}, []string{"two", "dos"}),
+			pair.Of([]int{3}, []string{"three"}),
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("got %#v, want %#v", got, want)
+		}
+	})
+}
+
+func TestReduceByHashedKey(t *testing.T) {
+	s := ReduceByHashedKey(Of(
+		pair.Of("foo", 1),
+		pair.Of("bar", 2),
+		pair.Of("foo", 3),
+	), hash.String[string](), func(a, b int) int { return a + b })
+	got := CollectSlice(s)
+	want := []pair.Pair[string, int]{pair.Of("foo", 4), pair.Of("bar", 2)}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+}
+
+func TestAggregateByHashedKey(t *testing.T) {
+	s := AggregateByHashedKey(
+		Of(
+			pair.Of("foo", 1),
+			pair.Of("bar", 2),
+			pair.Of("foo", 3),
+		),
+		hash.String[string](),
+		0,
+		func(a, b int) int { return a + b },
+		func(a int) string { return fmt.Sprintf("%d", a) },
+	)
+	got := CollectSlice(s)
+	want := []pair.Pair[string, string]{pair.Of("foo", "4"), pair.Of("bar", "2")}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+}
+
+func TestCountByHashedKey(t *testing.T) {
+	s := CountByHashedKey(Of(
+		pair.Of("foo", 1),
+		pair.Of("bar", 2),
+		pair.Of("foo", 3),
+	), hash.String[string]())
+	got := CollectSlice(s)
+	want := []pair.Pair[string, int64]{pair.Of("foo", int64(2)), pair.Of("bar", int64(1))}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+}
+
+func TestCountByHashedValue(t *testing.T) {
+	s := CountByHashedValue(Of([]int{1}, []int{2}, []int{1}), hash.Slice(hash.Integer[int]()))
+	got := CollectSlice(s)
+	want := []pair.Pair[[]int, int64]{pair.Of([]int{1}, int64(2)), pair.Of([]int{2}, int64(1))}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+}
+
+func TestGroupingBy(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		s := GroupingBy(Empty[string](), func(s string) byte { return s[0] }, collector.Counting[string]())
+		got := CollectMap(s)
+		if len(got) != 0 {
+			t.Fatalf("got %#v, want empty", got)
+		}
+	})
+
+	t.Run("non-empty", func(t *testing.T) {
+		s := GroupingBy(Of("apple", "banana", "avocado"), func(s string) byte { return s[0] }, collector.Counting[string]())
+		got := CollectMap(s)
+		want := map[byte]int64{'a': 2, 'b': 1}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("got %#v, want %#v", got, want)
+		}
+	})
+
+	t.Run("nested", func(t *testing.T) {
+		s := GroupingBy(
+			Of("ab", "abc", "b", "ad"),
+			func(s string) byte { return s[0] },
+			collector.GroupingBy(func(s string) int { return len(s) }, collector.Joining("+")),
+		)
+		got := CollectMap(s)
+		want := map[byte]map[int]string{
+			'a': {2: "ab+ad", 3: "abc"},
+			'b': {1: "b"},
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("got %#v, want %#v", got, want)
+		}
+	})
+}
+
+func TestGroupingBySortedKey(t *testing.T) {
+	s := GroupingBySortedKey(
+		Of("banana", "apple", "avocado", "blueberry"),
+		func(s string) byte { return s[0] },
+		cmp.Natural[byte](),
+		collector.Joining("+"),
+	)
+	got := CollectSlice(s)
+	want := []pair.Pair[byte, string]{pair.Of(byte('a'), "apple+avocado"), pair.Of(byte('b'), "banana+blueberry")}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+}
+
+func TestGroupingByHashedKey(t *testing.T) {
+	s := GroupingByHashedKey(
+		Of([]int{1, 2}, []int{3}, []int{1, 2}),
+		func(s []int) []int { return s },
+		hash.Slice(hash.Integer[int]()),
+		collector.Counting[[]int](),
+	)
+	got := CollectSlice(s)
+	want := []pair.Pair[[]int, int64]{pair.Of([]int{1, 2}, int64(2)), pair.Of([]int{3}, int64(1))}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+	got = CollectSlice(Limit(s, 1))
+	if !reflect.DeepEqual(got, want[:1]) {
+		t.Fatalf("got %#v, want %#v", got, want[:1])
+	}
+}
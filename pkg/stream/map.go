@@ -1,7 +1,11 @@
 package stream
 
 import (
+	"context"
+
 	"github.com/jpfourny/papaya/v2/pkg/opt"
+	"github.com/jpfourny/papaya/v2/pkg/res"
+	"github.com/jpfourny/papaya/v2/pkg/retry"
 )
 
 // Mapper represents a function that transforms an input of type E to an output of type F.
@@ -48,6 +52,27 @@ func MapOrDiscard[E, F any](s Stream[E], m OptionalMapper[E, F]) Stream[F] {
 	}
 }
 
+// MapRetry applies a fallible mapper function to each element in a stream, retrying it according to the given retry.Policy, and returns a new stream containing the outcome for each element as a res.Result.
+// An element that cannot be mapped yields a failed result, rather than aborting the stream; see retry.Do for details.
+// The context is passed to each attempt; once it is done, the remaining elements yield failed results without calling the mapper.
+//
+// Example usage:
+//
+//	s := stream.MapRetry(
+//	  ctx,
+//	  stream.Of("a", "b"),
+//	  retry.DefaultPolicy(),
+//	  func(ctx context.Context, key string) (string, error) { return fetch(ctx, key) },
+//	)
+//	stream.ForEach(s, func(r res.Result[string]) { fmt.Println(r) }) // eg: Success("A"), Failure(retry: gave up after 3 attempt(s): unavailable)
+func MapRetry[E, F any](ctx context.Context, s Stream[E], p retry.Policy, m func(ctx context.Context, e E) (F, error)) Stream[res.Result[F]] {
+	return Map(s, func(e E) res.Result[F] {
+		return retry.Do(ctx, p, func(ctx context.Context) (F, error) {
+			return m(ctx, e)
+		})
+	})
+}
+
 // StreamMapper represents a function that takes an input of type E and returns an output stream of type F.
 // The StreamMapper function is typically used as a parameter of the FlatMap function.
 // It must be idempotent, free of side effects, and thread-safe.
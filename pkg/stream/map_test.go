@@ -1,11 +1,15 @@
 package stream
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"github.com/jpfourny/papaya/v2/pkg/stream/mapper"
 	"testing"
 
 	"github.com/jpfourny/papaya/v2/internal/assert"
+	"github.com/jpfourny/papaya/v2/pkg/res"
+	"github.com/jpfourny/papaya/v2/pkg/retry"
 )
 
 func TestMap(t *testing.T) {
@@ -22,6 +26,29 @@ func TestMapOrDiscard(t *testing.T) {
 	assert.ElementsMatch(t, got, want)
 }
 
+func TestMapRetry(t *testing.T) {
+	errFlaky := errors.New("flaky")
+	calls := map[int]int{}
+	s := MapRetry(
+		context.Background(),
+		Of(1, 2, 3),
+		retry.Policy{MaxAttempts: 2},
+		func(_ context.Context, e int) (string, error) {
+			calls[e]++
+			if e == 2 && calls[e] == 1 || e == 3 {
+				return "", errFlaky // 2 fails once; 3 always fails.
+			}
+			return fmt.Sprint(e), nil
+		},
+	)
+	got := CollectSlice(Map(s, func(r res.Result[string]) string {
+		return fmt.Sprintf("%s/%v", r.Value().GetOrZero(), res.Is(r, errFlaky))
+	}))
+	want := []string{"1/false", "2/false", "/true"}
+	assert.ElementsMatch(t, got, want)
+	assert.ElementsMatch(t, []int{calls[1], calls[2], calls[3]}, []int{1, 2, 2})
+}
+
 func TestFlatMap(t *testing.T) {
 	s := FlatMap(Of(1, 2, 3), func(e int) Stream[string] {
 		return Of(fmt.Sprintf("%dA", e), fmt.Sprintf("%dB", e))
@@ -223,27 +223,3 @@ func DebugString[E any](s Stream[E]) string {
 			", ",
 		) + ">"
 }
-
-// Cache returns a stream that caches all elements from the given stream.
-// The first call to the returned stream will cache all elements from the given stream (and exhaust it).
-// Subsequent calls to the returned stream will replay the cache.
-func Cache[E any](s Stream[E]) Stream[E] {
-	var cache []E
-	return func(yield Consumer[E]) {
-		if cache == nil {
-			// First call to stream caches all elements.
-			cache = []E{}
-			cont := true
-			s(func(e E) bool {
-				cache = append(cache, e)
-				if cont { // Yield until the consumer requests to stop.
-					cont = yield(e)
-				}
-				return true // Always exhaust the stream for caching.
-			})
-		} else {
-			// Replay from the cache.
-			FromSlice(cache)(yield)
-		}
-	}
-}
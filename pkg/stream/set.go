@@ -3,6 +3,7 @@ package stream
 import (
 	"github.com/jpfourny/papaya/v2/internal/kvstore"
 	"github.com/jpfourny/papaya/v2/pkg/cmp"
+	"github.com/jpfourny/papaya/v2/pkg/hash"
 )
 
 // Union combines multiple streams into a single stream (concatenation).
@@ -92,6 +93,39 @@ func IntersectionAllBy[E any](compare cmp.Comparer[E], ss ...Stream[E]) Stream[E
 	return IntersectionBy[E](ss[0], ss[1], compare)
 }
 
+// IntersectionByHash returns a stream that contains elements that are in the given streams, hashed and compared by the given hash.Hasher.
+// The order of the elements is not guaranteed.
+//
+// Example usage:
+//
+//	s := stream.IntersectionByHash(stream.Of([]int{1}, []int{2}), stream.Of([]int{2}, []int{3}), hash.Slice(hash.Integer[int]()))
+//	out := stream.DebugString(s) // "<[2]>"
+func IntersectionByHash[E any](s1, s2 Stream[E], hasher hash.Hasher[E]) Stream[E] {
+	return intersection(s1, s2, kvstore.HashedMaker[E, struct{}](hasher))
+}
+
+// IntersectionAllByHash returns a stream that contains elements that are in all the given streams, hashed and compared by the given hash.Hasher.
+// The order of the elements is not guaranteed.
+// Unlike the other *ByHash operators, the hash.Hasher is passed first, since the streams are variadic, as with IntersectionAllBy.
+//
+// Example usage:
+//
+//	s := stream.IntersectionAllByHash(hash.Integer[int](), stream.Of(1, 2, 3, 4, 5), stream.Of(4, 5, 6), stream.Of(4, 5, 7))
+//	out := stream.DebugString(s) // "<4, 5>"
+func IntersectionAllByHash[E any](hasher hash.Hasher[E], ss ...Stream[E]) Stream[E] {
+	switch {
+	case len(ss) == 0: // No streams; result is empty.
+		return Empty[E]()
+	case len(ss) == 1: // One stream; result is the same stream.
+		return ss[0]
+	case len(ss) > 2: // More than 2 streams; recursively intersect stream pairs.
+		return IntersectionByHash[E](ss[0], IntersectionAllByHash[E](hasher, ss[1:]...), hasher)
+	}
+
+	// Exactly 2 streams; intersect ss[0] and ss[1].
+	return IntersectionByHash[E](ss[0], ss[1], hasher)
+}
+
 func intersection[E any](s1, s2 Stream[E], kv kvstore.Maker[E, struct{}]) Stream[E] {
 	return func(yield Consumer[E]) {
 		set2 := toSet(s2, kv)
@@ -128,6 +162,17 @@ func DifferenceBy[E any](s1, s2 Stream[E], compare cmp.Comparer[E]) Stream[E] {
 	return difference(s1, s2, kvstore.SortedMaker[E, struct{}](compare))
 }
 
+// DifferenceByHash returns a stream that contains elements that are in the first stream but not in the second stream, hashed and compared by the given hash.Hasher.
+// The order of the elements is not guaranteed.
+//
+// Example usage:
+//
+//	s := stream.DifferenceByHash(stream.Of([]int{1}, []int{2}), stream.Of([]int{2}, []int{3}), hash.Slice(hash.Integer[int]()))
+//	out := stream.DebugString(s) // "<[1]>"
+func DifferenceByHash[E any](s1, s2 Stream[E], hasher hash.Hasher[E]) Stream[E] {
+	return difference(s1, s2, kvstore.HashedMaker[E, struct{}](hasher))
+}
+
 func difference[E any](s1, s2 Stream[E], kv kvstore.Maker[E, struct{}]) Stream[E] {
 	return func(yield Consumer[E]) {
 		set2 := toSet(s2, kv)
@@ -164,6 +209,17 @@ func SymmetricDifferenceBy[E any](s1, s2 Stream[E], compare cmp.Comparer[E]) Str
 	return Union(DifferenceBy(s1, s2, compare), DifferenceBy(s2, s1, compare))
 }
 
+// SymmetricDifferenceByHash returns a stream that contains elements that are in either of the given streams, but not in both, hashed and compared by the given hash.Hasher.
+// The order of the elements is not guaranteed.
+//
+// Example usage:
+//
+//	s := stream.SymmetricDifferenceByHash(stream.Of(1, 2, 3, 4, 5), stream.Of(4, 5, 6), hash.Integer[int]())
+//	out := stream.DebugString(s) // "<1, 2, 3, 6>"
+func SymmetricDifferenceByHash[E any](s1, s2 Stream[E], hasher hash.Hasher[E]) Stream[E] {
+	return Union(DifferenceByHash(s1, s2, hasher), DifferenceByHash(s2, s1, hasher))
+}
+
 // Subset returns true if all elements of the first stream are in the second stream.
 // The element type E must be comparable.
 //
@@ -185,6 +241,16 @@ func SubsetBy[E any](s1, s2 Stream[E], compare cmp.Comparer[E]) bool {
 	return subset(s1, s2, kvstore.SortedMaker[E, struct{}](compare))
 }
 
+// SubsetByHash returns true if all elements of the first stream are in the second stream, hashed and compared by the given hash.Hasher.
+//
+// Example usage:
+//
+//	ok := stream.SubsetByHash(stream.Of(1, 2), stream.Of(1, 2, 3, 4), hash.Integer[int]())
+//	fmt.Println(ok) // "true"
+func SubsetByHash[E any](s1, s2 Stream[E], hasher hash.Hasher[E]) bool {
+	return subset(s1, s2, kvstore.HashedMaker[E, struct{}](hasher))
+}
+
 func subset[E any](s1, s2 Stream[E], kv kvstore.Maker[E, struct{}]) bool {
 	// Index elements of the second stream into a set.
 	set2 := toSet(s2, kv)
@@ -222,6 +288,16 @@ func SupersetBy[E any](s1, s2 Stream[E], compare cmp.Comparer[E]) bool {
 	return SubsetBy(s2, s1, compare)
 }
 
+// SupersetByHash returns true if all elements of the second stream are in the first stream, hashed and compared by the given hash.Hasher.
+//
+// Example usage:
+//
+//	ok := stream.SupersetByHash(stream.Of(1, 2, 3, 4), stream.Of(1, 2), hash.Integer[int]())
+//	fmt.Println(ok) // "true"
+func SupersetByHash[E any](s1, s2 Stream[E], hasher hash.Hasher[E]) bool {
+	return SubsetByHash(s2, s1, hasher)
+}
+
 // SetEqual returns true if the two streams contain the same elements, ignoring order and duplicates (ie: set equality).
 // The element type E must be comparable.
 //
@@ -243,6 +319,16 @@ func SetEqualBy[E any](s1, s2 Stream[E], compare cmp.Comparer[E]) bool {
 	return setEqual(s1, s2, kvstore.SortedMaker[E, struct{}](compare))
 }
 
+// SetEqualByHash returns true if the two streams contain the same elements (in any order), hashed and compared by the given hash.Hasher.
+//
+// Example usage:
+//
+//	ok := stream.SetEqualByHash(stream.Of(1, 2, 3), stream.Of(3, 2, 1), hash.Integer[int]())
+//	fmt.Println(ok) // "true"
+func SetEqualByHash[E any](s1, s2 Stream[E], hasher hash.Hasher[E]) bool {
+	return setEqual(s1, s2, kvstore.HashedMaker[E, struct{}](hasher))
+}
+
 func setEqual[E any](s1, s2 Stream[E], kv kvstore.Maker[E, struct{}]) bool {
 	set1 := toSet(s1, kv)
 	set2 := toSet(s2, kv)
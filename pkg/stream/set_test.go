@@ -2,6 +2,8 @@ package stream
 
 import (
 	"github.com/jpfourny/papaya/v2/pkg/cmp"
+	"github.com/jpfourny/papaya/v2/pkg/hash"
+	"reflect"
 	"testing"
 
 	"github.com/jpfourny/papaya/v2/internal/assert"
@@ -229,3 +231,91 @@ func TestSetEqualBy(t *testing.T) {
 		t.Errorf("SetEqualBy(Of(1, 2, 3, 3), Of(3, 2, 1, 1), cmp.Natural[int]()) = false; want true")
 	}
 }
+
+func TestIntersectionByHash(t *testing.T) {
+	t.Run("two-streams", func(t *testing.T) {
+		s := IntersectionByHash(Of([]int{1}, []int{2}, []int{3}), Of([]int{2}, []int{3}, []int{4}), hash.Slice(hash.Integer[int]()))
+		got := CollectSlice(s)
+		want := [][]int{{2}, {3}}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("got %#v, want %#v", got, want)
+		}
+	})
+
+	t.Run("limited", func(t *testing.T) {
+		s := IntersectionByHash(Of(1, 2, 3), Of(2, 3, 4), hash.Integer[int]())
+		got := CollectSlice(Limit(s, 1)) // Stops stream after 1 element.
+		want := []int{2}
+		assert.ElementsMatch(t, got, want)
+	})
+}
+
+func TestIntersectionAllByHash(t *testing.T) {
+	t.Run("zero-streams", func(t *testing.T) {
+		s := IntersectionAllByHash[int](hash.Integer[int]())
+		got := CollectSlice(s)
+		var want []int
+		assert.ElementsMatch(t, got, want)
+	})
+
+	t.Run("one-stream", func(t *testing.T) {
+		s := IntersectionAllByHash(hash.Integer[int](), Of(1, 2, 3))
+		got := CollectSlice(s)
+		want := []int{1, 2, 3}
+		assert.ElementsMatch(t, got, want)
+	})
+
+	t.Run("three-streams", func(t *testing.T) {
+		s := IntersectionAllByHash(hash.Integer[int](), Of(1, 2, 3, 4), Of(2, 3, 4, 5), Of(3, 4, 5, 6))
+		got := CollectSlice(s)
+		want := []int{3, 4}
+		assert.ElementsMatch(t, got, want)
+	})
+}
+
+func TestDifferenceByHash(t *testing.T) {
+	s := DifferenceByHash(Of([]int{1}, []int{2}, []int{3}), Of([]int{2}, []int{3}, []int{4}), hash.Slice(hash.Integer[int]()))
+	got := CollectSlice(s)
+	want := [][]int{{1}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %#v, want %#v", got, want)
+	}
+}
+
+func TestSymmetricDifferenceByHash(t *testing.T) {
+	s := SymmetricDifferenceByHash(Of(1, 2, 3), Of(2, 3, 4), hash.Integer[int]())
+	got := CollectSlice(s)
+	want := []int{1, 4}
+	assert.ElementsMatch(t, got, want)
+}
+
+func TestSubsetByHash(t *testing.T) {
+	if !SubsetByHash(Of(1, 2), Of(1, 2, 3), hash.Integer[int]()) {
+		t.Errorf("SubsetByHash(Of(1, 2), Of(1, 2, 3), hash.Integer[int]()) = false; want true")
+	}
+	if SubsetByHash(Of(1, 4), Of(1, 2, 3), hash.Integer[int]()) {
+		t.Errorf("SubsetByHash(Of(1, 4), Of(1, 2, 3), hash.Integer[int]()) = true; want false")
+	}
+}
+
+func TestSupersetByHash(t *testing.T) {
+	if !SupersetByHash(Of(1, 2, 3), Of(1, 2), hash.Integer[int]()) {
+		t.Errorf("SupersetByHash(Of(1, 2, 3), Of(1, 2), hash.Integer[int]()) = false; want true")
+	}
+	if SupersetByHash(Of(1, 2, 3), Of(1, 4), hash.Integer[int]()) {
+		t.Errorf("SupersetByHash(Of(1, 2, 3), Of(1, 4), hash.Integer[int]()) = true; want false")
+	}
+}
+
+func TestSetEqualByHash(t *testing.T) {
+	h := hash.Slice(hash.Integer[int]())
+	if SetEqualByHash(Of([]int{1}, []int{2}), Of([]int{2}, []int{3}), h) {
+		t.Errorf("SetEqualByHash(Of([1], [2]), Of([2], [3])) = true; want false")
+	}
+	if SetEqualByHash(Of([]int{1}, []int{2}), Of([]int{1}), h) {
+		t.Errorf("SetEqualByHash(Of([1], [2]), Of([1])) = true; want false")
+	}
+	if !SetEqualByHash(Of([]int{1}, []int{2}, []int{2}), Of([]int{2}, []int{1}), h) {
+		t.Errorf("SetEqualByHash(Of([1], [2], [2]), Of([2], [1])) = false; want true")
+	}
+}
@@ -0,0 +1,213 @@
+package stream
+
+import (
+	"iter"
+	"sync"
+
+	"github.com/jpfourny/papaya/v2/pkg/pair"
+)
+
+// PartitionBy splits the elements of the stream into those that satisfy the given predicate, and those that do not, in a single pass.
+// The result is a pair of slices: the elements that satisfy the predicate first, and the others second, each in stream order.
+// The stream is fully consumed.
+//
+// Example usage:
+//
+//	p := stream.PartitionBy(stream.Of(1, 2, 3, 4, 5), func(e int) bool { return e%2 == 0 })
+//	even, odd := p.Explode() // []int{2, 4}, []int{1, 3, 5}
+func PartitionBy[E any](s Stream[E], pred Predicate[E]) pair.Pair[[]E, []E] {
+	var pass, fail []E
+	s(func(e E) bool {
+		if pred(e) {
+			pass = append(pass, e)
+		} else {
+			fail = append(fail, e)
+		}
+		return true
+	})
+	return pair.Of(pass, fail)
+}
+
+// Broadcast yields each element of the stream to all the given consumers, in order, in a single pass of the stream.
+// A consumer that returns false receives no further elements; the stream stops once all consumers have stopped.
+// This allows non-idempotent streams, such as those created by FromChannel, to feed several consumers.
+//
+// Example usage:
+//
+//	var sum, count int
+//	stream.Broadcast(
+//	  stream.Of(1, 2, 3),
+//	  func(e int) bool { sum += e; return true },
+//	  func(e int) bool { count++; return true },
+//	) // sum == 6, count == 3
+func Broadcast[E any](s Stream[E], consumers ...Consumer[E]) {
+	active := make([]Consumer[E], len(consumers))
+	copy(active, consumers)
+	if len(active) == 0 {
+		return // Nobody to feed.
+	}
+	s(func(e E) bool {
+		n := 0
+		for _, c := range active {
+			if c(e) {
+				active[n] = c // Keep consumers that wish to continue.
+				n++
+			}
+		}
+		clear(active[n:])
+		active = active[:n]
+		return n > 0
+	})
+}
+
+// Route yields each element of the stream to the consumer in sinks for the key returned by the given Mapper, in a single pass of the stream.
+// Elements whose key has no sink are discarded.
+// A sink that returns false receives no further elements; the stream stops once all sinks have stopped.
+//
+// Example usage:
+//
+//	var small, large []int
+//	stream.Route(
+//	  stream.Of(1, 20, 3, 40),
+//	  func(e int) bool { return e >= 10 },
+//	  map[bool]stream.Consumer[int]{
+//	    false: func(e int) bool { small = append(small, e); return true },
+//	    true:  func(e int) bool { large = append(large, e); return true },
+//	  },
+//	) // small == []int{1, 3}, large == []int{20, 40}
+func Route[E any, K comparable](s Stream[E], key Mapper[E, K], sinks map[K]Consumer[E]) {
+	active := make(map[K]Consumer[E], len(sinks))
+	for k, c := range sinks {
+		active[k] = c
+	}
+	if len(active) == 0 {
+		return // Nobody to feed.
+	}
+	s(func(e E) bool {
+		k := key(e)
+		if c, ok := active[k]; ok && !c(e) {
+			delete(active, k)
+		}
+		return len(active) > 0
+	})
+}
+
+// Tee returns n streams that each yield the elements of the given stream, which is consumed in a single pass, shared by all of them.
+// The given stream is not called until one of the returned streams is consumed, and is read only as far as the furthest consumer needs.
+// Elements read from the given stream are buffered until every returned stream has either consumed them or stopped, so consuming the returned streams one after the other buffers up to the whole stream.
+//
+// Each returned stream can be consumed once; a returned stream that is called again yields no elements.
+// The returned streams may be consumed concurrently by multiple goroutines.
+// The given stream is released once every returned stream has been consumed or stopped; a returned stream that is never called keeps it, and the buffered elements, alive.
+//
+// Example usage:
+//
+//	ts := stream.Tee(stream.FromChannel(ch), 2)
+//	sum := stream.Sum[int](ts[0]) // Reads all elements from the channel.
+//	count := stream.Count(ts[1])  // Replays the buffered elements.
+func Tee[E any](s Stream[E], n int) []Stream[E] {
+	if n <= 0 {
+		return nil
+	}
+	t := &tee[E]{
+		source: s,
+		pos:    make([]int, n),
+		active: n,
+	}
+	ss := make([]Stream[E], n)
+	for i := range ss {
+		ss[i] = func(yield Consumer[E]) {
+			t.consume(i, yield)
+		}
+	}
+	return ss
+}
+
+// tee holds the state shared by the streams returned by Tee.
+// The source is read through a pull iterator, created on first use, and elements are buffered from the position of the slowest active branch onwards.
+type tee[E any] struct {
+	source Stream[E]
+	mu     sync.Mutex
+	next   func() (E, bool)
+	stop   func()
+	buf    []E   // Buffered elements, starting at position base.
+	base   int   // Position of the first buffered element.
+	pos    []int // Position of the next element of each branch; -1 once the branch is done.
+	active int   // Number of branches that are not done.
+	eof    bool  // True if the source is exhausted.
+}
+
+func (t *tee[E]) consume(i int, yield Consumer[E]) {
+	defer t.done(i)
+	for {
+		e, ok := t.get(i)
+		if !ok || !yield(e) {
+			return
+		}
+	}
+}
+
+// get returns the next element for branch i, reading it from the source if it is not buffered yet.
+func (t *tee[E]) get(i int) (E, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var zero E
+	p := t.pos[i]
+	if p < 0 {
+		return zero, false // Branch already consumed.
+	}
+	if p == t.base+len(t.buf) {
+		if t.eof {
+			return zero, false
+		}
+		if t.next == nil {
+			t.next, t.stop = iter.Pull(ToIterSeq(t.source))
+		}
+		e, ok := t.next()
+		if !ok {
+			t.eof = true
+			return zero, false
+		}
+		t.buf = append(t.buf, e)
+	}
+	t.pos[i] = p + 1
+	e := t.buf[p-t.base]
+	t.trim()
+	return e, true
+}
+
+// done marks branch i as done, releasing its hold on buffered elements, and releases the source once all branches are done.
+func (t *tee[E]) done(i int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.pos[i] < 0 {
+		return
+	}
+	t.pos[i] = -1
+	t.active--
+	if t.active > 0 {
+		t.trim()
+		return
+	}
+	t.buf = nil
+	if t.stop != nil {
+		t.stop()
+	}
+}
+
+// trim discards the buffered elements that every active branch has consumed.
+func (t *tee[E]) trim() {
+	low := -1
+	for _, p := range t.pos {
+		if p >= 0 && (low < 0 || p < low) {
+			low = p
+		}
+	}
+	if low <= t.base {
+		return
+	}
+	n := min(low-t.base, len(t.buf))
+	clear(t.buf[:n])
+	t.buf = t.buf[n:]
+	t.base += n
+}
@@ -0,0 +1,176 @@
+package stream
+
+import (
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/jpfourny/papaya/v2/internal/assert"
+)
+
+func TestPartitionBy(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		pass, fail := PartitionBy(Empty[int](), func(e int) bool { return e%2 == 0 }).Explode()
+		if len(pass) != 0 || len(fail) != 0 {
+			t.Fatalf("got %#v, %#v, want empty", pass, fail)
+		}
+	})
+
+	t.Run("non-empty", func(t *testing.T) {
+		pass, fail := PartitionBy(Of(1, 2, 3, 4, 5), func(e int) bool { return e%2 == 0 }).Explode()
+		assert.ElementsMatch(t, pass, []int{2, 4})
+		assert.ElementsMatch(t, fail, []int{1, 3, 5})
+	})
+}
+
+// onceStream returns a stream of the given elements that can only be called once, like a stream read from a channel, and counts its calls.
+func onceStream(calls *int, es ...int) Stream[int] {
+	return func(yield Consumer[int]) {
+		*calls++
+		if *calls > 1 {
+			return
+		}
+		for _, e := range es {
+			if !yield(e) {
+				return
+			}
+		}
+	}
+}
+
+func TestBroadcast(t *testing.T) {
+	t.Run("no-consumers", func(t *testing.T) {
+		var calls int
+		Broadcast(onceStream(&calls, 1, 2, 3))
+		if calls != 0 {
+			t.Fatalf("got %d calls, want 0", calls)
+		}
+	})
+
+	t.Run("all-consumers", func(t *testing.T) {
+		var calls int
+		var a, b []int
+		Broadcast(
+			onceStream(&calls, 1, 2, 3),
+			func(e int) bool { a = append(a, e); return true },
+			func(e int) bool { b = append(b, e); return true },
+		)
+		assert.ElementsMatch(t, a, []int{1, 2, 3})
+		assert.ElementsMatch(t, b, []int{1, 2, 3})
+		if calls != 1 {
+			t.Fatalf("got %d calls, want 1", calls)
+		}
+	})
+
+	t.Run("stopping-consumers", func(t *testing.T) {
+		var a, b []int
+		var seen []int
+		Broadcast(
+			Peek(Of(1, 2, 3, 4), func(e int) { seen = append(seen, e) }),
+			func(e int) bool { a = append(a, e); return len(a) < 1 },
+			func(e int) bool { b = append(b, e); return len(b) < 2 },
+		)
+		assert.ElementsMatch(t, a, []int{1})
+		assert.ElementsMatch(t, b, []int{1, 2})
+		assert.ElementsMatch(t, seen, []int{1, 2}) // Stops once all consumers stop.
+	})
+}
+
+func TestRoute(t *testing.T) {
+	t.Run("routed", func(t *testing.T) {
+		var calls int
+		var small, large []int
+		Route(
+			onceStream(&calls, 1, 20, 3, 40, 5),
+			func(e int) bool { return e >= 10 },
+			map[bool]Consumer[int]{
+				false: func(e int) bool { small = append(small, e); return true },
+				true:  func(e int) bool { large = append(large, e); return true },
+			},
+		)
+		assert.ElementsMatch(t, small, []int{1, 3, 5})
+		assert.ElementsMatch(t, large, []int{20, 40})
+		if calls != 1 {
+			t.Fatalf("got %d calls, want 1", calls)
+		}
+	})
+
+	t.Run("unrouted-and-stopping", func(t *testing.T) {
+		var odd []int
+		var seen []int
+		Route(
+			Peek(Of(1, 2, 3, 4, 5), func(e int) { seen = append(seen, e) }),
+			func(e int) int { return e % 2 },
+			map[int]Consumer[int]{
+				1: func(e int) bool { odd = append(odd, e); return len(odd) < 2 },
+			},
+		)
+		assert.ElementsMatch(t, odd, []int{1, 3})
+		assert.ElementsMatch(t, seen, []int{1, 2, 3}) // Stops once all sinks stop.
+	})
+}
+
+func TestTee(t *testing.T) {
+	t.Run("zero", func(t *testing.T) {
+		if ts := Tee(Of(1, 2, 3), 0); ts != nil {
+			t.Fatalf("got %#v, want nil", ts)
+		}
+	})
+
+	t.Run("sequential", func(t *testing.T) {
+		var calls int
+		ts := Tee(onceStream(&calls, 1, 2, 3), 3)
+		if calls != 0 {
+			t.Fatalf("got %d calls, want 0 before consumption", calls)
+		}
+		assert.ElementsMatch(t, CollectSlice(ts[0]), []int{1, 2, 3})
+		assert.ElementsMatch(t, CollectSlice(Limit(ts[1], 2)), []int{1, 2})
+		assert.ElementsMatch(t, CollectSlice(ts[2]), []int{1, 2, 3})
+		if calls != 1 {
+			t.Fatalf("got %d calls, want 1", calls)
+		}
+		assert.ElementsMatch(t, CollectSlice(ts[0]), []int(nil)) // Already consumed.
+	})
+
+	t.Run("lazy", func(t *testing.T) {
+		var seen []int
+		ts := Tee(Peek(Of(1, 2, 3, 4), func(e int) { seen = append(seen, e) }), 2)
+		assert.ElementsMatch(t, CollectSlice(Limit(ts[0], 2)), []int{1, 2})
+		assert.ElementsMatch(t, CollectSlice(Limit(ts[1], 1)), []int{1})
+		assert.ElementsMatch(t, seen, []int{1, 2}) // Read only as far as needed.
+	})
+
+	t.Run("interleaved", func(t *testing.T) {
+		ts := Tee(Of(1, 2, 3), 2)
+		var got []int
+		ts[0](func(e int) bool {
+			got = append(got, e)
+			ts[1](func(f int) bool {
+				got = append(got, -f)
+				return false // Stop the second branch after its first element.
+			})
+			return true
+		})
+		assert.ElementsMatch(t, got, []int{1, -1, 2, 3})
+	})
+
+	t.Run("concurrent", func(t *testing.T) {
+		ts := Tee(Interval(0, 1000, 1), 4)
+		results := make([][]int, len(ts))
+		var wg sync.WaitGroup
+		for i, s := range ts {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				results[i] = CollectSlice(s)
+			}()
+		}
+		wg.Wait()
+		want := CollectSlice(Interval(0, 1000, 1))
+		for _, got := range results {
+			if !slices.Equal(got, want) {
+				t.Fatalf("got %d elements, want %d in order", len(got), len(want))
+			}
+		}
+	})
+}
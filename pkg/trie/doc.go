@@ -0,0 +1,4 @@
+// Package trie provides a prefix tree (trie) of string keys, supporting efficient prefix-search and ordered iteration.
+// It is useful for routing, autocomplete, and other tasks that involve searching large sets of strings by prefix.
+// Keys may be of any string type (see constraint.String), such as a named type for routes or identifiers.
+package trie
@@ -0,0 +1,292 @@
+package trie
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/jpfourny/papaya/v2/pkg/constraint"
+	"github.com/jpfourny/papaya/v2/pkg/opt"
+	"github.com/jpfourny/papaya/v2/pkg/pair"
+	"github.com/jpfourny/papaya/v2/pkg/stream"
+)
+
+// Trie represents a prefix tree mapping keys of string type K to values of type V.
+// It is implemented as a radix tree, where chains of single-child nodes are compressed into a single edge.
+// Keys are ordered lexicographically by byte value.
+// The zero value is an empty Trie ready to use.
+// A Trie is not safe for concurrent use; concurrent reads are safe only if there are no concurrent writes.
+type Trie[K constraint.String, V any] struct {
+	root node[V]
+	size int
+}
+
+// node represents a node in the radix tree.
+// The label is the edge leading from the parent to this node.
+// The children are sorted by the first byte of their labels, which are unique among siblings.
+type node[V any] struct {
+	label    string
+	children []*node[V]
+	value    V
+	leaf     bool // True if a key terminates at this node.
+}
+
+// New returns a new, empty Trie.
+func New[K constraint.String, V any]() *Trie[K, V] {
+	return &Trie[K, V]{}
+}
+
+// FromMap returns a new Trie containing the key-value pairs of the given map.
+func FromMap[K constraint.String, V any](m map[K]V) *Trie[K, V] {
+	t := New[K, V]()
+	for k, v := range m {
+		t.Put(k, v)
+	}
+	return t
+}
+
+// Len returns the number of keys in the Trie.
+func (t *Trie[K, V]) Len() int {
+	return t.size
+}
+
+// Put associates the given value with the given key, replacing any existing value.
+// Returns true if the key was newly added; false if an existing value was replaced.
+func (t *Trie[K, V]) Put(k K, value V) (added bool) {
+	key := string(k)
+	n := &t.root
+	for {
+		if key == "" {
+			added = !n.leaf
+			if added {
+				t.size++
+			}
+			n.value = value
+			n.leaf = true
+			return
+		}
+
+		i, found := n.find(key[0])
+		if !found {
+			n.insert(i, &node[V]{label: key, value: value, leaf: true})
+			t.size++
+			return true
+		}
+
+		c := n.children[i]
+		l := commonPrefixLen(key, c.label)
+		if l < len(c.label) {
+			// Split the edge at the end of the common prefix.
+			mid := &node[V]{label: c.label[:l], children: []*node[V]{c}}
+			c.label = c.label[l:]
+			n.children[i] = mid
+			c = mid
+		}
+		key = key[l:]
+		n = c
+	}
+}
+
+// Get returns the value associated with the given key; an empty opt.Optional, if the key is not present.
+func (t *Trie[K, V]) Get(key K) opt.Optional[V] {
+	if n := t.lookup(string(key)); n != nil && n.leaf {
+		return opt.Of(n.value)
+	}
+	return opt.Empty[V]()
+}
+
+// Contains returns true if the given key is present in the Trie; false otherwise.
+func (t *Trie[K, V]) Contains(key K) bool {
+	n := t.lookup(string(key))
+	return n != nil && n.leaf
+}
+
+// Delete removes the given key from the Trie.
+// Returns true if the key was present; false otherwise.
+func (t *Trie[K, V]) Delete(key K) bool {
+	if t.root.delete(string(key)) {
+		t.size--
+		return true
+	}
+	return false
+}
+
+// LongestPrefix returns the longest key in the Trie that is a prefix of the given string, along with its value.
+// If no key is a prefix of the given string, an empty opt.Optional is returned.
+//
+// Example usage:
+//
+//	t := trie.New[string, int]()
+//	t.Put("/api", 1)
+//	t.Put("/api/users", 2)
+//	out := t.LongestPrefix("/api/users/42") // Some(("/api/users", 2))
+//	out = t.LongestPrefix("/static") // None()
+func (t *Trie[K, V]) LongestPrefix(k K) opt.Optional[pair.Pair[K, V]] {
+	s := string(k)
+	longest := opt.Empty[pair.Pair[K, V]]()
+	n := &t.root
+	matched := 0
+	for {
+		if n.leaf {
+			longest = opt.Of(pair.Of(K(s[:matched]), n.value))
+		}
+		rest := s[matched:]
+		if rest == "" {
+			return longest
+		}
+		i, found := n.find(rest[0])
+		if !found || !strings.HasPrefix(rest, n.children[i].label) {
+			return longest
+		}
+		n = n.children[i]
+		matched += len(n.label)
+	}
+}
+
+// WithPrefix returns a stream of the key-value pairs in the Trie whose keys begin with the given prefix.
+// The pairs are ordered lexicographically by key.
+// Only the subtree under the prefix is visited, so the cost is proportional to the number of matches, not the size of the Trie.
+//
+// Example usage:
+//
+//	t := trie.New[string, int]()
+//	t.Put("car", 1)
+//	t.Put("cart", 2)
+//	t.Put("cat", 3)
+//	t.Put("dog", 4)
+//	out := stream.DebugString(t.WithPrefix("car")) // "<(car, 1), (cart, 2)>"
+func (t *Trie[K, V]) WithPrefix(prefix K) stream.Stream[pair.Pair[K, V]] {
+	return func(yield stream.Consumer[pair.Pair[K, V]]) {
+		n, path := t.seek(string(prefix))
+		if n == nil {
+			return // No keys with the prefix.
+		}
+		walk(n, []byte(path), yield)
+	}
+}
+
+// Stream returns a stream of all key-value pairs in the Trie, ordered lexicographically by key.
+func (t *Trie[K, V]) Stream() stream.Stream[pair.Pair[K, V]] {
+	return t.WithPrefix("")
+}
+
+// Keys returns a stream of all keys in the Trie, ordered lexicographically.
+func (t *Trie[K, V]) Keys() stream.Stream[K] {
+	return stream.UnzipFirst(t.Stream())
+}
+
+// Values returns a stream of all values in the Trie, ordered lexicographically by key.
+func (t *Trie[K, V]) Values() stream.Stream[V] {
+	return stream.UnzipSecond(t.Stream())
+}
+
+// lookup returns the node at exactly the given key, or nil if there is no such node.
+func (t *Trie[K, V]) lookup(key string) *node[V] {
+	n := &t.root
+	for key != "" {
+		i, found := n.find(key[0])
+		if !found || !strings.HasPrefix(key, n.children[i].label) {
+			return nil
+		}
+		n = n.children[i]
+		key = key[len(n.label):]
+	}
+	return n
+}
+
+// seek returns the topmost node whose subtree contains exactly the keys beginning with the given prefix, along with the full path to that node.
+// Returns nil if no keys begin with the prefix.
+func (t *Trie[K, V]) seek(prefix string) (*node[V], string) {
+	n := &t.root
+	matched := 0
+	for matched < len(prefix) {
+		rest := prefix[matched:]
+		i, found := n.find(rest[0])
+		if !found {
+			return nil, ""
+		}
+		c := n.children[i]
+		switch {
+		case strings.HasPrefix(rest, c.label): // Edge is fully consumed by the prefix; keep descending.
+			matched += len(c.label)
+			n = c
+		case strings.HasPrefix(c.label, rest): // Prefix ends part-way along the edge.
+			return c, prefix[:matched] + c.label
+		default:
+			return nil, ""
+		}
+	}
+	return n, prefix
+}
+
+// find returns the index of the child whose label begins with the given byte, and whether it was found.
+// If not found, the index is where such a child would be inserted.
+func (n *node[V]) find(b byte) (int, bool) {
+	i := sort.Search(len(n.children), func(i int) bool {
+		return n.children[i].label[0] >= b
+	})
+	return i, i < len(n.children) && n.children[i].label[0] == b
+}
+
+func (n *node[V]) insert(i int, c *node[V]) {
+	n.children = append(n.children, nil)
+	copy(n.children[i+1:], n.children[i:]) // Shift children.
+	n.children[i] = c                      // Insert child.
+}
+
+func (n *node[V]) delete(key string) bool {
+	if key == "" {
+		if !n.leaf {
+			return false
+		}
+		var zero V
+		n.value = zero
+		n.leaf = false
+		return true
+	}
+
+	i, found := n.find(key[0])
+	if !found {
+		return false
+	}
+	c := n.children[i]
+	if !strings.HasPrefix(key, c.label) || !c.delete(key[len(c.label):]) {
+		return false
+	}
+
+	// Compact the child, if it no longer terminates a key.
+	if !c.leaf {
+		switch len(c.children) {
+		case 0: // Remove the child.
+			n.children = append(n.children[:i], n.children[i+1:]...)
+		case 1: // Merge the child with its only grandchild.
+			gc := c.children[0]
+			gc.label = c.label + gc.label
+			n.children[i] = gc
+		}
+	}
+	return true
+}
+
+// walk yields the key-value pairs in the subtree rooted at the given node in lexicographic order.
+// The given path is the key of the node.
+func walk[K constraint.String, V any](n *node[V], path []byte, yield stream.Consumer[pair.Pair[K, V]]) bool {
+	if n.leaf && !yield(pair.Of(K(path), n.value)) {
+		return false
+	}
+	for _, c := range n.children {
+		if !walk(c, append(path, c.label...), yield) {
+			return false
+		}
+	}
+	return true
+}
+
+func commonPrefixLen(a, b string) int {
+	n := min(len(a), len(b))
+	for i := 0; i < n; i++ {
+		if a[i] != b[i] {
+			return i
+		}
+	}
+	return n
+}
@@ -0,0 +1,214 @@
+package trie
+
+import (
+	"testing"
+
+	"github.com/jpfourny/papaya/v2/internal/assert"
+	"github.com/jpfourny/papaya/v2/pkg/opt"
+	"github.com/jpfourny/papaya/v2/pkg/pair"
+	"github.com/jpfourny/papaya/v2/pkg/stream"
+)
+
+func newTestTrie() *Trie[string, int] {
+	t := New[string, int]()
+	t.Put("romane", 1)
+	t.Put("romanus", 2)
+	t.Put("romulus", 3)
+	t.Put("rubens", 4)
+	t.Put("ruber", 5)
+	t.Put("rubicon", 6)
+	t.Put("rubicundus", 7)
+	t.Put("rom", 8)
+	return t
+}
+
+func TestPutGet(t *testing.T) {
+	tr := newTestTrie()
+	if tr.Len() != 8 {
+		t.Errorf("got Len() %d, want %d", tr.Len(), 8)
+	}
+
+	for k, want := range map[string]opt.Optional[int]{
+		"romane":     opt.Of(1),
+		"romanus":    opt.Of(2),
+		"romulus":    opt.Of(3),
+		"rubicundus": opt.Of(7),
+		"rom":        opt.Of(8),
+		"roman":      opt.Empty[int](), // Intermediate node; not a key.
+		"r":          opt.Empty[int](),
+		"":           opt.Empty[int](),
+		"romanes":    opt.Empty[int](),
+		"x":          opt.Empty[int](),
+	} {
+		if got := tr.Get(k); got != want {
+			t.Errorf("Get(%q): got %v, want %v", k, got, want)
+		}
+		if got := tr.Contains(k); got != want.Present() {
+			t.Errorf("Contains(%q): got %v, want %v", k, got, want.Present())
+		}
+	}
+
+	t.Run("replace", func(t *testing.T) {
+		tr := newTestTrie()
+		if tr.Put("ruber", 50) {
+			t.Errorf("expected Put to return false for existing key")
+		}
+		if got := tr.Get("ruber"); got != opt.Of(50) {
+			t.Errorf("got %v, want %v", got, opt.Of(50))
+		}
+		if tr.Len() != 8 {
+			t.Errorf("got Len() %d, want %d", tr.Len(), 8)
+		}
+	})
+
+	t.Run("empty-key", func(t *testing.T) {
+		tr := New[string, int]()
+		if !tr.Put("", 1) {
+			t.Errorf("expected Put to return true for new key")
+		}
+		if got := tr.Get(""); got != opt.Of(1) {
+			t.Errorf("got %v, want %v", got, opt.Of(1))
+		}
+	})
+
+	t.Run("zero-value", func(t *testing.T) {
+		var tr Trie[string, int]
+		tr.Put("foo", 1)
+		if got := tr.Get("foo"); got != opt.Of(1) {
+			t.Errorf("got %v, want %v", got, opt.Of(1))
+		}
+	})
+}
+
+func TestDelete(t *testing.T) {
+	tr := newTestTrie()
+
+	if tr.Delete("roman") {
+		t.Errorf("expected Delete to return false for intermediate node")
+	}
+	if tr.Delete("x") {
+		t.Errorf("expected Delete to return false for missing key")
+	}
+	if !tr.Delete("romane") {
+		t.Errorf("expected Delete to return true for existing key")
+	}
+	if tr.Delete("romane") {
+		t.Errorf("expected Delete to return false for deleted key")
+	}
+	if !tr.Delete("rom") {
+		t.Errorf("expected Delete to return true for existing key")
+	}
+	if tr.Len() != 6 {
+		t.Errorf("got Len() %d, want %d", tr.Len(), 6)
+	}
+
+	got := stream.CollectSlice(tr.Keys())
+	want := []string{"romanus", "romulus", "rubens", "ruber", "rubicon", "rubicundus"}
+	assert.ElementsMatch(t, got, want)
+
+	// Compaction must preserve lookups through merged edges.
+	if got := tr.Get("romanus"); got != opt.Of(2) {
+		t.Errorf("got %v, want %v", got, opt.Of(2))
+	}
+
+	for _, k := range want {
+		tr.Delete(k)
+	}
+	if tr.Len() != 0 {
+		t.Errorf("got Len() %d, want %d", tr.Len(), 0)
+	}
+	if len(tr.root.children) != 0 {
+		t.Errorf("expected all nodes to be removed; got %d children", len(tr.root.children))
+	}
+}
+
+func TestLongestPrefix(t *testing.T) {
+	tr := New[string, string]()
+	tr.Put("/", "root")
+	tr.Put("/api", "api")
+	tr.Put("/api/users", "users")
+
+	for s, want := range map[string]opt.Optional[pair.Pair[string, string]]{
+		"/api/users/42": opt.Of(pair.Of("/api/users", "users")),
+		"/api/user":     opt.Of(pair.Of("/api", "api")),
+		"/api":          opt.Of(pair.Of("/api", "api")),
+		"/static":       opt.Of(pair.Of("/", "root")),
+		"static":        opt.Empty[pair.Pair[string, string]](),
+		"":              opt.Empty[pair.Pair[string, string]](),
+	} {
+		if got := tr.LongestPrefix(s); got != want {
+			t.Errorf("LongestPrefix(%q): got %v, want %v", s, got, want)
+		}
+	}
+}
+
+func TestWithPrefix(t *testing.T) {
+	tr := newTestTrie()
+
+	t.Run("edge-boundary", func(t *testing.T) {
+		got := stream.CollectSlice(tr.WithPrefix("rom"))
+		want := []pair.Pair[string, int]{
+			pair.Of("rom", 8),
+			pair.Of("romane", 1),
+			pair.Of("romanus", 2),
+			pair.Of("romulus", 3),
+		}
+		assert.ElementsMatch(t, got, want)
+	})
+
+	t.Run("mid-edge", func(t *testing.T) {
+		got := stream.CollectSlice(tr.WithPrefix("rubi"))
+		want := []pair.Pair[string, int]{
+			pair.Of("rubicon", 6),
+			pair.Of("rubicundus", 7),
+		}
+		assert.ElementsMatch(t, got, want)
+	})
+
+	t.Run("exact-key", func(t *testing.T) {
+		got := stream.CollectSlice(tr.WithPrefix("romulus"))
+		want := []pair.Pair[string, int]{pair.Of("romulus", 3)}
+		assert.ElementsMatch(t, got, want)
+	})
+
+	t.Run("no-match", func(t *testing.T) {
+		got := stream.CollectSlice(tr.WithPrefix("rox"))
+		assert.ElementsMatch(t, got, nil)
+
+		got = stream.CollectSlice(tr.WithPrefix("romulusx"))
+		assert.ElementsMatch(t, got, nil)
+	})
+
+	t.Run("limited", func(t *testing.T) {
+		got := stream.CollectSlice(stream.Limit(tr.WithPrefix("r"), 2))
+		want := []pair.Pair[string, int]{
+			pair.Of("rom", 8),
+			pair.Of("romane", 1),
+		}
+		assert.ElementsMatch(t, got, want)
+	})
+}
+
+func TestStream(t *testing.T) {
+	tr := FromMap(map[string]int{"b": 2, "a": 1, "ab": 3, "": 0})
+	got := stream.CollectSlice(tr.Stream())
+	want := []pair.Pair[string, int]{
+		pair.Of("", 0),
+		pair.Of("a", 1),
+		pair.Of("ab", 3),
+		pair.Of("b", 2),
+	}
+	assert.ElementsMatch(t, got, want)
+	assert.ElementsMatch(t, stream.CollectSlice(tr.Values()), []int{0, 1, 3, 2})
+}
+
+func TestNamedKey(t *testing.T) {
+	type route string
+	tr := FromMap(map[route]int{"/api": 1, "/api/users": 2, "/static": 3})
+	if got, want := tr.LongestPrefix("/api/users/42"), opt.Of(pair.Of(route("/api/users"), 2)); got != want {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	got := stream.CollectSlice(tr.WithPrefix("/api"))
+	want := []pair.Pair[route, int]{pair.Of(route("/api"), 1), pair.Of(route("/api/users"), 2)}
+	assert.ElementsMatch(t, got, want)
+}
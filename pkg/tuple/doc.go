@@ -0,0 +1,2 @@
+// Package tuple provides generic tuples of three to five values, complementing pair.Pair.
+package tuple
@@ -0,0 +1,217 @@
+package tuple
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jpfourny/papaya/v2/pkg/pair"
+)
+
+// T3 represents a tuple of 3 values of types A, B and C.
+type T3[A, B, C any] struct {
+	first  A
+	second B
+	third  C
+}
+
+// Zero3 returns a new T3 with default zero values for the type parameters.
+func Zero3[A, B, C any]() T3[A, B, C] {
+	return T3[A, B, C]{}
+}
+
+// Of3 creates a new T3 with the provided values.
+//
+// Example usage:
+//
+//	t := tuple.Of3(1, "a", true)
+//	out := t.String() // `(1, "a", true)`
+func Of3[A, B, C any](first A, second B, third C) T3[A, B, C] {
+	return T3[A, B, C]{first: first, second: second, third: third}
+}
+
+// First returns the first element of type A.
+func (t T3[A, B, C]) First() A {
+	return t.first
+}
+
+// Second returns the second element of type B.
+func (t T3[A, B, C]) Second() B {
+	return t.second
+}
+
+// Third returns the third element of type C.
+func (t T3[A, B, C]) Third() C {
+	return t.third
+}
+
+// Explode returns the elements together as a tuple.
+func (t T3[A, B, C]) Explode() (A, B, C) {
+	return t.first, t.second, t.third
+}
+
+// ToPairs returns the elements as nested pairs, with each pair holding an element and the pair of the remaining elements.
+//
+// Example usage:
+//
+//	p := tuple.Of3(1, "a", true).ToPairs() // (1, ("a", true))
+func (t T3[A, B, C]) ToPairs() pair.Pair[A, pair.Pair[B, C]] {
+	return pair.Of(t.first, pair.Of(t.second, t.third))
+}
+
+// FromPairs3 returns a T3 with the elements of the provided nested pairs, as returned by T3.ToPairs.
+func FromPairs3[A, B, C any](p pair.Pair[A, pair.Pair[B, C]]) T3[A, B, C] {
+	return T3[A, B, C]{first: p.First(), second: p.Second().First(), third: p.Second().Second()}
+}
+
+// String returns a string representation of the T3, formatted as "(%#v, %#v, %#v)".
+func (t T3[A, B, C]) String() string {
+	return formatValues(t.first, t.second, t.third)
+}
+
+// T4 represents a tuple of 4 values of types A, B, C and D.
+type T4[A, B, C, D any] struct {
+	first  A
+	second B
+	third  C
+	fourth D
+}
+
+// Zero4 returns a new T4 with default zero values for the type parameters.
+func Zero4[A, B, C, D any]() T4[A, B, C, D] {
+	return T4[A, B, C, D]{}
+}
+
+// Of4 creates a new T4 with the provided values.
+//
+// Example usage:
+//
+//	t := tuple.Of4(1, "a", true, 2.5)
+//	out := t.String() // `(1, "a", true, 2.5)`
+func Of4[A, B, C, D any](first A, second B, third C, fourth D) T4[A, B, C, D] {
+	return T4[A, B, C, D]{first: first, second: second, third: third, fourth: fourth}
+}
+
+// First returns the first element of type A.
+func (t T4[A, B, C, D]) First() A {
+	return t.first
+}
+
+// Second returns the second element of type B.
+func (t T4[A, B, C, D]) Second() B {
+	return t.second
+}
+
+// Third returns the third element of type C.
+func (t T4[A, B, C, D]) Third() C {
+	return t.third
+}
+
+// Fourth returns the fourth element of type D.
+func (t T4[A, B, C, D]) Fourth() D {
+	return t.fourth
+}
+
+// Explode returns the elements together as a tuple.
+func (t T4[A, B, C, D]) Explode() (A, B, C, D) {
+	return t.first, t.second, t.third, t.fourth
+}
+
+// ToPairs returns the elements as nested pairs, with each pair holding an element and the pair of the remaining elements.
+//
+// Example usage:
+//
+//	p := tuple.Of4(1, "a", true, 2.5).ToPairs() // (1, ("a", (true, 2.5)))
+func (t T4[A, B, C, D]) ToPairs() pair.Pair[A, pair.Pair[B, pair.Pair[C, D]]] {
+	return pair.Of(t.first, pair.Of(t.second, pair.Of(t.third, t.fourth)))
+}
+
+// FromPairs4 returns a T4 with the elements of the provided nested pairs, as returned by T4.ToPairs.
+func FromPairs4[A, B, C, D any](p pair.Pair[A, pair.Pair[B, pair.Pair[C, D]]]) T4[A, B, C, D] {
+	return T4[A, B, C, D]{first: p.First(), second: p.Second().First(), third: p.Second().Second().First(), fourth: p.Second().Second().Second()}
+}
+
+// String returns a string representation of the T4, formatted as "(%#v, %#v, %#v, %#v)".
+func (t T4[A, B, C, D]) String() string {
+	return formatValues(t.first, t.second, t.third, t.fourth)
+}
+
+// T5 represents a tuple of 5 values of types A, B, C, D and E.
+type T5[A, B, C, D, E any] struct {
+	first  A
+	second B
+	third  C
+	fourth D
+	fifth  E
+}
+
+// Zero5 returns a new T5 with default zero values for the type parameters.
+func Zero5[A, B, C, D, E any]() T5[A, B, C, D, E] {
+	return T5[A, B, C, D, E]{}
+}
+
+// Of5 creates a new T5 with the provided values.
+//
+// Example usage:
+//
+//	t := tuple.Of5(1, "a", true, 2.5, "b")
+//	out := t.String() // `(1, "a", true, 2.5, "b")`
+func Of5[A, B, C, D, E any](first A, second B, third C, fourth D, fifth E) T5[A, B, C, D, E] {
+	return T5[A, B, C, D, E]{first: first, second: second, third: third, fourth: fourth, fifth: fifth}
+}
+
+// First returns the first element of type A.
+func (t T5[A, B, C, D, E]) First() A {
+	return t.first
+}
+
+// Second returns the second element of type B.
+func (t T5[A, B, C, D, E]) Second() B {
+	return t.second
+}
+
+// Third returns the third element of type C.
+func (t T5[A, B, C, D, E]) Third() C {
+	return t.third
+}
+
+// Fourth returns the fourth element of type D.
+func (t T5[A, B, C, D, E]) Fourth() D {
+	return t.fourth
+}
+
+// Fifth returns the fifth element of type E.
+func (t T5[A, B, C, D, E]) Fifth() E {
+	return t.fifth
+}
+
+// Explode returns the elements together as a tuple.
+func (t T5[A, B, C, D, E]) Explode() (A, B, C, D, E) {
+	return t.first, t.second, t.third, t.fourth, t.fifth
+}
+
+// ToPairs returns the elements as nested pairs, with each pair holding an element and the pair of the remaining elements.
+//
+// Example usage:
+//
+//	p := tuple.Of5(1, "a", true, 2.5, "b").ToPairs() // (1, ("a", (true, (2.5, "b"))))
+func (t T5[A, B, C, D, E]) ToPairs() pair.Pair[A, pair.Pair[B, pair.Pair[C, pair.Pair[D, E]]]] {
+	return pair.Of(t.first, pair.Of(t.second, pair.Of(t.third, pair.Of(t.fourth, t.fifth))))
+}
+
+// FromPairs5 returns a T5 with the elements of the provided nested pairs, as returned by T5.ToPairs.
+func FromPairs5[A, B, C, D, E any](p pair.Pair[A, pair.Pair[B, pair.Pair[C, pair.Pair[D, E]]]]) T5[A, B, C, D, E] {
+	return T5[A, B, C, D, E]{first: p.First(), second: p.Second().First(), third: p.Second().Second().First(), fourth: p.Second().Second().Second().First(), fifth: p.Second().Second().Second().Second()}
+}
+
+// String returns a string representation of the T5, formatted as "(%#v, %#v, %#v, %#v, %#v)".
+func (t T5[A, B, C, D, E]) String() string {
+	return formatValues(t.first, t.second, t.third, t.fourth, t.fifth)
+}
+
+func formatValues(values ...any) string {
+	s := make([]string, len(values))
+	for i, v := range values {
+		s[i] = fmt.Sprintf("%#v", v)
+	}
+	return "(" + strings.Join(s, ", ") + ")"
+}
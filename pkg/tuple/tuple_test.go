@@ -0,0 +1,73 @@
+package tuple
+
+import (
+	"testing"
+
+	"github.com/jpfourny/papaya/v2/pkg/pair"
+)
+
+func TestT3(t *testing.T) {
+	tp := Of3(1, "foo", true)
+	if tp.First() != 1 || tp.Second() != "foo" || tp.Third() != true {
+		t.Errorf(`Of3(1, "foo", true) = %v; want (1, "foo", true)`, tp)
+	}
+	if a, b, c := tp.Explode(); a != 1 || b != "foo" || c != true {
+		t.Errorf(`Explode() = (%#v, %#v, %#v); want (1, "foo", true)`, a, b, c)
+	}
+	if got, want := tp.String(), `(1, "foo", true)`; got != want {
+		t.Errorf("String() = %s; want %s", got, want)
+	}
+	if got, want := tp.ToPairs(), pair.Of(1, pair.Of("foo", true)); got != want {
+		t.Errorf("ToPairs() = %v; want %v", got, want)
+	}
+	if got := FromPairs3(tp.ToPairs()); got != tp {
+		t.Errorf("FromPairs3(ToPairs()) = %v; want %v", got, tp)
+	}
+	if got, want := Zero3[int, string, bool](), Of3(0, "", false); got != want {
+		t.Errorf("Zero3() = %v; want %v", got, want)
+	}
+}
+
+func TestT4(t *testing.T) {
+	tp := Of4(1, "foo", true, 2.5)
+	if tp.First() != 1 || tp.Second() != "foo" || tp.Third() != true || tp.Fourth() != 2.5 {
+		t.Errorf(`Of4(1, "foo", true, 2.5) = %v; want (1, "foo", true, 2.5)`, tp)
+	}
+	if a, b, c, d := tp.Explode(); a != 1 || b != "foo" || c != true || d != 2.5 {
+		t.Errorf(`Explode() = (%#v, %#v, %#v, %#v); want (1, "foo", true, 2.5)`, a, b, c, d)
+	}
+	if got, want := tp.String(), `(1, "foo", true, 2.5)`; got != want {
+		t.Errorf("String() = %s; want %s", got, want)
+	}
+	if got, want := tp.ToPairs(), pair.Of(1, pair.Of("foo", pair.Of(true, 2.5))); got != want {
+		t.Errorf("ToPairs() = %v; want %v", got, want)
+	}
+	if got := FromPairs4(tp.ToPairs()); got != tp {
+		t.Errorf("FromPairs4(ToPairs()) = %v; want %v", got, tp)
+	}
+	if got, want := Zero4[int, string, bool, float64](), Of4(0, "", false, 0.0); got != want {
+		t.Errorf("Zero4() = %v; want %v", got, want)
+	}
+}
+
+func TestT5(t *testing.T) {
+	tp := Of5(1, "foo", true, 2.5, 'x')
+	if tp.First() != 1 || tp.Second() != "foo" || tp.Third() != true || tp.Fourth() != 2.5 || tp.Fifth() != 'x' {
+		t.Errorf(`Of5(1, "foo", true, 2.5, 'x') = %v; want (1, "foo", true, 2.5, 120)`, tp)
+	}
+	if a, b, c, d, e := tp.Explode(); a != 1 || b != "foo" || c != true || d != 2.5 || e != 'x' {
+		t.Errorf(`Explode() = (%#v, %#v, %#v, %#v, %#v); want (1, "foo", true, 2.5, 120)`, a, b, c, d, e)
+	}
+	if got, want := tp.String(), `(1, "foo", true, 2.5, 120)`; got != want {
+		t.Errorf("String() = %s; want %s", got, want)
+	}
+	if got, want := tp.ToPairs(), pair.Of(1, pair.Of("foo", pair.Of(true, pair.Of(2.5, 'x')))); got != want {
+		t.Errorf("ToPairs() = %v; want %v", got, want)
+	}
+	if got := FromPairs5(tp.ToPairs()); got != tp {
+		t.Errorf("FromPairs5(ToPairs()) = %v; want %v", got, tp)
+	}
+	if got, want := Zero5[int, string, bool, float64, rune](), Of5(0, "", false, 0.0, rune(0)); got != want {
+		t.Errorf("Zero5() = %v; want %v", got, want)
+	}
+}